@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ngicks/elastic-type/cmd/internal/inputfile"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+var (
+	oldPath = flag.String(
+		"old",
+		"",
+		"filename of the current mapping. panic if empty.\n"+
+			"Contents of the file must be what you can fetch from\n"+
+			"<elasticsearch origin>/<index_name>/_mappings\n"+
+			"json, json with comments and yaml are accepted, detected by extension or contents.",
+	)
+	newPath = flag.String(
+		"new",
+		"",
+		"filename of the new mapping. set -- if you want to read from stdin. panic if empty.\n"+
+			"Contents of the file must be in the same format as -old.",
+	)
+	exitCode = flag.Bool(
+		"exit-code",
+		false,
+		"exit with 1 if there is any breaking change.",
+	)
+	oldIndex = flag.String(
+		"old-index",
+		"",
+		"name of the old index. defaults to the index name in -old.",
+	)
+	newIndex = flag.String(
+		"new-index",
+		"",
+		"name of the new index. defaults to the index name in -new.",
+	)
+	outIndexBody = flag.String(
+		"out-index-body",
+		"",
+		"output filename to write the create index body for the new index. skipped if empty.",
+	)
+	outReindexBody = flag.String(
+		"out-reindex-body",
+		"",
+		"output filename to write the _reindex body. skipped if empty.",
+	)
+	outConvert = flag.String(
+		"out-convert",
+		"",
+		"output filename to write functions converting old types into new ones. skipped if empty.\n"+
+			"Options below must be same as ones passed to generate-es-type for each mapping.",
+	)
+	pkgName   = flag.String("pkg", "example", "package of -out-convert.")
+	oldImport = flag.String(
+		"old-import",
+		"",
+		"import path of the package where old types are generated. leave empty if it is same as -pkg.",
+	)
+	newImport = flag.String(
+		"new-import",
+		"",
+		"import path of the package where new types are generated. leave empty if it is same as -pkg.",
+	)
+	oldMapOptPath       = flag.String("old-map-option", "", "path to generate.MapOption json or yaml for the old mapping.")
+	oldGlobalOptPath    = flag.String("old-global-option", "", "path to generate.GlobalOption json or yaml for the old mapping.")
+	newMapOptPath       = flag.String("new-map-option", "", "path to generate.MapOption json or yaml for the new mapping.")
+	newGlobalOptPath    = flag.String("new-global-option", "", "path to generate.GlobalOption json or yaml for the new mapping.")
+	prefixWithIndexName = flag.Bool(
+		"prefix-with-index-name",
+		false,
+		"whether types are prefixed with index name.",
+	)
+)
+
+func main() {
+	flag.Parse()
+
+	if *oldPath == "" || *newPath == "" {
+		panic("old or new is empty")
+	}
+
+	oldName, oldMappings := readMappings(*oldPath)
+	newName, newMappings := readMappings(*newPath)
+	if *oldIndex != "" {
+		oldName = *oldIndex
+	}
+	if *newIndex != "" {
+		newName = *newIndex
+	}
+
+	if *outIndexBody == "" && *outReindexBody == "" && *outConvert == "" {
+		changes := mapping.Diff(oldMappings, newMappings)
+		fmt.Print(changes.String())
+		exit(changes)
+		return
+	}
+
+	var oldHighLevelTy, newHighLevelTy []generate.GeneratedType
+	if *outConvert != "" {
+		oldHighLevelTy = generateHighLevel(oldMappings, oldName, *oldGlobalOptPath, *oldMapOptPath)
+		newHighLevelTy = generateHighLevel(newMappings, newName, *newGlobalOptPath, *newMapOptPath)
+	}
+
+	plan := generate.GenerateMigration(
+		oldMappings,
+		newMappings,
+		oldName,
+		newName,
+		oldHighLevelTy,
+		newHighLevelTy,
+		generate.MigrationOption{
+			OldImportPath: *oldImport,
+			NewImportPath: *newImport,
+		},
+	)
+
+	writeJSON(*outIndexBody, plan.IndexBody)
+	writeJSON(*outReindexBody, plan.ReindexBody)
+	if *outConvert != "" {
+		err := generate.WriteTypes(*outConvert, plan.Converter, *pkgName)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	fmt.Print(plan.String())
+	exit(plan.Changes)
+}
+
+func exit(changes mapping.Changes) {
+	if *exitCode && changes.Breaking() {
+		os.Exit(1)
+	}
+}
+
+func generateHighLevel(
+	mappings mapping.Mappings,
+	indexName, globalOptPath, mapOptPath string,
+) []generate.GeneratedType {
+	mapOpt := generate.MapOption{}
+	globalOpt := generate.GlobalOption{}
+
+	if mapOptPath != "" {
+		decode(mapOptPath, &mapOpt)
+	}
+	if globalOptPath != "" {
+		decode(globalOptPath, &globalOpt)
+	}
+	if *prefixWithIndexName {
+		globalOpt.TypeNameGenerator.PostProcess = []generate.TypeNamePostProcessRule{
+			generate.Prefix(indexName, 1),
+			generate.PascalCaseUnderscoreHyphen(),
+		}
+	}
+
+	highLevelTy, _, _, err := generate.Generate(mappings, indexName, globalOpt, mapOpt)
+	if err != nil {
+		panic(err)
+	}
+	return highLevelTy
+}
+
+func writeJSON(filename string, v any) {
+	if filename == "" {
+		return
+	}
+	bin, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	err = os.WriteFile(filename, append(bin, '\n'), 0o644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func readMappings(filename string) (indexName string, mappings mapping.Mappings) {
+	var settings mapping.MappingSettings
+	decode(filename, &settings)
+
+	for k, v := range settings {
+		if v.Mappings == nil {
+			panic(fmt.Sprintf("%s: no mappings", filename))
+		}
+		return k, *v.Mappings
+	}
+	panic(fmt.Sprintf("%s: empty mappings settings", filename))
+}
+
+// decode decodes JSON, JSON with comments or YAML in filename into v. filename "--" means stdin.
+func decode(filename string, v any) {
+	err := inputfile.Decode(filename, v)
+	if err != nil {
+		panic(err)
+	}
+}
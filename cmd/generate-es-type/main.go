@@ -1,11 +1,10 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
-	"io"
-	"os"
+	"fmt"
 
+	"github.com/ngicks/elastic-type/cmd/internal/inputfile"
 	"github.com/ngicks/elastic-type/generate"
 	"github.com/ngicks/elastic-type/mapping"
 )
@@ -16,6 +15,7 @@ var (
 		"i",
 		"--",
 		"input filename. set -- if you want to read from stdin.\n"+
+			"json, json with comments and yaml are accepted, detected by extension or contents.\n"+
 			"Contents of the file must be what you can fetch from\n"+
 			"<elasticsearch origin>/<index_name>/_mappings",
 	)
@@ -34,17 +34,64 @@ var (
 		"",
 		"output filename to write tests for types. currently it only generates for date type.",
 	)
+	outQuery = flag.String(
+		"out-query",
+		"",
+		"output filename to write typed query helpers. skipped if empty.",
+	)
+	outFields = flag.String(
+		"out-fields",
+		"",
+		"output filename to write typed field paths. skipped if empty.",
+	)
+	outRuntime = flag.String(
+		"out-runtime",
+		"",
+		"output filename to write a type for runtime fields, decoded from fields of search hits. skipped if empty.",
+	)
 	mapOptPath = flag.String(
 		"map-option",
 		"",
-		"path to a json file that can be unmarshalled to generate.MapOption.",
+		"path to a json or yaml file that can be unmarshalled to generate.MapOption.",
 	)
 	globalOptPath = flag.String(
 		"global-option",
 		"",
-		"path to a json file that con be unmarshalled to generate.GlobalOption. "+
+		"path to a json or yaml file that can be unmarshalled to generate.GlobalOption. "+
 			"TypeNameGenerator field must be empty.",
 	)
+	indexTemplate = flag.String(
+		"index-template",
+		"",
+		"input filename of index templates. if set, -i is ignored.\n"+
+			"Contents of the file must be what you can fetch from\n"+
+			"<elasticsearch origin>/_index_template",
+	)
+	componentTemplate = flag.String(
+		"component-template",
+		"",
+		"input filename of component templates which the index template is composed of.\n"+
+			"Contents of the file must be what you can fetch from\n"+
+			"<elasticsearch origin>/_component_template",
+	)
+	templateName = flag.String(
+		"template",
+		"",
+		"name of the index template to generate from.\n"+
+			"If empty, the template that matches -index-name with the highest priority is used,\n"+
+			"or the only template in -index-template.",
+	)
+	indexNameFlag = flag.String(
+		"index-name",
+		"",
+		"index name used for type names instead of the one in the input. defaults to the template name for -index-template.",
+	)
+	targetVersion = flag.String(
+		"target-version",
+		"",
+		"Elasticsearch version the generated code targets, e.g. 8.15. overrides TargetVersion of -global-option.\n"+
+			"Types added after the version are treated as unknown types. defaults to 8.4.",
+	)
 	prefixWithIndexName = flag.Bool(
 		"prefix-with-index-name",
 		false,
@@ -55,46 +102,35 @@ var (
 func main() {
 	flag.Parse()
 
-	if *pkgName == "" || *input == "" || *outHigh == "" || *outRaw == "" {
+	if *pkgName == "" || (*input == "" && *indexTemplate == "") || *outHigh == "" || *outRaw == "" {
 		panic("pkgName, input, outHigh or outRaw is empty")
 	}
 
-	var inFile *os.File
-	if *input == "--" {
-		inFile = os.Stdin
+	var indexName string
+	var mappings mapping.Mappings
+	if *indexTemplate != "" {
+		indexName, mappings = readTemplate()
 	} else {
-		var err error
-		inFile, err = os.Open(*input)
-		if err != nil {
-			panic(err)
-		}
-		defer inFile.Close()
+		var settings mapping.MappingSettings
+		decode(*input, &settings)
+		indexName, mappings = getFirst(settings)
 	}
-
-	var err error
-	bin, err := io.ReadAll(inFile)
-	if err != nil {
-		panic(err)
-	}
-
-	var settings mapping.MappingSettings
-
-	err = json.Unmarshal(bin, &settings)
-	if err != nil {
-		panic(err)
+	if *indexNameFlag != "" {
+		indexName = *indexNameFlag
 	}
 
 	mapOpt := generate.MapOption{}
 	globalOpt := generate.GlobalOption{}
 
-	indexName, mappings := getFirst(settings)
-
 	if *mapOptPath != "" {
 		decode(*mapOptPath, &mapOpt)
 	}
 	if *globalOptPath != "" {
 		decode(*globalOptPath, &globalOpt)
 	}
+	if *targetVersion != "" {
+		globalOpt.TargetVersion = *targetVersion
+	}
 	if *prefixWithIndexName {
 		globalOpt.TypeNameGenerator.PostProcess = []generate.TypeNamePostProcessRule{
 			generate.Prefix(indexName, 1),
@@ -112,6 +148,16 @@ func main() {
 		panic(err)
 	}
 
+	var runtimeTy []generate.GeneratedType
+	if *outRuntime != "" {
+		var runtimeTestDef []generate.GeneratedType
+		runtimeTy, runtimeTestDef, err = generate.GenerateRuntime(mappings, highLevelTy, globalOpt)
+		if err != nil {
+			panic(err)
+		}
+		testDef = append(testDef, runtimeTestDef...)
+	}
+
 	err = generate.WriteFile(
 		*outHigh,
 		*outRaw,
@@ -124,18 +170,63 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+
+	if *outQuery != "" {
+		err = generate.WriteTypes(*outQuery, generate.GenerateQuery(highLevelTy), *pkgName)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if *outRuntime != "" {
+		err = generate.WriteTypes(*outRuntime, runtimeTy, *pkgName)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if *outFields != "" {
+		err = generate.WriteTypes(*outFields, generate.GenerateFieldPaths(highLevelTy), *pkgName)
+		if err != nil {
+			panic(err)
+		}
+	}
 }
 
-func decode(filename string, v any) {
-	f, err := os.Open(filename)
-	if err != nil {
-		panic(err)
+func readTemplate() (indexName string, mappings mapping.Mappings) {
+	var templates mapping.IndexTemplatesResponse
+	decode(*indexTemplate, &templates)
+
+	var components mapping.ComponentTemplatesResponse
+	if *componentTemplate != "" {
+		decode(*componentTemplate, &components)
 	}
-	bin, err := io.ReadAll(f)
+
+	var template mapping.IndexTemplate
+	var ok bool
+	switch {
+	case *templateName != "":
+		indexName = *templateName
+		template, ok = templates.Get(*templateName)
+	case *indexNameFlag != "":
+		indexName, template, ok = templates.Match(*indexNameFlag)
+	case len(templates.IndexTemplates) == 1:
+		indexName, template, ok = templates.IndexTemplates[0].Name, templates.IndexTemplates[0].IndexTemplate, true
+	default:
+		panic("-template or -index-name must be set if -index-template has more than one templates")
+	}
+	if !ok {
+		panic(fmt.Sprintf("no index template found: template = %q, index name = %q", *templateName, *indexNameFlag))
+	}
+
+	mappings, err := template.Compose(components.Map())
 	if err != nil {
 		panic(err)
 	}
-	err = json.Unmarshal(bin, v)
+	return indexName, mappings
+}
+
+// decode decodes JSON, JSON with comments or YAML in filename into v. filename "--" means stdin.
+func decode(filename string, v any) {
+	err := inputfile.Decode(filename, v)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,265 @@
+// Package inputfile reads input files of commands.
+//
+// Inputs can be JSON, JSON with comments or YAML.
+// They are converted into JSON and then unmarshalled by encoding/json,
+// so that custom UnmarshalJSON methods of option and mapping types apply to every format.
+package inputfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format int
+
+const (
+	// Detect detects format by content. Contents starting with { or [ are JSON with comments, others are YAML.
+	Detect Format = iota
+	JSON
+	// JSONC is JSON with comments. // and /* */ comments and trailing commas are allowed.
+	JSONC
+	YAML
+)
+
+// FormatOf returns format of filename detected by its extension.
+// .json is treated as JSONC since JSONC is a superset of JSON.
+// It returns Detect for other extensions, including stdin ("--").
+func FormatOf(filename string) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json", ".jsonc":
+		return JSONC
+	case ".yaml", ".yml":
+		return YAML
+	}
+	return Detect
+}
+
+// Decode reads filename and unmarshals it into v. filename "--" means stdin.
+func Decode(filename string, v any) error {
+	var r io.Reader
+	if filename == "--" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	bin, err := ToJSON(data, FormatOf(filename))
+	if err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+	if err := json.Unmarshal(bin, v); err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+	return nil
+}
+
+// ToJSON converts data in format into JSON.
+func ToJSON(data []byte, format Format) ([]byte, error) {
+	if format == Detect {
+		trimmed := bytes.TrimLeft(stripComments(data), " \t\r\n")
+		if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+			format = JSONC
+		} else {
+			format = YAML
+		}
+	}
+
+	switch format {
+	case JSON:
+		return data, nil
+	case JSONC:
+		return stripTrailingCommas(stripComments(data)), nil
+	case YAML:
+		return yamlToJSON(data)
+	}
+	return nil, fmt.Errorf("unknown format %d", format)
+}
+
+// stripComments replaces comments outside of strings with spaces, keeping offsets of errors.
+func stripComments(data []byte) []byte {
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	var inString, escaped bool
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			out[i], out[i+1] = ' ', ' '
+			for i += 2; i < len(out) && !(out[i] == '*' && i+1 < len(out) && out[i+1] == '/'); i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			if i+1 < len(out) {
+				out[i], out[i+1] = ' ', ' '
+				i++
+			}
+		}
+	}
+	return out
+}
+
+// stripTrailingCommas removes commas followed by } or ] outside of strings.
+// data must have no comments.
+func stripTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	var inString, escaped bool
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			out = append(out, c)
+			continue
+		}
+		if c == '"' {
+			inString = true
+		}
+		if c == ',' {
+			j := i + 1
+			for j < len(data) && (data[j] == ' ' || data[j] == '\t' || data[j] == '\r' || data[j] == '\n') {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func yamlToJSON(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	if node.Kind == 0 {
+		// empty document.
+		return []byte("null"), nil
+	}
+	v, err := nodeToAny(&node)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// nodeToAny converts node into a value json.Marshal accepts.
+// Scalars are converted by their resolved tags. Timestamps are left as strings.
+func nodeToAny(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return nodeToAny(node.Content[0])
+	case yaml.AliasNode:
+		return nodeToAny(node.Alias)
+	case yaml.SequenceNode:
+		out := make([]any, 0, len(node.Content))
+		for _, elem := range node.Content {
+			v, err := nodeToAny(elem)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case yaml.MappingNode:
+		out := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				merged, err := nodeToAny(value)
+				if err != nil {
+					return nil, err
+				}
+				if m, ok := merged.(map[string]any); ok {
+					for k, v := range m {
+						if _, ok := out[k]; !ok {
+							out[k] = v
+						}
+					}
+				}
+				continue
+			}
+			if key.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: mapping key must be a scalar", key.Line)
+			}
+			v, err := nodeToAny(value)
+			if err != nil {
+				return nil, err
+			}
+			out[key.Value] = v
+		}
+		return out, nil
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!null":
+			return nil, nil
+		case "!!bool":
+			var b bool
+			if err := node.Decode(&b); err != nil {
+				return nil, err
+			}
+			return b, nil
+		case "!!int":
+			var i int64
+			if err := node.Decode(&i); err != nil {
+				var u uint64
+				if err := node.Decode(&u); err != nil {
+					return nil, err
+				}
+				return u, nil
+			}
+			return i, nil
+		case "!!float":
+			var f float64
+			if err := node.Decode(&f); err != nil {
+				return nil, err
+			}
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+		}
+		return node.Value, nil
+	}
+	return nil, fmt.Errorf("line %d: unknown yaml node", node.Line)
+}
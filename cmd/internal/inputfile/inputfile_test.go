@@ -0,0 +1,187 @@
+package inputfile_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ngicks/elastic-type/cmd/internal/inputfile"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeContent[T any](t *testing.T, name, content string) T {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	var v T
+	require.NoError(t, inputfile.Decode(path, &v))
+	return v
+}
+
+func TestGlobalOption(t *testing.T) {
+	require := require.New(t)
+
+	var expected generate.GlobalOption
+	require.NoError(json.Unmarshal([]byte(`{
+		"IsRequired": true,
+		"IsSingle": "false",
+		"PreferStringBoolean": "",
+		"PreferTimeEpochMarshalling": "maybe",
+		"UnknownTypeAsRaw": false,
+		"TargetVersion": "8.15",
+		"TypeOption": {
+			"keyword": {"IsSingle": "true"}
+		}
+	}`), &expected))
+	require.Equal(generate.True, expected.IsRequired)
+	require.Equal(generate.False, expected.IsSingle)
+	require.Equal(generate.None, expected.PreferTimeEpochMarshalling)
+
+	for name, content := range map[string]string{
+		"global.yaml": `
+IsRequired: true
+IsSingle: "false"
+PreferStringBoolean: ""
+PreferTimeEpochMarshalling: maybe
+UnknownTypeAsRaw: false
+TargetVersion: "8.15"
+TypeOption:
+  keyword:
+    IsSingle: "true"
+`,
+		"global.jsonc": `{
+	// bare booleans and strings are both accepted.
+	"IsRequired": true,
+	"IsSingle": "false", /* string "false" */
+	"PreferStringBoolean": "",
+	"PreferTimeEpochMarshalling": "maybe",
+	"UnknownTypeAsRaw": false,
+	"TargetVersion": "8.15",
+	"TypeOption": {
+		"keyword": {"IsSingle": "true"},
+	},
+}`,
+		// no extension: detected by contents.
+		"global": `IsRequired: true
+IsSingle: 'false'
+PreferStringBoolean: ''
+PreferTimeEpochMarshalling: maybe
+UnknownTypeAsRaw: false
+TargetVersion: '8.15'
+TypeOption: {keyword: {IsSingle: 'true'}}
+`,
+	} {
+		require.Equal(expected, decodeContent[generate.GlobalOption](t, name, content), name)
+	}
+}
+
+func TestMapOption(t *testing.T) {
+	require := require.New(t)
+
+	var expected generate.MapOption
+	require.NoError(json.Unmarshal([]byte(`{
+		"name": {"IsRequired": "true", "ChildOption": {"first": {"IsSingle": false}}},
+		"created_at": {"PreferredTimeMarshallingFormat": "2006-01-02", "PreferTimeEpochMarshalling": ""}
+	}`), &expected))
+
+	for name, content := range map[string]string{
+		"map.yml": `
+name:
+  IsRequired: "true"
+  ChildOption:
+    first:
+      IsSingle: false
+created_at:
+  PreferredTimeMarshallingFormat: "2006-01-02"
+  PreferTimeEpochMarshalling: ""
+`,
+		"map": `// detected as json with comments.
+{
+	"name": {"IsRequired": "true", "ChildOption": {"first": {"IsSingle": false}}},
+	"created_at": {"PreferredTimeMarshallingFormat": "2006-01-02", "PreferTimeEpochMarshalling": ""},
+}`,
+	} {
+		require.Equal(expected, decodeContent[generate.MapOption](t, name, content), name)
+	}
+}
+
+func TestMappings(t *testing.T) {
+	require := require.New(t)
+
+	var expected mapping.MappingSettings
+	require.NoError(json.Unmarshal([]byte(`{
+		"doc": {
+			"mappings": {
+				"dynamic": "strict",
+				"properties": {
+					"name": {"type": "keyword", "ignore_above": 256},
+					"url": {"type": "keyword", "meta": {"note": "// not a comment"}},
+					"price": {"type": "scaled_float", "scaling_factor": 100},
+					"created_at": {"type": "date", "format": "yyyy-MM-dd"}
+				}
+			}
+		}
+	}`), &expected))
+
+	for name, content := range map[string]string{
+		"mappings.yaml": `
+doc:
+  mappings:
+    dynamic: strict
+    properties:
+      name: {type: keyword, ignore_above: 256}
+      url:
+        type: keyword
+        meta:
+          note: "// not a comment"
+      price:
+        type: scaled_float
+        scaling_factor: 100
+      created_at:
+        type: date
+        format: yyyy-MM-dd
+`,
+		"mappings.json": `{
+	"doc": {
+		"mappings": {
+			"dynamic": "strict",
+			"properties": {
+				"name": {"type": "keyword", "ignore_above": 256},
+				"url": {"type": "keyword", "meta": {"note": "// not a comment"}}, // this is.
+				"price": {"type": "scaled_float", "scaling_factor": 100},
+				"created_at": {"type": "date", "format": "yyyy-MM-dd"},
+			}
+		}
+	}
+}`,
+	} {
+		require.Equal(expected, decodeContent[mapping.MappingSettings](t, name, content), name)
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		format   inputfile.Format
+		input    string
+		expected string
+	}{
+		{inputfile.JSONC, `{"a": "/* x */", /* y */ "b": [1, 2,],}`, `{"a": "/* x */", "b": [1, 2]}`},
+		{inputfile.JSONC, `{"a\"//": 1 // c` + "\n}", `{"a\"//": 1}`},
+		{inputfile.YAML, "a: 1.5\nb: null\nc: 2022-01-01\nd: [yes, on]\n", `{"a": 1.5, "b": null, "c": "2022-01-01", "d": ["yes", "on"]}`},
+		{inputfile.YAML, "base: &b {x: 1}\nderived:\n  <<: *b\n  y: 2\n", `{"base": {"x": 1}, "derived": {"x": 1, "y": 2}}`},
+		{inputfile.Detect, "# comment\na: true\n", `{"a": true}`},
+		{inputfile.Detect, "  [1, /* x */ 2]", `[1, 2]`},
+	} {
+		bin, err := inputfile.ToJSON([]byte(tc.input), tc.format)
+		require.NoError(err, tc.input)
+		require.JSONEq(tc.expected, string(bin), tc.input)
+	}
+
+	_, err := inputfile.ToJSON([]byte("? [a]\n: b\n"), inputfile.YAML)
+	require.Error(err)
+}
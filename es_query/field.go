@@ -0,0 +1,179 @@
+package esquery
+
+// ExistsField is a query helper for a field which only supports the exists query.
+// Every other field helper embeds this.
+type ExistsField struct {
+	path string
+}
+
+func NewExistsField(path string) ExistsField {
+	return ExistsField{path: path}
+}
+
+// Path returns the dotted full path to the field.
+func (f ExistsField) Path() string {
+	return f.path
+}
+
+// Exists returns an exists query, matching documents that contain an indexed value for the field.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-exists-query.html
+func (f ExistsField) Exists() Query {
+	return Query{
+		"exists": map[string]any{
+			"field": f.path,
+		},
+	}
+}
+
+// ObjectField is a query helper for an object field.
+type ObjectField struct {
+	ExistsField
+}
+
+func NewObjectField(path string) ObjectField {
+	return ObjectField{ExistsField: NewExistsField(path)}
+}
+
+// NestedField is a query helper for a nested field.
+// Queries against fields under a nested field must be wrapped by Wrap.
+type NestedField struct {
+	ExistsField
+}
+
+func NewNestedField(path string) NestedField {
+	return NestedField{ExistsField: NewExistsField(path)}
+}
+
+// Wrap wraps query with a nested query for the field.
+func (f NestedField) Wrap(query Query) Query {
+	return Nested(f.path, query)
+}
+
+// TermField is a query helper for a field whose value can be compared exactly.
+// T is the Go type of the field value.
+type TermField[T any] struct {
+	ExistsField
+}
+
+func NewTermField[T any](path string) TermField[T] {
+	return TermField[T]{ExistsField: NewExistsField(path)}
+}
+
+// Term returns a term query, matching documents that contain exactly value.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-term-query.html
+func (f TermField[T]) Term(value T, opts ...TermOption) Query {
+	return Query{
+		"term": map[string]any{
+			f.path: applyTermOptions(map[string]any{"value": value}, opts),
+		},
+	}
+}
+
+// Terms returns a terms query, matching documents that contain one or more of values.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-terms-query.html
+func (f TermField[T]) Terms(values ...T) Query {
+	if values == nil {
+		values = []T{}
+	}
+	return Query{
+		"terms": map[string]any{
+			f.path: values,
+		},
+	}
+}
+
+// RangeField is a query helper for a field whose value is ordered.
+// T is the Go type of the field value.
+type RangeField[T any] struct {
+	TermField[T]
+}
+
+func NewRangeField[T any](path string) RangeField[T] {
+	return RangeField[T]{TermField: NewTermField[T](path)}
+}
+
+// Range returns a range query, matching documents whose value is within bounds.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-range-query.html
+func (f RangeField[T]) Range(bounds ...RangeBound[T]) Query {
+	params := map[string]any{}
+	for _, bound := range bounds {
+		bound(params)
+	}
+	return Query{
+		"range": map[string]any{
+			f.path: params,
+		},
+	}
+}
+
+// KeywordField is a query helper for keyword family fields.
+//
+// Unlike TextField, Match of it takes no analysis options,
+// since the query string is analyzed by the normalizer of the field, if any.
+type KeywordField[T any] struct {
+	RangeField[T]
+}
+
+func NewKeywordField[T any](path string) KeywordField[T] {
+	return KeywordField[T]{RangeField: NewRangeField[T](path)}
+}
+
+// Prefix returns a prefix query, matching documents whose value starts with prefix.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-prefix-query.html
+func (f KeywordField[T]) Prefix(prefix string, opts ...TermOption) Query {
+	return prefixQuery(f.path, prefix, opts)
+}
+
+// Match returns a match query for value.
+func (f KeywordField[T]) Match(value T) Query {
+	return Query{
+		"match": map[string]any{
+			f.path: map[string]any{"query": value},
+		},
+	}
+}
+
+// TextField is a query helper for full-text fields.
+// T is the Go type of the field value.
+type TextField[T any] struct {
+	TermField[T]
+}
+
+func NewTextField[T any](path string) TextField[T] {
+	return TextField[T]{TermField: NewTermField[T](path)}
+}
+
+// Prefix returns a prefix query, matching documents that contain a term starting with prefix.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-prefix-query.html
+func (f TextField[T]) Prefix(prefix string, opts ...TermOption) Query {
+	return prefixQuery(f.path, prefix, opts)
+}
+
+// Match returns a match query, which analyzes query and searches with produced tokens.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-match-query.html
+func (f TextField[T]) Match(query string, opts ...MatchOption) Query {
+	params := map[string]any{"query": query}
+	for _, opt := range opts {
+		opt(params)
+	}
+	return Query{
+		"match": map[string]any{
+			f.path: params,
+		},
+	}
+}
+
+func prefixQuery(path string, prefix string, opts []TermOption) Query {
+	return Query{
+		"prefix": map[string]any{
+			path: applyTermOptions(map[string]any{"value": prefix}, opts),
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package esquery
+
+import "github.com/ngicks/elastic-type/mapping"
+
+// FieldPath is a dotted full path to a field, with the Elasticsearch type of the field.
+type FieldPath struct {
+	path   string
+	esType mapping.EsType
+}
+
+func NewFieldPath(path string, esType mapping.EsType) FieldPath {
+	return FieldPath{
+		path:   path,
+		esType: esType,
+	}
+}
+
+// Path returns the dotted full path to the field.
+func (p FieldPath) Path() string {
+	return p.path
+}
+
+// EsType returns the Elasticsearch type of the field.
+func (p FieldPath) EsType() mapping.EsType {
+	return p.esType
+}
+
+func (p FieldPath) String() string {
+	return p.path
+}
@@ -0,0 +1,85 @@
+package esquery_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/google/go-cmp/cmp"
+	esquery "github.com/ngicks/elastic-type/es_query"
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func toAny(t *testing.T, v any) any {
+	bin, err := json.Marshal(v)
+	require.NoError(t, err)
+	var out any
+	require.NoError(t, json.Unmarshal(bin, &out))
+	return out
+}
+
+func TestQuery(t *testing.T) {
+	kwd := esquery.NewKeywordField[string]("user.name.keyword")
+	text := esquery.NewTextField[string]("user.name")
+	date := esquery.NewRangeField[estype.EpochMillis]("created")
+	boolean := esquery.NewTermField[estype.Boolean]("enabled")
+
+	created := estype.EpochMillis(time.UnixMilli(1666282966123))
+
+	type testCase struct {
+		query    esquery.Query
+		expected string
+		// TermsQuery of the typed API v8.4.0 has no UnmarshalJSON,
+		// so it drops field values when decoded.
+		lossyInTypedAPI bool
+	}
+	for _, tc := range []testCase{
+		{kwd.Term("john", esquery.CaseInsensitive(true)), `{"term":{"user.name.keyword":{"value":"john","case_insensitive":true}}}`, false},
+		{kwd.Terms("john", "jane"), `{"terms":{"user.name.keyword":["john","jane"]}}`, true},
+		{kwd.Prefix("jo"), `{"prefix":{"user.name.keyword":{"value":"jo"}}}`, false},
+		{kwd.Match("john"), `{"match":{"user.name.keyword":{"query":"john"}}}`, false},
+		{kwd.Exists(), `{"exists":{"field":"user.name.keyword"}}`, false},
+		{
+			text.Match("john doe", esquery.Analyzer("standard"), esquery.Operator("and")),
+			`{"match":{"user.name":{"query":"john doe","analyzer":"standard","operator":"and"}}}`,
+			false,
+		},
+		{date.Range(esquery.Gte(created), esquery.Lt(created)), `{"range":{"created":{"gte":1666282966123,"lt":1666282966123}}}`, false},
+		{boolean.Term(true), `{"term":{"enabled":{"value":true}}}`, false},
+		{
+			esquery.Bool(esquery.BoolQuery{
+				Filter: []esquery.Query{boolean.Term(true)},
+				Must:   []esquery.Query{text.Match("john")},
+			}),
+			`{"bool":{"must":[{"match":{"user.name":{"query":"john"}}}],"filter":[{"term":{"enabled":{"value":true}}}]}}`,
+			false,
+		},
+		{
+			esquery.NewNestedField("user").Wrap(kwd.Term("john")),
+			`{"nested":{"path":"user","query":{"term":{"user.name.keyword":{"value":"john"}}}}}`,
+			false,
+		},
+	} {
+		var expected any
+		require.NoError(t, json.Unmarshal([]byte(tc.expected), &expected))
+		actual := toAny(t, tc.query)
+		require.Empty(t, cmp.Diff(expected, actual))
+
+		// The typed API must accept the query body as is.
+		bin, err := json.Marshal(tc.query)
+		require.NoError(t, err)
+		var container types.QueryContainer
+		require.NoError(t, json.Unmarshal(bin, &container))
+		if !tc.lossyInTypedAPI {
+			require.Empty(t, cmp.Diff(expected, toAny(t, container)))
+		}
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	require.Equal(t, "a.b", esquery.JoinPath("", "a", "b"))
+	require.Equal(t, "a", esquery.JoinPath("a", ""))
+	require.Equal(t, "", esquery.JoinPath())
+}
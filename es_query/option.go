@@ -0,0 +1,76 @@
+package esquery
+
+// RangeBound is a bound of the range query.
+type RangeBound[T any] func(params map[string]any)
+
+// Gt bounds a range query to values greater than v.
+func Gt[T any](v T) RangeBound[T] {
+	return func(params map[string]any) { params["gt"] = v }
+}
+
+// Gte bounds a range query to values greater than or equal to v.
+func Gte[T any](v T) RangeBound[T] {
+	return func(params map[string]any) { params["gte"] = v }
+}
+
+// Lt bounds a range query to values less than v.
+func Lt[T any](v T) RangeBound[T] {
+	return func(params map[string]any) { params["lt"] = v }
+}
+
+// Lte bounds a range query to values less than or equal to v.
+func Lte[T any](v T) RangeBound[T] {
+	return func(params map[string]any) { params["lte"] = v }
+}
+
+// TermOption is an option for term level queries, namely the term and the prefix query.
+type TermOption func(params map[string]any)
+
+// Boost sets the boost, a multiplier of relevance scores of the query.
+// Values between 0 and 1 decrease scores, and values greater than 1 increase them. Defaults to 1.0.
+func Boost(boost float32) TermOption {
+	return func(params map[string]any) { params["boost"] = boost }
+}
+
+// CaseInsensitive allows ASCII case insensitive matching of the value with the indexed field values.
+func CaseInsensitive(caseInsensitive bool) TermOption {
+	return func(params map[string]any) { params["case_insensitive"] = caseInsensitive }
+}
+
+func applyTermOptions(params map[string]any, opts []TermOption) map[string]any {
+	for _, opt := range opts {
+		opt(params)
+	}
+	return params
+}
+
+// MatchOption is an option for the match query.
+type MatchOption func(params map[string]any)
+
+// Analyzer sets the analyzer used to convert the query text into tokens.
+// Defaults to the search analyzer of the field.
+func Analyzer(analyzer string) MatchOption {
+	return func(params map[string]any) { params["analyzer"] = analyzer }
+}
+
+// Operator sets the boolean logic used to interpret the tokens, "or" or "and".
+// Defaults to "or".
+func Operator(operator string) MatchOption {
+	return func(params map[string]any) { params["operator"] = operator }
+}
+
+// Fuzziness sets the maximum edit distance allowed for matching, e.g. "AUTO".
+func Fuzziness(fuzziness string) MatchOption {
+	return func(params map[string]any) { params["fuzziness"] = fuzziness }
+}
+
+// MinimumShouldMatch sets the minimum number of tokens which must match for a document to be returned,
+// e.g. "2", "75%" or "3<90%".
+func MinimumShouldMatch(minimumShouldMatch string) MatchOption {
+	return func(params map[string]any) { params["minimum_should_match"] = minimumShouldMatch }
+}
+
+// MatchBoost is same as Boost, but for the match query.
+func MatchBoost(boost float32) MatchOption {
+	return func(params map[string]any) { params["boost"] = boost }
+}
@@ -0,0 +1,51 @@
+package esquery
+
+import "strings"
+
+// Query is a query clause of the Elasticsearch query DSL.
+//
+// It marshals into a JSON object that the search API accepts as the query body,
+// which is also what QueryContainer of the go-elasticsearch typed API can be unmarshalled from.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl.html
+type Query map[string]any
+
+// BoolQuery is a set of clauses of the bool query.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-bool-query.html
+type BoolQuery struct {
+	Must               []Query `json:"must,omitempty"`
+	Filter             []Query `json:"filter,omitempty"`
+	Should             []Query `json:"should,omitempty"`
+	MustNot            []Query `json:"must_not,omitempty"`
+	MinimumShouldMatch *string `json:"minimum_should_match,omitempty"`
+}
+
+// Bool returns a bool query consists of b.
+func Bool(b BoolQuery) Query {
+	return Query{"bool": b}
+}
+
+// Nested returns a nested query which searches query against nested objects at path.
+//
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/8.4/query-dsl-nested-query.html
+func Nested(path string, query Query) Query {
+	return Query{
+		"nested": map[string]any{
+			"path":  path,
+			"query": query,
+		},
+	}
+}
+
+// JoinPath joins field names with dot.
+// Empty names are skipped, so the prefix of the document root can be an empty string.
+func JoinPath(names ...string) string {
+	nonEmpty := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != "" {
+			nonEmpty = append(nonEmpty, name)
+		}
+	}
+	return strings.Join(nonEmpty, ".")
+}
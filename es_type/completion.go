@@ -0,0 +1,211 @@
+package estype
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Completion is a value of completion fields.
+//
+// It is unmarshalled from a string, an array of strings,
+// or an object of input, weight and contexts, e.g. {"input": ["Nevermind", "Nirvana"], "weight": 34}.
+// It is marshalled into the object form.
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/search-suggesters.html#indexing
+type Completion struct {
+	// Input is inputs to be suggested.
+	Input []string
+	// Weight ranks suggestions. It must be non-negative. Nil if not set.
+	Weight *int32
+	// Contexts are values of contexts keyed by context names declared in the mapping.
+	Contexts map[string]CompletionContextValues
+}
+
+type completionObject struct {
+	Input    json.RawMessage                    `json:"input"`
+	Weight   json.RawMessage                    `json:"weight,omitempty"`
+	Contexts map[string]CompletionContextValues `json:"contexts,omitempty"`
+}
+
+func (c *Completion) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		input, err := unmarshalStrings(data)
+		if err != nil {
+			return err
+		}
+		*c = Completion{Input: input}
+		return nil
+	}
+
+	var obj completionObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	input, err := unmarshalStrings(obj.Input)
+	if err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	weight, err := unmarshalWeight(obj.Weight)
+	if err != nil {
+		return err
+	}
+	*c = Completion{Input: input, Weight: weight, Contexts: obj.Contexts}
+	return nil
+}
+
+func (c Completion) MarshalJSON() ([]byte, error) {
+	if c.Weight != nil && *c.Weight < 0 {
+		return nil, fmt.Errorf("weight must be non-negative, but is %d", *c.Weight)
+	}
+	input := c.Input
+	if input == nil {
+		input = []string{}
+	}
+	inputBin, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	obj := completionObject{Input: inputBin, Contexts: c.Contexts}
+	if c.Weight != nil {
+		obj.Weight = []byte(strconv.FormatInt(int64(*c.Weight), 10))
+	}
+	return json.Marshal(obj)
+}
+
+// unmarshalWeight unmarshals a non-negative integer, or a string containing it.
+func unmarshalWeight(data []byte) (*int32, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var s string
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+	} else {
+		s = string(data)
+	}
+	w, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || w < 0 {
+		return nil, fmt.Errorf("weight must be a non-negative integer, but is %s", string(data))
+	}
+	weight := int32(w)
+	return &weight, nil
+}
+
+// unmarshalStrings unmarshals a string or an array of strings.
+func unmarshalStrings(data []byte) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var s []string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return []string{s}, nil
+}
+
+// CompletionContextValues are values of a context of Completion,
+// category names for category contexts, or geopoints for geo contexts.
+// A single value is unmarshalled into a slice of one element.
+type CompletionContextValues []json.RawMessage
+
+// CategoryContext returns CompletionContextValues of category names.
+func CategoryContext(categories ...string) CompletionContextValues {
+	values := make(CompletionContextValues, len(categories))
+	for i, c := range categories {
+		values[i], _ = json.Marshal(c)
+	}
+	return values
+}
+
+// GeoContext returns CompletionContextValues of points.
+func GeoContext(points ...Geopoint) CompletionContextValues {
+	values := make(CompletionContextValues, len(points))
+	for i, p := range points {
+		values[i], _ = json.Marshal(p)
+	}
+	return values
+}
+
+// Categories returns values as category names.
+func (v CompletionContextValues) Categories() ([]string, error) {
+	out := make([]string, len(v))
+	for i, raw := range v {
+		if err := json.Unmarshal(raw, &out[i]); err != nil {
+			return nil, fmt.Errorf("category must be string, but is %s", string(raw))
+		}
+	}
+	return out, nil
+}
+
+// Geopoints returns values as geopoints.
+func (v CompletionContextValues) Geopoints() ([]Geopoint, error) {
+	out := make([]Geopoint, len(v))
+	for i, raw := range v {
+		if err := json.Unmarshal(raw, &out[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (v *CompletionContextValues) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var values []json.RawMessage
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		// [lon, lat] is a single geopoint.
+		if len(values) == 0 || !isJSONNumber(values[0]) {
+			*v = values
+			return nil
+		}
+	}
+	*v = CompletionContextValues{append(json.RawMessage{}, data...)}
+	return nil
+}
+
+func isJSONNumber(value []byte) bool {
+	value = bytes.TrimSpace(value)
+	return len(value) > 0 && (value[0] == '-' || ('0' <= value[0] && value[0] <= '9'))
+}
+
+// ErrUnknownContext is returned when a context of Completion is not declared in the mapping.
+var ErrUnknownContext = errors.New("unknown context")
+
+// CompletionContextTypes maps context names declared in contexts of a completion field to their types,
+// "category" or "geo".
+type CompletionContextTypes map[string]string
+
+// Check returns an error wrapping ErrUnknownContext if any of contexts of c is not declared in t,
+// or an error if values of a context are not of its type.
+func (t CompletionContextTypes) Check(c Completion) error {
+	for _, name := range sortedKeys(c.Contexts) {
+		ty, ok := t[name]
+		if !ok {
+			return fmt.Errorf("%w: %q is not one of %v", ErrUnknownContext, name, sortedKeys(t))
+		}
+		var err error
+		switch ty {
+		case "category":
+			_, err = c.Contexts[name].Categories()
+		case "geo":
+			_, err = c.Contexts[name].Geopoints()
+		}
+		if err != nil {
+			return fmt.Errorf("context %q: %w", name, err)
+		}
+	}
+	return nil
+}
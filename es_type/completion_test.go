@@ -0,0 +1,86 @@
+package estype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletion(t *testing.T) {
+	weight := int32(34)
+	for _, testCase := range []struct {
+		input    string
+		expected estype.Completion
+	}{
+		{`"Nevermind"`, estype.Completion{Input: []string{"Nevermind"}}},
+		{`["Nevermind","Nirvana"]`, estype.Completion{Input: []string{"Nevermind", "Nirvana"}}},
+		{`{"input":"Nevermind"}`, estype.Completion{Input: []string{"Nevermind"}}},
+		{`{"input":["Nevermind","Nirvana"],"weight":34}`, estype.Completion{Input: []string{"Nevermind", "Nirvana"}, Weight: &weight}},
+		{`{"input":"Nevermind","weight":"34"}`, estype.Completion{Input: []string{"Nevermind"}, Weight: &weight}},
+	} {
+		var c estype.Completion
+		require.NoError(t, json.Unmarshal([]byte(testCase.input), &c), testCase.input)
+		require.Equal(t, testCase.expected, c, testCase.input)
+
+		bin, err := json.Marshal(c)
+		require.NoError(t, err)
+		var c2 estype.Completion
+		require.NoError(t, json.Unmarshal(bin, &c2))
+		require.Equal(t, c, c2)
+	}
+
+	for _, invalid := range []string{
+		`{"input":"a","weight":-1}`,
+		`{"input":"a","weight":1.5}`,
+		`{"input":1}`,
+		`1`,
+	} {
+		var c estype.Completion
+		require.Error(t, json.Unmarshal([]byte(invalid), &c), invalid)
+	}
+
+	negative := int32(-1)
+	_, err := json.Marshal(estype.Completion{Input: []string{"a"}, Weight: &negative})
+	require.Error(t, err)
+}
+
+func TestCompletionContexts(t *testing.T) {
+	input := `{
+		"input": "timmy's",
+		"contexts": {
+			"place_type": ["cafe", "food"],
+			"location": {"lat": 43.662, "lon": -79.380}
+		}
+	}`
+
+	var c estype.Completion
+	require.NoError(t, json.Unmarshal([]byte(input), &c))
+
+	categories, err := c.Contexts["place_type"].Categories()
+	require.NoError(t, err)
+	require.Equal(t, []string{"cafe", "food"}, categories)
+
+	points, err := c.Contexts["location"].Geopoints()
+	require.NoError(t, err)
+	require.Equal(t, []estype.Geopoint{{Lat: 43.662, Lon: -79.380}}, points)
+
+	// [lon, lat] is a single point.
+	var values estype.CompletionContextValues
+	require.NoError(t, json.Unmarshal([]byte(`[-79.380, 43.662]`), &values))
+	points, err = values.Geopoints()
+	require.NoError(t, err)
+	require.Equal(t, []estype.Geopoint{{Lat: 43.662, Lon: -79.380}}, points)
+
+	types := estype.CompletionContextTypes{"place_type": "category", "location": "geo"}
+	require.NoError(t, types.Check(c))
+	require.ErrorIs(
+		t,
+		types.Check(estype.Completion{Contexts: map[string]estype.CompletionContextValues{"genre": estype.CategoryContext("rock")}}),
+		estype.ErrUnknownContext,
+	)
+	require.Error(t, types.Check(estype.Completion{
+		Contexts: map[string]estype.CompletionContextValues{"place_type": estype.GeoContext(estype.Geopoint{})},
+	}))
+}
@@ -0,0 +1,220 @@
+package estype
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// VectorSimilarity is a similarity of dense_vector fields, used in kNN search.
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/dense-vector.html#dense-vector-params
+type VectorSimilarity string
+
+const (
+	L2Norm     VectorSimilarity = "l2_norm"
+	DotProduct VectorSimilarity = "dot_product"
+	Cosine     VectorSimilarity = "cosine"
+)
+
+var (
+	// ErrVectorDims is returned when the length of a vector is not dims of the field.
+	ErrVectorDims = errors.New("vector dims mismatch")
+	// ErrVectorNotNormalized is returned when a vector breaks the rule of the similarity,
+	// e.g. non unit length vectors of float elements for dot_product, or zero magnitude vectors for cosine.
+	ErrVectorNotNormalized = errors.New("vector not normalized")
+)
+
+// unitVectorTolerance is the tolerance Elasticsearch allows for squared magnitude of unit length vectors of float elements.
+// Vectors with |squaredMagnitude - 1| > 1e-4 are rejected.
+const unitVectorTolerance = 1e-4
+
+// CheckFloatVector checks v as Elasticsearch does for dense_vector fields of float elements.
+// It returns an error wrapping ErrVectorDims if length of v is not dims,
+// or ErrVectorNotNormalized if v is not unit length for dot_product, or has zero magnitude for cosine.
+// similarity may be empty to skip the latter check, e.g. for fields which are not indexed.
+func CheckFloatVector(v []float32, dims int, similarity VectorSimilarity) error {
+	if len(v) != dims {
+		return fmt.Errorf("%w: must be %d but is %d", ErrVectorDims, dims, len(v))
+	}
+	switch similarity {
+	case DotProduct:
+		if sq := squaredMagnitude(v); math.Abs(float64(sq)-1) > unitVectorTolerance {
+			return fmt.Errorf("%w: dot_product is only for unit length vectors, but squared magnitude is %v", ErrVectorNotNormalized, sq)
+		}
+	case Cosine:
+		if squaredMagnitude(v) == 0 {
+			return fmt.Errorf("%w: cosine does not support vectors with zero magnitude", ErrVectorNotNormalized)
+		}
+	}
+	return nil
+}
+
+// CheckByteVector checks v as Elasticsearch does for dense_vector fields of byte elements.
+// It returns an error wrapping ErrVectorDims if length of v is not dims,
+// or ErrVectorNotNormalized if v has zero magnitude for cosine.
+// similarity may be empty to skip the latter check.
+func CheckByteVector(v []int8, dims int, similarity VectorSimilarity) error {
+	if len(v) != dims {
+		return fmt.Errorf("%w: must be %d but is %d", ErrVectorDims, dims, len(v))
+	}
+	if similarity == Cosine {
+		zero := true
+		for _, e := range v {
+			if e != 0 {
+				zero = false
+				break
+			}
+		}
+		if zero {
+			return fmt.Errorf("%w: cosine does not support vectors with zero magnitude", ErrVectorNotNormalized)
+		}
+	}
+	return nil
+}
+
+// CheckBitVector checks v as Elasticsearch does for dense_vector fields of bit elements.
+// Bits are packed into bytes, so length of v must be dims / 8.
+func CheckBitVector(v []int8, dims int) error {
+	if len(v)*8 != dims {
+		return fmt.Errorf("%w: must be %d bytes for %d bits but is %d", ErrVectorDims, dims/8, dims, len(v))
+	}
+	return nil
+}
+
+// UnmarshalByteVectorJSON unmarshals an array of signed 8-bit integers, or a hex string of bytes, e.g. "0aff",
+// which Elasticsearch accepts for dense_vector fields of byte and bit elements.
+func UnmarshalByteVectorJSON(data []byte) ([]int8, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		v := make([]int8, len(b))
+		for i := range b {
+			v[i] = int8(b[i])
+		}
+		return v, nil
+	}
+	var v []int8
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// FloatVectorScore returns _score of kNN search Elasticsearch computes for a document vector doc and a query vector,
+// of dense_vector fields of float elements.
+//
+//   - l2_norm: 1 / (1 + l2_norm(query, doc)^2)
+//   - dot_product: (1 + dot_product(query, doc)) / 2
+//   - cosine: (1 + cosine(query, doc)) / 2
+//
+// Vectors must pass CheckFloatVector. Calculation is done in float32, as Elasticsearch does.
+func FloatVectorScore(similarity VectorSimilarity, query, doc []float32) (float32, error) {
+	if len(query) != len(doc) {
+		return 0, fmt.Errorf("%w: query has %d dims but doc has %d", ErrVectorDims, len(query), len(doc))
+	}
+	switch similarity {
+	case L2Norm:
+		var sum float32
+		for i := range query {
+			d := query[i] - doc[i]
+			sum += d * d
+		}
+		return 1 / (1 + sum), nil
+	case DotProduct:
+		return (1 + dotProduct(query, doc)) / 2, nil
+	case Cosine:
+		sq := squaredMagnitude(query) * squaredMagnitude(doc)
+		if sq == 0 {
+			return 0, fmt.Errorf("%w: cosine does not support vectors with zero magnitude", ErrVectorNotNormalized)
+		}
+		cos := dotProduct(query, doc) / float32(math.Sqrt(float64(sq)))
+		return (1 + cos) / 2, nil
+	}
+	return 0, fmt.Errorf("unknown similarity %q", similarity)
+}
+
+// ByteVectorScore is same as FloatVectorScore, but for dense_vector fields of byte elements.
+// dot_product of byte vectors is scaled by the maximum possible value instead of unit length:
+// 0.5 + dot_product(query, doc) / (32768 * dims).
+func ByteVectorScore(similarity VectorSimilarity, query, doc []int8) (float32, error) {
+	if len(query) != len(doc) {
+		return 0, fmt.Errorf("%w: query has %d dims but doc has %d", ErrVectorDims, len(query), len(doc))
+	}
+	var dot, sqQuery, sqDoc, sqDiff int64
+	for i := range query {
+		q, d := int64(query[i]), int64(doc[i])
+		dot += q * d
+		sqQuery += q * q
+		sqDoc += d * d
+		sqDiff += (q - d) * (q - d)
+	}
+	switch similarity {
+	case L2Norm:
+		return 1 / (1 + float32(sqDiff)), nil
+	case DotProduct:
+		return 0.5 + float32(dot)/float32(32768*len(query)), nil
+	case Cosine:
+		if sqQuery == 0 || sqDoc == 0 {
+			return 0, fmt.Errorf("%w: cosine does not support vectors with zero magnitude", ErrVectorNotNormalized)
+		}
+		cos := float32(dot) / float32(math.Sqrt(float64(sqQuery)*float64(sqDoc)))
+		return (1 + cos) / 2, nil
+	}
+	return 0, fmt.Errorf("unknown similarity %q", similarity)
+}
+
+// BitVectorScore returns _score of kNN search for dense_vector fields of bit elements,
+// which only support l2_norm, computed from the hamming distance: (bits - hamming(query, doc)) / bits.
+func BitVectorScore(query, doc []int8) (float32, error) {
+	if len(query) != len(doc) {
+		return 0, fmt.Errorf("%w: query has %d bytes but doc has %d", ErrVectorDims, len(query), len(doc))
+	}
+	if len(query) == 0 {
+		return 0, fmt.Errorf("%w: empty vectors", ErrVectorDims)
+	}
+	var hamming int
+	for i := range query {
+		hamming += bits.OnesCount8(uint8(query[i] ^ doc[i]))
+	}
+	numBits := len(query) * 8
+	return float32(numBits-hamming) / float32(numBits), nil
+}
+
+// NormalizeVector returns v scaled to unit length, as required for dot_product of float elements.
+// It returns v as is if v has zero magnitude.
+func NormalizeVector(v []float32) []float32 {
+	sq := squaredMagnitude(v)
+	if sq == 0 {
+		return v
+	}
+	norm := float32(math.Sqrt(float64(sq)))
+	out := make([]float32, len(v))
+	for i := range v {
+		out[i] = v[i] / norm
+	}
+	return out
+}
+
+func dotProduct(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func squaredMagnitude(v []float32) float32 {
+	return dotProduct(v, v)
+}
@@ -0,0 +1,90 @@
+package estype_test
+
+import (
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVector(t *testing.T) {
+	require.NoError(t, estype.CheckFloatVector([]float32{3, 4}, 2, ""))
+	require.NoError(t, estype.CheckFloatVector([]float32{0.6, 0.8}, 2, estype.DotProduct))
+	require.NoError(t, estype.CheckFloatVector([]float32{0, 0}, 2, estype.L2Norm))
+	require.ErrorIs(t, estype.CheckFloatVector([]float32{3, 4, 5}, 2, ""), estype.ErrVectorDims)
+	require.ErrorIs(t, estype.CheckFloatVector([]float32{3, 4}, 2, estype.DotProduct), estype.ErrVectorNotNormalized)
+	// squared magnitude is 1.0005, within 1e-3 but not within 1e-4 of 1.
+	require.ErrorIs(t, estype.CheckFloatVector([]float32{1.00025, 0}, 2, estype.DotProduct), estype.ErrVectorNotNormalized)
+	// squared magnitude is about 1.00004.
+	require.NoError(t, estype.CheckFloatVector([]float32{1.00002, 0}, 2, estype.DotProduct))
+	require.ErrorIs(t, estype.CheckFloatVector([]float32{0, 0}, 2, estype.Cosine), estype.ErrVectorNotNormalized)
+
+	require.NoError(t, estype.CheckByteVector([]int8{-128, 127}, 2, estype.DotProduct))
+	require.ErrorIs(t, estype.CheckByteVector([]int8{1}, 2, ""), estype.ErrVectorDims)
+	require.ErrorIs(t, estype.CheckByteVector([]int8{0, 0}, 2, estype.Cosine), estype.ErrVectorNotNormalized)
+
+	require.NoError(t, estype.CheckBitVector([]int8{1, 2}, 16))
+	require.ErrorIs(t, estype.CheckBitVector([]int8{1, 2}, 8), estype.ErrVectorDims)
+
+	normalized := estype.NormalizeVector([]float32{3, 4})
+	require.InDeltaSlice(t, []float32{0.6, 0.8}, normalized, 1e-6)
+	require.NoError(t, estype.CheckFloatVector(normalized, 2, estype.DotProduct))
+}
+
+func TestVectorScore(t *testing.T) {
+	for _, testCase := range []struct {
+		similarity estype.VectorSimilarity
+		query, doc []float32
+		expected   float32
+	}{
+		{estype.L2Norm, []float32{1, 0}, []float32{0, 1}, 1.0 / 3},
+		{estype.L2Norm, []float32{1, 2}, []float32{1, 2}, 1},
+		{estype.DotProduct, []float32{1, 0}, []float32{0.6, 0.8}, 0.8},
+		{estype.DotProduct, []float32{1, 0}, []float32{-1, 0}, 0},
+		{estype.Cosine, []float32{1, 0}, []float32{3, 4}, 0.8},
+	} {
+		score, err := estype.FloatVectorScore(testCase.similarity, testCase.query, testCase.doc)
+		require.NoError(t, err)
+		require.InDelta(t, testCase.expected, score, 1e-6, "%+v", testCase)
+	}
+
+	for _, testCase := range []struct {
+		similarity estype.VectorSimilarity
+		query, doc []int8
+		expected   float32
+	}{
+		{estype.L2Norm, []int8{1, 2, 3}, []int8{1, 2, 5}, 0.2},
+		{estype.DotProduct, []int8{127, 0}, []int8{127, 0}, 0.5 + 16129.0/(32768*2)},
+		{estype.DotProduct, []int8{-128, 0}, []int8{127, 0}, 0.5 - 16256.0/(32768*2)},
+		{estype.Cosine, []int8{1, 0}, []int8{3, 4}, 0.8},
+	} {
+		score, err := estype.ByteVectorScore(testCase.similarity, testCase.query, testCase.doc)
+		require.NoError(t, err)
+		require.InDelta(t, testCase.expected, score, 1e-6, "%+v", testCase)
+	}
+
+	score, err := estype.BitVectorScore([]int8{0x0f, 0}, []int8{0, 0})
+	require.NoError(t, err)
+	require.InDelta(t, 0.75, score, 1e-6)
+
+	_, err = estype.FloatVectorScore(estype.L2Norm, []float32{1}, []float32{1, 2})
+	require.ErrorIs(t, err, estype.ErrVectorDims)
+	_, err = estype.ByteVectorScore(estype.Cosine, []int8{0, 0}, []int8{1, 2})
+	require.ErrorIs(t, err, estype.ErrVectorNotNormalized)
+}
+
+func TestUnmarshalByteVectorJSON(t *testing.T) {
+	v, err := estype.UnmarshalByteVectorJSON([]byte(`[-128, 0, 127]`))
+	require.NoError(t, err)
+	require.Equal(t, []int8{-128, 0, 127}, v)
+
+	v, err = estype.UnmarshalByteVectorJSON([]byte(`"80007f"`))
+	require.NoError(t, err)
+	require.Equal(t, []int8{-128, 0, 127}, v)
+
+	_, err = estype.UnmarshalByteVectorJSON([]byte(`[128]`))
+	require.Error(t, err)
+
+	_, err = estype.UnmarshalByteVectorJSON([]byte(`"zz"`))
+	require.Error(t, err)
+}
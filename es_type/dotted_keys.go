@@ -0,0 +1,268 @@
+package estype
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ObjectKeys is keys of a JSON object which a raw type accepts,
+// mapped to whether the field is object-like (object, nested or passthrough).
+// Keys may contain dots, e.g. "metrics.cpu" in an object with subobjects: false.
+type ObjectKeys map[string]bool
+
+// KeyConflictError is returned from NormalizeKeys when a field is set more than once,
+// e.g. in both dotted and expanded forms.
+type KeyConflictError struct {
+	// Key is the dotted key of the field set more than once, relative to the object being normalized.
+	Key string
+}
+
+func (e *KeyConflictError) Error() string {
+	return fmt.Sprintf("key conflict: %q is set more than once in dotted and expanded forms", e.Key)
+}
+
+// NormalizeKeys rewrites keys of the JSON object data to keys, so that data can be unmarshalled into the raw type.
+//
+// Elasticsearch treats {"user.name": "x"} and {"user": {"name": "x"}} the same.
+//   - Dotted keys under object-like fields, e.g. "user.name" for "user", are expanded into the field.
+//     They are merged with the expanded form if both exist.
+//     The rest of the key, "name", is left dotted if it has more dots. The raw type of the field normalizes it in turn.
+//   - Objects whose keys are prefixes of dotted keys, e.g. "metrics" for "metrics.cpu"
+//     of an object with subobjects: false, are flattened into them.
+//
+// Other keys are left as is.
+//
+// It returns data as is if nothing needs to be rewritten, or if data is not a JSON object.
+// It returns *KeyConflictError if a field is set more than once after rewriting.
+//
+// Top-level keys of data are scanned first without decoding values, which costs a single pass over data.
+// Only if any of them needs rewriting, data is decoded into a map and re-encoded,
+// which costs roughly as much as unmarshalling data once more.
+func NormalizeKeys(data []byte, keys ObjectKeys) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return data, nil
+	}
+	if !keys.needsRewrite(trimmed) {
+		return data, nil
+	}
+
+	var in map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &in); err != nil {
+		return nil, err
+	}
+
+	n := normalizer{
+		keys:   keys,
+		out:    make(map[string]json.RawMessage, len(in)),
+		merged: map[string]map[string]json.RawMessage{},
+	}
+	for _, k := range sortedKeys(in) {
+		if err := n.put(k, in[k]); err != nil {
+			return nil, err
+		}
+	}
+	for k, m := range n.merged {
+		bin, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		n.out[k] = bin
+	}
+	return json.Marshal(n.out)
+}
+
+type normalizer struct {
+	keys ObjectKeys
+	out  map[string]json.RawMessage
+	// merged is objects of object-like fields, being merged from expanded and dotted forms.
+	merged map[string]map[string]json.RawMessage
+}
+
+func (n *normalizer) put(key string, value json.RawMessage) error {
+	isObject, known := n.keys[key]
+	switch {
+	case known && isObject && isJSONObject(value):
+		var sub map[string]json.RawMessage
+		if err := json.Unmarshal(value, &sub); err != nil {
+			return err
+		}
+		for _, subKey := range sortedKeys(sub) {
+			if err := n.merge(key, subKey, sub[subKey]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case known:
+	case n.keys.isPrefix(key):
+		var sub map[string]json.RawMessage
+		if isJSONObject(value) && json.Unmarshal(value, &sub) == nil {
+			for _, subKey := range sortedKeys(sub) {
+				if err := n.put(key+"."+subKey, sub[subKey]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	default:
+		if prefix, ok := n.keys.objectPrefix(key); ok {
+			return n.merge(prefix, key[len(prefix)+1:], value)
+		}
+	}
+
+	if _, ok := n.out[key]; ok {
+		return &KeyConflictError{Key: key}
+	}
+	if _, ok := n.merged[key]; ok {
+		return &KeyConflictError{Key: key}
+	}
+	n.out[key] = value
+	return nil
+}
+
+// merge sets value to subKey of the object-like field key.
+func (n *normalizer) merge(key, subKey string, value json.RawMessage) error {
+	if _, ok := n.out[key]; ok {
+		// already set to a non-object value.
+		return &KeyConflictError{Key: key}
+	}
+	m, ok := n.merged[key]
+	if !ok {
+		m = map[string]json.RawMessage{}
+		n.merged[key] = m
+	}
+	if _, ok := m[subKey]; ok {
+		return &KeyConflictError{Key: key + "." + subKey}
+	}
+	m[subKey] = value
+	return nil
+}
+
+// objectPrefix returns the longest object-like key of keys which key + "." is a prefix of.
+func (keys ObjectKeys) objectPrefix(key string) (string, bool) {
+	for i := strings.LastIndexByte(key, '.'); i > 0; i = strings.LastIndexByte(key[:i], '.') {
+		if keys[key[:i]] {
+			return key[:i], true
+		}
+	}
+	return "", false
+}
+
+// needsRewrite reports whether any of top-level keys of the JSON object data needs rewriting.
+// It conservatively returns true for keys with escapes or malformed data, leaving them to the decoder.
+func (keys ObjectKeys) needsRewrite(data []byte) bool {
+	i := 1 // skip '{'
+	for {
+		i = skipSpace(data, i)
+		if i >= len(data) {
+			return true
+		}
+		switch data[i] {
+		case '}':
+			return false
+		case ',':
+			i++
+			continue
+		case '"':
+		default:
+			return true
+		}
+
+		end := i + 1
+		for end < len(data) && data[end] != '"' {
+			if data[end] == '\\' {
+				return true
+			}
+			end++
+		}
+		if end >= len(data) {
+			return true
+		}
+		if _, ok := keys[string(data[i+1:end])]; !ok {
+			key := string(data[i+1 : end])
+			if _, ok := keys.objectPrefix(key); ok || keys.isPrefix(key) {
+				return true
+			}
+		}
+
+		i = skipSpace(data, end+1)
+		if i >= len(data) || data[i] != ':' {
+			return true
+		}
+		var ok bool
+		if i, ok = skipValue(data, i+1); !ok {
+			return true
+		}
+	}
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// skipValue returns the index next to the JSON value starting at data[i:].
+// Values other than strings, objects and arrays end at the next ',' or closing bracket.
+func skipValue(data []byte, i int) (int, bool) {
+	depth := 0
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(data) {
+				return i, false
+			}
+			if depth == 0 {
+				return i + 1, true
+			}
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return i, true
+			}
+			depth--
+			if depth == 0 {
+				return i + 1, true
+			}
+		case ',':
+			if depth == 0 {
+				return i, true
+			}
+		}
+	}
+	return i, false
+}
+
+// isPrefix reports whether prefix + "." is a prefix of any of keys.
+func (keys ObjectKeys) isPrefix(prefix string) bool {
+	for k := range keys {
+		if strings.HasPrefix(k, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func isJSONObject(value []byte) bool {
+	value = bytes.TrimSpace(value)
+	return len(value) > 0 && value[0] == '{'
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
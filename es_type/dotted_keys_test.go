@@ -0,0 +1,93 @@
+package estype_test
+
+import (
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeKeys(t *testing.T) {
+	keys := estype.ObjectKeys{
+		"a.b":   false,
+		"a.c.d": false,
+		"e":     false,
+	}
+
+	for _, testCase := range []struct {
+		input    string
+		expected string
+	}{
+		{`{"a.b":1,"e":2}`, `{"a.b":1,"e":2}`},
+		{`{"a":{"b":1,"c":{"d":2}},"e":3}`, `{"a.b":1,"a.c.d":2,"e":3}`},
+		{`{"a":{"b":1},"a.c":{"d":2}}`, `{"a.b":1,"a.c.d":2}`},
+		// unknown keys are left as is.
+		{`{"a":{"x":1},"y":2}`, `{"a.x":1,"y":2}`},
+		{`{"a":[{"b":1}]}`, `{"a":[{"b":1}]}`},
+		{`null`, `null`},
+	} {
+		out, err := estype.NormalizeKeys([]byte(testCase.input), keys)
+		require.NoError(t, err, testCase.input)
+		require.JSONEq(t, testCase.expected, string(out), testCase.input)
+	}
+
+	_, err := estype.NormalizeKeys([]byte(`{"a.b":1,"a":{"b":2}}`), keys)
+	require.Equal(t, &estype.KeyConflictError{Key: "a.b"}, err)
+}
+
+func TestNormalizeKeysExpand(t *testing.T) {
+	keys := estype.ObjectKeys{
+		"user":       true,
+		"user.extra": true,
+		"tag":        false,
+	}
+
+	for _, testCase := range []struct {
+		input    string
+		expected string
+	}{
+		{`{"user":{"name":"x"},"tag":"t"}`, `{"user":{"name":"x"},"tag":"t"}`},
+		{`{"user.name":"x"}`, `{"user":{"name":"x"}}`},
+		{`{"user.name.first":"x"}`, `{"user":{"name.first":"x"}}`},
+		{`{"user":{"id":1},"user.name":"x"}`, `{"user":{"id":1,"name":"x"}}`},
+		// the longest object-like key wins.
+		{`{"user.extra.a":1}`, `{"user.extra":{"a":1}}`},
+		{`{"tag.x":1}`, `{"tag.x":1}`},
+	} {
+		out, err := estype.NormalizeKeys([]byte(testCase.input), keys)
+		require.NoError(t, err, testCase.input)
+		require.JSONEq(t, testCase.expected, string(out), testCase.input)
+	}
+
+	for input, key := range map[string]string{
+		`{"user":{"name":"x"},"user.name":"y"}`: "user.name",
+		`{"user":null,"user.name":"y"}`:         "user",
+	} {
+		_, err := estype.NormalizeKeys([]byte(input), keys)
+		require.Equal(t, &estype.KeyConflictError{Key: key}, err, input)
+	}
+}
+
+func TestNormalizeKeysUntouched(t *testing.T) {
+	keys := estype.ObjectKeys{
+		"user": true,
+		"ip":   false,
+		"tags": false,
+	}
+
+	// Documents without keys to rewrite are returned as is, without being re-encoded.
+	for _, input := range []string{
+		`{}`,
+		` { "ip" : "192.168.0.1", "user": {"name.first": "a.b", "x": [1.5, {"y.z": "}"}]}, "tags": ["a,b", "c\"d."] } `,
+		`{"unknown":{"a.b":1},"ip":null}`,
+	} {
+		out, err := estype.NormalizeKeys([]byte(input), keys)
+		require.NoError(t, err, input)
+		require.Equal(t, input, string(out))
+	}
+
+	// escaped keys are left to the decoder.
+	out, err := estype.NormalizeKeys([]byte(`{"user\u002ename":"x"}`), keys)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"user":{"name":"x"}}`, string(out))
+}
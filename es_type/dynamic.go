@@ -0,0 +1,241 @@
+package estype
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sync"
+	"time"
+
+	builtinformat "github.com/ngicks/elastic-type/es_type/builtin_format"
+	"github.com/ngicks/elastic-type/internal/wildcard"
+)
+
+// DynamicTemplateMatcher is the matching conditions of a dynamic template.
+// Generated types for dynamic objects use it to route fields to typed maps.
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/dynamic-templates.html
+type DynamicTemplateMatcher struct {
+	Name             string
+	MatchMappingType []string
+	Match            []string
+	Unmatch          []string
+	PathMatch        []string
+	PathUnmatch      []string
+	// MatchRegex is true if match_pattern is "regex".
+	// Then Match and Unmatch are regular expressions, instead of simple patterns where * is a wildcard.
+	MatchRegex bool
+}
+
+// DynamicDetection is the settings of dynamic field mapping which affect types detected from values.
+// The zero value is the default of Elasticsearch, except for date formats described in Detect.
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/dynamic-field-mapping.html#date-detection
+type DynamicDetection struct {
+	// DateDetectionDisabled is true if date_detection is false. Then strings are never detected as date.
+	DateDetectionDisabled bool
+	// ParseDate parses a string in one of dynamic_date_formats.
+	// If nil, strict_date_optional_time is used.
+	ParseDate func(string) (time.Time, error)
+}
+
+// DynamicTemplateTable is dynamic_templates of a mapping, with the settings of dynamic field mapping of it.
+// Generated types for dynamic objects use it to route fields to typed maps.
+type DynamicTemplateTable struct {
+	Detection DynamicDetection
+	Matchers  []DynamicTemplateMatcher
+}
+
+// Match returns the index of the first matcher that matches the field, or -1 if none.
+// path is the full dotted path to the field and name is the last element of it.
+// value is the JSON value of the field.
+func (t DynamicTemplateTable) Match(path, name string, value []byte) int {
+	for i, m := range t.Matchers {
+		if m.matches(t.Detection, path, name, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MatchDynamicTemplate is same as Match of DynamicTemplateTable with the default DynamicDetection.
+func MatchDynamicTemplate(matchers []DynamicTemplateMatcher, path, name string, value []byte) int {
+	return DynamicTemplateTable{Matchers: matchers}.Match(path, name, value)
+}
+
+// Matches reports whether m matches the field with the default DynamicDetection.
+// See Match of DynamicTemplateTable for params.
+func (m DynamicTemplateMatcher) Matches(path, name string, value []byte) bool {
+	return m.matches(DynamicDetection{}, path, name, value)
+}
+
+func (m DynamicTemplateMatcher) matches(detection DynamicDetection, path, name string, value []byte) bool {
+	if len(m.MatchMappingType) > 0 {
+		detected := detection.Detect(value)
+		if detected == "" {
+			return false
+		}
+		var found bool
+		for _, ty := range m.MatchMappingType {
+			if ty == "*" || ty == detected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	matchName := wildcard.SimpleMatch
+	if m.MatchRegex {
+		matchName = regexMatch
+	}
+	if len(m.Match) > 0 && !anyMatch(m.Match, name, matchName) {
+		return false
+	}
+	if anyMatch(m.Unmatch, name, matchName) {
+		return false
+	}
+	if len(m.PathMatch) > 0 && !anyMatch(m.PathMatch, path, wildcard.SimpleMatch) {
+		return false
+	}
+	if anyMatch(m.PathUnmatch, path, wildcard.SimpleMatch) {
+		return false
+	}
+	return true
+}
+
+func anyMatch(patterns []string, s string, match func(pattern, s string) bool) bool {
+	for _, p := range patterns {
+		if match(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+var regexCache sync.Map // map[string]*regexp.Regexp
+
+func regexMatch(pattern, s string) bool {
+	var re *regexp.Regexp
+	if cached, ok := regexCache.Load(pattern); ok {
+		re = cached.(*regexp.Regexp)
+	} else {
+		var err error
+		// Java's Matcher.matches requires the entire string to match.
+		re, err = regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			// Patterns are validated on generation. See ValidatePatterns of mapping.DynamicTemplate.
+			return false
+		}
+		regexCache.Store(pattern, re)
+	}
+	return re.MatchString(s)
+}
+
+// DetectDynamicMappingType is same as Detect of DynamicDetection with the default settings.
+func DetectDynamicMappingType(value []byte) string {
+	return DynamicDetection{}.Detect(value)
+}
+
+// Detect returns the type which dynamic field mapping detects from value,
+// as named in match_mapping_type: "boolean", "date", "double", "long", "object" or "string".
+// For an array, the first non-null element is used.
+// It returns an empty string for null or an empty array, which are not dynamically mapped.
+//
+// If ParseDate is nil, date detection only tries strict_date_optional_time,
+// whereas Elasticsearch also tries "yyyy/MM/dd HH:mm:ss Z||yyyy/MM/dd Z" by default.
+func (d DynamicDetection) Detect(value []byte) string {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 {
+		return ""
+	}
+	switch value[0] {
+	case 'n':
+		return ""
+	case 't', 'f':
+		return "boolean"
+	case '{':
+		return "object"
+	case '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(value, &elems); err != nil {
+			return ""
+		}
+		for _, elem := range elems {
+			if ty := d.Detect(elem); ty != "" {
+				return ty
+			}
+		}
+		return ""
+	case '"':
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return ""
+		}
+		if d.DateDetectionDisabled {
+			return "string"
+		}
+		parseDate := d.ParseDate
+		if parseDate == nil {
+			parseDate = builtinformat.Formatters[builtinformat.StrictDateOptionalTime].Parse
+		}
+		if _, err := parseDate(s); err == nil {
+			return "date"
+		}
+		return "string"
+	default:
+		if bytes.ContainsAny(value, ".eE") {
+			return "double"
+		}
+		return "long"
+	}
+}
+
+// UnmarshalDynamicField unmarshals value into a new Field[T] and stores it in *m with key name.
+// *m is initialized if nil.
+func UnmarshalDynamicField[T any](m *map[string]Field[T], name string, value []byte) error {
+	var field Field[T]
+	if err := json.Unmarshal(value, &field); err != nil {
+		return err
+	}
+	if *m == nil {
+		*m = map[string]Field[T]{}
+	}
+	(*m)[name] = field
+	return nil
+}
+
+// CollectDynamicFields stores fields of m into out, skipping undefined ones.
+func CollectDynamicFields[T any](out map[string]any, m map[string]Field[T]) {
+	for k, v := range m {
+		if !v.IsUndefined() {
+			out[k] = v
+		}
+	}
+}
+
+// DynamicFieldsToPlain converts m into a map of plain slices.
+func DynamicFieldsToPlain[T any](m map[string]Field[T]) map[string][]T {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string][]T, len(m))
+	for k, v := range m {
+		out[k] = v.ValueZero()
+	}
+	return out
+}
+
+// DynamicFieldsToRaw converts m into a map of Field[T].
+func DynamicFieldsToRaw[T any](m map[string][]T) map[string]Field[T] {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]Field[T], len(m))
+	for k, v := range m {
+		out[k] = NewFieldSlice(v, false)
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package estype_test
+
+import (
+	"testing"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDynamicMappingType(t *testing.T) {
+	for _, testCase := range []struct {
+		value    string
+		expected string
+	}{
+		{`null`, ""},
+		{`[]`, ""},
+		{`[null, 1]`, "long"},
+		{`true`, "boolean"},
+		{`12`, "long"},
+		{`-1.5`, "double"},
+		{`1e3`, "double"},
+		{`"foo"`, "string"},
+		{`"12"`, "string"},
+		{`"2022-10-20T16:22:46Z"`, "date"},
+		{`"2022-10-20"`, "date"},
+		{`{"a": 1}`, "object"},
+	} {
+		require.Equal(
+			t,
+			testCase.expected,
+			estype.DetectDynamicMappingType([]byte(testCase.value)),
+			"value = %s", testCase.value,
+		)
+	}
+}
+
+func TestDynamicDetection(t *testing.T) {
+	require := require.New(t)
+
+	disabled := estype.DynamicDetection{DateDetectionDisabled: true}
+	require.Equal("string", disabled.Detect([]byte(`"2022-10-20T16:22:46Z"`)))
+
+	slashed := estype.DynamicDetection{
+		ParseDate: func(s string) (time.Time, error) { return time.Parse("2006/01/02", s) },
+	}
+	require.Equal("date", slashed.Detect([]byte(`"2022/10/20"`)))
+	require.Equal("string", slashed.Detect([]byte(`"2022-10-20"`)))
+	require.Equal("date", slashed.Detect([]byte(`[null, "2022/10/20"]`)))
+
+	table := estype.DynamicTemplateTable{
+		Detection: disabled,
+		Matchers: []estype.DynamicTemplateMatcher{
+			{Name: "dates", MatchMappingType: []string{"date"}},
+			{Name: "strings", MatchMappingType: []string{"string"}},
+		},
+	}
+	require.Equal(1, table.Match("x.at", "at", []byte(`"2022-10-20"`)))
+	require.Equal(0, estype.MatchDynamicTemplate(table.Matchers, "x.at", "at", []byte(`"2022-10-20"`)))
+}
+
+func TestMatchDynamicTemplate(t *testing.T) {
+	matchers := []estype.DynamicTemplateMatcher{
+		{Name: "strings_at", MatchMappingType: []string{"string"}, Match: []string{"*_at"}},
+		{Name: "regex", Match: []string{`^num_\d+$`}, MatchRegex: true},
+		{Name: "path", PathMatch: []string{"a.*"}, PathUnmatch: []string{"a.b.*"}},
+		{Name: "unmatch", Match: []string{"*"}, Unmatch: []string{"skip_*"}, MatchMappingType: []string{"*"}},
+	}
+
+	for _, testCase := range []struct {
+		path, name, value string
+		expected          int
+	}{
+		{"x.created_at", "created_at", `"2022/10/20"`, 0},
+		{"x.created_at", "created_at", `1`, 3},
+		{"x.num_12", "num_12", `1`, 1},
+		{"x.num_12a", "num_12a", `1`, 3},
+		{"a.c", "c", `1`, 2},
+		{"a.b.c", "c", `1`, 3},
+		{"x.skip_me", "skip_me", `1`, -1},
+		{"x.null", "null", `null`, -1},
+	} {
+		require.Equal(
+			t,
+			testCase.expected,
+			estype.MatchDynamicTemplate(matchers, testCase.path, testCase.name, []byte(testCase.value)),
+			"path = %s, value = %s", testCase.path, testCase.value,
+		)
+	}
+}
@@ -3,6 +3,7 @@ package estype
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 )
 
 const (
@@ -45,6 +46,17 @@ type Field[T any] struct {
 	inner *[]T
 }
 
+// ElemTyper is implemented by Field[T], to tell T through reflection without knowing T at compile time.
+type ElemTyper interface {
+	// ElemType returns reflect.Type of T.
+	ElemType() reflect.Type
+}
+
+// ElemType returns reflect.Type of T.
+func (f Field[T]) ElemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
 // NewFieldUnsafe returns a new Field.
 // This does not clone v.
 func NewFieldUnsafe[T any](v *[]T) Field[T] {
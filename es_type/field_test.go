@@ -2,6 +2,7 @@ package estype_test
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -193,3 +194,11 @@ func TestField_New_function_clones_value(t *testing.T) {
 		require.Empty(cmp.Diff(field.Unwrap(), []string{"foo"}))
 	}
 }
+
+func TestFieldElemType(t *testing.T) {
+	var f any = estype.Field[estype.Boolean]{}
+	typer, ok := f.(estype.ElemTyper)
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeOf(estype.Boolean(false)), typer.ElemType())
+	require.Equal(t, reflect.TypeOf([]string{}), estype.Field[[]string]{}.ElemType())
+}
@@ -17,28 +17,37 @@ type Geoshape struct {
 }
 
 func (g *Geoshape) UnmarshalJSON(data []byte) error {
-	data = bytes.TrimLeft(data, " ")
+	geo, err := unmarshalGeometryJSON(data)
+	if err != nil {
+		return err
+	}
+	g.Geometry = geo
+	return nil
+}
 
-	switch data[0] {
-	case '{':
-		var geo geojson.Geometry
-		err := geo.UnmarshalJSON(data)
-		if err != nil {
-			return err
-		}
-		g.Geometry = geo.Geometry
-		return nil
-	case '"':
-		geo, err := wkt.DecodeBytes(data[1 : len(data)-1])
-		if err != nil {
-			return err
+// unmarshalGeometryJSON decodes GeoJSON or a string literal of well-known text.
+func unmarshalGeometryJSON(data []byte) (geom.Geometry, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 {
+		switch data[0] {
+		case '{':
+			var geo geojson.Geometry
+			err := geo.UnmarshalJSON(data)
+			if err != nil {
+				return nil, err
+			}
+			return geo.Geometry, nil
+		case '"':
+			var text string
+			if err := json.Unmarshal(data, &text); err != nil {
+				return nil, err
+			}
+			return wkt.DecodeString(text)
 		}
-		g.Geometry = geo
-		return nil
 	}
 
-	return fmt.Errorf(
-		"unknown type: must be geojson or geohash string literal, but was %s",
+	return nil, fmt.Errorf(
+		"unknown type: must be geojson or well-known text string literal, but was %s",
 		string(data),
 	)
 }
@@ -0,0 +1,73 @@
+package estype
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidHistogram is returned when Histogram breaks a rule of Elasticsearch histogram fields.
+var ErrInvalidHistogram = errors.New("invalid histogram")
+
+// Histogram is a value of histogram fields, pre-aggregated numerical data.
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/histogram.html
+type Histogram struct {
+	// Values are buckets of the histogram. They must be in strictly increasing order.
+	Values []float64 `json:"values"`
+	// Counts are counts of each bucket. It must have the same length as Values, and must be non-negative.
+	Counts []int64 `json:"counts"`
+}
+
+// Validate returns an error wrapping ErrInvalidHistogram if h is not accepted by Elasticsearch.
+func (h Histogram) Validate() error {
+	if len(h.Values) != len(h.Counts) {
+		return fmt.Errorf(
+			"%w: values and counts must have the same length, but are %d and %d",
+			ErrInvalidHistogram, len(h.Values), len(h.Counts),
+		)
+	}
+	for i, v := range h.Values {
+		if i > 0 && !(h.Values[i-1] < v) {
+			return fmt.Errorf(
+				"%w: values must be in strictly increasing order, but values[%d] = %v follows %v",
+				ErrInvalidHistogram, i, v, h.Values[i-1],
+			)
+		}
+	}
+	for i, c := range h.Counts {
+		if c < 0 {
+			return fmt.Errorf("%w: counts must be non-negative, but counts[%d] = %d", ErrInvalidHistogram, i, c)
+		}
+	}
+	return nil
+}
+
+func (h Histogram) MarshalJSON() ([]byte, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+	type plain Histogram
+	p := plain(h)
+	// Elasticsearch rejects null.
+	if p.Values == nil {
+		p.Values = []float64{}
+	}
+	if p.Counts == nil {
+		p.Counts = []int64{}
+	}
+	return json.Marshal(p)
+}
+
+func (h *Histogram) UnmarshalJSON(data []byte) error {
+	type plain Histogram
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	if err := Histogram(p).Validate(); err != nil {
+		return err
+	}
+	*h = Histogram(p)
+	return nil
+}
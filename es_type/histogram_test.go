@@ -0,0 +1,44 @@
+package estype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	input := `{"values":[0.1,0.2,0.3],"counts":[3,0,23]}`
+
+	var h estype.Histogram
+	require.NoError(t, json.Unmarshal([]byte(input), &h))
+	require.Equal(t, estype.Histogram{Values: []float64{0.1, 0.2, 0.3}, Counts: []int64{3, 0, 23}}, h)
+
+	bin, err := json.Marshal(h)
+	require.NoError(t, err)
+	require.JSONEq(t, input, string(bin))
+
+	bin, err = json.Marshal(estype.Histogram{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"values":[],"counts":[]}`, string(bin))
+
+	for _, invalid := range []estype.Histogram{
+		{Values: []float64{0.1, 0.2}, Counts: []int64{1}},
+		{Values: []float64{0.2, 0.1}, Counts: []int64{1, 1}},
+		{Values: []float64{0.1, 0.1}, Counts: []int64{1, 1}},
+		{Values: []float64{0.1, 0.2}, Counts: []int64{1, -1}},
+	} {
+		require.ErrorIs(t, invalid.Validate(), estype.ErrInvalidHistogram, "%+v", invalid)
+
+		_, err := json.Marshal(invalid)
+		require.ErrorIs(t, err, estype.ErrInvalidHistogram, "%+v", invalid)
+
+		bin, _ := json.Marshal(struct {
+			Values []float64 `json:"values"`
+			Counts []int64   `json:"counts"`
+		}(invalid))
+		var h estype.Histogram
+		require.ErrorIs(t, json.Unmarshal(bin, &h), estype.ErrInvalidHistogram, "%s", bin)
+	}
+}
@@ -0,0 +1,63 @@
+package estype
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Join is a value of join fields.
+// It is unmarshalled from both the object form, {"name": "answer", "parent": "1"},
+// and the string shorthand for parent documents, "question".
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/parent-join.html
+type Join struct {
+	// Name is the relation name of the document.
+	Name string `json:"name"`
+	// Parent is the id of the parent document. It is empty for documents which have no parent.
+	Parent string `json:"parent,omitempty"`
+}
+
+func (j *Join) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return err
+		}
+		*j = Join{Name: name}
+		return nil
+	}
+	type plain Join
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*j = Join(p)
+	return nil
+}
+
+var (
+	// ErrUnknownRelation is returned when a relation name of Join is not declared in the mapping.
+	ErrUnknownRelation = errors.New("unknown relation")
+	// ErrMissingParent is returned when Join of a child relation has no parent id.
+	ErrMissingParent = errors.New("missing parent")
+)
+
+// JoinRelations maps relation names declared in relations of a join field to names of their parent relations.
+// Names of root relations, which have no parent, are mapped to "".
+type JoinRelations map[string]string
+
+// Check returns an error wrapping ErrUnknownRelation if j.Name is not declared in r,
+// or ErrMissingParent if j is of a child relation but has no parent id.
+func (r JoinRelations) Check(j Join) error {
+	parent, ok := r[j.Name]
+	if !ok {
+		return fmt.Errorf("%w: %q is not one of %v", ErrUnknownRelation, j.Name, sortedKeys(r))
+	}
+	if parent != "" && j.Parent == "" {
+		return fmt.Errorf("%w: %q is a child of %q, but parent id is empty", ErrMissingParent, j.Name, parent)
+	}
+	return nil
+}
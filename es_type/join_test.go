@@ -0,0 +1,40 @@
+package estype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	for _, testCase := range []struct {
+		input    string
+		expected estype.Join
+	}{
+		{`"question"`, estype.Join{Name: "question"}},
+		{`{"name":"question"}`, estype.Join{Name: "question"}},
+		{`{"name":"answer","parent":"1"}`, estype.Join{Name: "answer", Parent: "1"}},
+	} {
+		var j estype.Join
+		require.NoError(t, json.Unmarshal([]byte(testCase.input), &j), testCase.input)
+		require.Equal(t, testCase.expected, j)
+	}
+
+	bin, err := json.Marshal(estype.Join{Name: "question"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"question"}`, string(bin))
+}
+
+func TestJoinRelations(t *testing.T) {
+	relations := estype.JoinRelations{
+		"question": "",
+		"answer":   "question",
+	}
+
+	require.NoError(t, relations.Check(estype.Join{Name: "question"}))
+	require.NoError(t, relations.Check(estype.Join{Name: "answer", Parent: "1"}))
+	require.ErrorIs(t, relations.Check(estype.Join{Name: "comment"}), estype.ErrUnknownRelation)
+	require.ErrorIs(t, relations.Check(estype.Join{Name: "answer"}), estype.ErrMissingParent)
+}
@@ -0,0 +1,126 @@
+package estype
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-spatial/geom"
+	"github.com/go-spatial/geom/encoding/wkt"
+)
+
+// Point is a value of point fields, a point in a two-dimensional cartesian coordinate system.
+//
+// It is unmarshalled from every format Elasticsearch accepts:
+// an object of x and y, an array of [x, y], a string of "x,y", a well-known text POINT(x y),
+// and a GeoJSON Point. Unlike Geopoint, coordinates are x then y in every format.
+// It is marshalled into the object form, {"x": 1, "y": 2}.
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/point.html
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func UnmarshalEsPointJSON(data []byte) (Point, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) < 3 {
+		return Point{}, fmt.Errorf("too short: input must longer than 3 chars but %d", len(data))
+	}
+	switch data[0] {
+	case '[':
+		// [x, y]
+		var d []float64
+		if err := json.Unmarshal(data, &d); err != nil {
+			return Point{}, err
+		}
+		if len(d) < 2 {
+			return Point{}, fmt.Errorf("too short: must be [x, y] but %d", len(d))
+		}
+		return Point{X: d[0], Y: d[1]}, nil
+	case '"':
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return Point{}, err
+		}
+		return UnmarshalEsPointText([]byte(text))
+	case '{':
+		var obj struct {
+			Type        *string   `json:"type"`
+			Coordinates []float64 `json:"coordinates"`
+			X           *float64  `json:"x"`
+			Y           *float64  `json:"y"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return Point{}, err
+		}
+		if obj.Type != nil {
+			// GeoJSON
+			if *obj.Type != "Point" {
+				return Point{}, fmt.Errorf("type must be Point but is %s", *obj.Type)
+			}
+			if len(obj.Coordinates) < 2 {
+				return Point{}, fmt.Errorf("too short: must have coordinates which is Number[] of at least 2 elements")
+			}
+			return Point{X: obj.Coordinates[0], Y: obj.Coordinates[1]}, nil
+		}
+		if obj.X != nil && obj.Y != nil {
+			return Point{X: *obj.X, Y: *obj.Y}, nil
+		}
+	}
+	return Point{}, fmt.Errorf("unknown format: %s", string(data))
+}
+
+func UnmarshalEsPointText(text []byte) (Point, error) {
+	strText := strings.TrimSpace(string(text))
+	if strings.HasPrefix(strings.ToUpper(strText), "POINT") {
+		// POINT(x y)
+		geo, err := wkt.Decode(strings.NewReader(strText))
+		if err != nil {
+			return Point{}, err
+		}
+		point, ok := geo.(geom.Point)
+		if !ok {
+			return Point{}, fmt.Errorf("unknown: must be point but is %T", geo)
+		}
+		return Point{X: point.X(), Y: point.Y()}, nil
+	}
+
+	// x,y
+	coordinates := strings.Split(strText, ",")
+	if len(coordinates) < 2 {
+		return Point{}, fmt.Errorf(
+			`unknown: %s. UnmarshalText only supports well-known text: "POINT(x y)" or "x,y"`,
+			strText,
+		)
+	}
+	x, err := strconv.ParseFloat(strings.TrimSpace(coordinates[0]), 64)
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(coordinates[1]), 64)
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var err error
+	*p, err = UnmarshalEsPointJSON(data)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *Point) UnmarshalText(text []byte) error {
+	var err error
+	*p, err = UnmarshalEsPointText(text)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package estype_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoint(t *testing.T) {
+	inputs := [][]byte{
+		[]byte(`{"type": "Point", "coordinates": [-71.34, 41.12]}`),
+		[]byte(`{"x": -71.34, "y": 41.12}`),
+		[]byte(`[ -71.34, 41.12 ]`),
+		[]byte(`"-71.34,41.12"`),
+		[]byte(`"POINT (-71.34 41.12)"`),
+	}
+
+	for _, v := range inputs {
+		var p estype.Point
+
+		err := json.Unmarshal(v, &p)
+		require.NoError(t, err, string(v))
+
+		require.InDelta(t, -71.34, p.X, 0.001)
+		require.InDelta(t, 41.12, p.Y, 0.001)
+
+		bin, err := json.Marshal(p)
+		require.NoError(t, err)
+
+		require.True(t, bytes.Contains(bin, []byte(`"x"`)))
+		require.True(t, bytes.Contains(bin, []byte(`"y"`)))
+
+		var p2 estype.Point
+
+		err = json.Unmarshal(bin, &p2)
+		require.NoError(t, err)
+
+		require.Equal(t, p, p2)
+	}
+
+	for _, v := range [][]byte{
+		[]byte(`{"type": "LineString", "coordinates": [[1, 2], [3, 4]]}`),
+		[]byte(`{"lat": 41.12, "lon": -71.34}`),
+		[]byte(`"drm3btev3e86"`),
+		[]byte(`[1]`),
+	} {
+		var p estype.Point
+		require.Error(t, json.Unmarshal(v, &p), string(v))
+	}
+}
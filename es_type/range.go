@@ -0,0 +1,96 @@
+package estype
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+)
+
+// Range is a value of range fields, e.g. integer_range, double_range or date_range.
+// Nil bounds are unbounded.
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/range.html
+type Range[T any] struct {
+	Gt  *T `json:"gt,omitempty"`
+	Gte *T `json:"gte,omitempty"`
+	Lt  *T `json:"lt,omitempty"`
+	Lte *T `json:"lte,omitempty"`
+}
+
+// IPRange is a value of ip_range fields.
+// It is an object of bounds, or a CIDR notation string, e.g. "192.168.0.0/16".
+type IPRange struct {
+	Range[netip.Addr]
+	// CIDR is set if the value is a CIDR notation string.
+	// Gte and Lte are set to the first and the last address of it when unmarshalled.
+	// IPRange is marshalled into the CIDR string if CIDR is non-nil, ignoring bounds.
+	CIDR *netip.Prefix
+}
+
+// NewIPRangeFromPrefix returns IPRange of prefix, whose bounds are the first and the last address of it.
+func NewIPRangeFromPrefix(prefix netip.Prefix) IPRange {
+	prefix = prefix.Masked()
+	first, last := prefix.Addr(), lastAddr(prefix)
+	return IPRange{
+		Range: Range[netip.Addr]{Gte: &first, Lte: &last},
+		CIDR:  &prefix,
+	}
+}
+
+func (r IPRange) MarshalJSON() ([]byte, error) {
+	if r.CIDR != nil {
+		return json.Marshal(r.CIDR.String())
+	}
+	return json.Marshal(r.Range)
+}
+
+func (r *IPRange) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return fmt.Errorf("ip_range: %w", err)
+		}
+		*r = NewIPRangeFromPrefix(prefix)
+		return nil
+	}
+
+	var rng Range[netip.Addr]
+	if err := json.Unmarshal(data, &rng); err != nil {
+		return err
+	}
+	*r = IPRange{Range: rng}
+	return nil
+}
+
+// lastAddr returns the last address of the masked prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Addr()
+	if addr.Is4() {
+		b := addr.As4()
+		setHostBits(b[:], prefix.Bits())
+		return netip.AddrFrom4(b)
+	}
+	b := addr.As16()
+	setHostBits(b[:], prefix.Bits())
+	return netip.AddrFrom16(b).WithZone(addr.Zone())
+}
+
+func setHostBits(b []byte, bits int) {
+	for i := range b {
+		switch {
+		case bits >= 8:
+			bits -= 8
+		case bits > 0:
+			b[i] |= 0xff >> bits
+			bits = 0
+		default:
+			b[i] = 0xff
+		}
+	}
+}
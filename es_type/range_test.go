@@ -0,0 +1,49 @@
+package estype_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange(t *testing.T) {
+	var r estype.Range[int32]
+	require.NoError(t, json.Unmarshal([]byte(`{"gte":10,"lt":20}`), &r))
+	require.Nil(t, r.Gt)
+	require.Nil(t, r.Lte)
+	require.Equal(t, int32(10), *r.Gte)
+	require.Equal(t, int32(20), *r.Lt)
+
+	bin, err := json.Marshal(r)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"gte":10,"lt":20}`, string(bin))
+}
+
+func TestIPRange(t *testing.T) {
+	for _, testCase := range []struct {
+		input     string
+		gte, lte  string
+		marshaled string
+	}{
+		{`"192.168.0.0/16"`, "192.168.0.0", "192.168.255.255", `"192.168.0.0/16"`},
+		{`"10.1.2.3/20"`, "10.1.0.0", "10.1.15.255", `"10.1.0.0/20"`},
+		{`"2001:db8::/33"`, "2001:db8::", "2001:db8:7fff:ffff:ffff:ffff:ffff:ffff", `"2001:db8::/33"`},
+		{`"10.0.0.1/32"`, "10.0.0.1", "10.0.0.1", `"10.0.0.1/32"`},
+		{`{"gte":"10.0.0.1","lte":"10.0.0.9"}`, "10.0.0.1", "10.0.0.9", `{"gte":"10.0.0.1","lte":"10.0.0.9"}`},
+	} {
+		var r estype.IPRange
+		require.NoError(t, json.Unmarshal([]byte(testCase.input), &r), testCase.input)
+		require.Equal(t, netip.MustParseAddr(testCase.gte), *r.Gte, testCase.input)
+		require.Equal(t, netip.MustParseAddr(testCase.lte), *r.Lte, testCase.input)
+
+		bin, err := json.Marshal(r)
+		require.NoError(t, err)
+		require.JSONEq(t, testCase.marshaled, string(bin), testCase.input)
+	}
+
+	var r estype.IPRange
+	require.Error(t, json.Unmarshal([]byte(`"192.168.0.0"`), &r))
+}
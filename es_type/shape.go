@@ -0,0 +1,32 @@
+package estype
+
+import (
+	"encoding/json"
+
+	"github.com/go-spatial/geom"
+	"github.com/go-spatial/geom/encoding/geojson"
+)
+
+// Shape is a value of shape fields, a geometry in a two-dimensional cartesian coordinate system.
+//
+// It accepts GeoJSON and well-known text as Geoshape does,
+// but coordinates are arbitrary x and y, not longitude and latitude. No geographic validation is done.
+// It is marshalled into GeoJSON.
+//
+// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/shape.html
+type Shape struct {
+	Geometry geom.Geometry
+}
+
+func (s *Shape) UnmarshalJSON(data []byte) error {
+	geo, err := unmarshalGeometryJSON(data)
+	if err != nil {
+		return err
+	}
+	s.Geometry = geo
+	return nil
+}
+
+func (s Shape) MarshalJSON() ([]byte, error) {
+	return json.Marshal(geojson.Geometry{Geometry: s.Geometry})
+}
@@ -0,0 +1,82 @@
+package estype_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-spatial/geom"
+	"github.com/google/go-cmp/cmp"
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShape(t *testing.T) {
+	require := require.New(t)
+
+	// coordinates out of the range of longitude and latitude are accepted.
+	points := [][]byte{
+		[]byte(`{"type" : "Point", "coordinates" : [-377.03653, 1038.897676]}`),
+		[]byte(`"POINT (-377.03653 1038.897676)"`),
+	}
+
+	for _, bin := range points {
+		var s estype.Shape
+
+		err := json.Unmarshal(bin, &s)
+		require.NoError(err)
+
+		point := s.Geometry.(geom.Point)
+		require.InDelta(-377.03653, point.X(), 0.001)
+		require.InDelta(1038.897676, point.Y(), 0.001)
+
+		bin, err := json.Marshal(s)
+		require.NoError(err)
+
+		// it does marshal into geojson.
+		require.True(bytes.HasPrefix(bin, []byte(`{`)))
+		require.True(bytes.HasSuffix(bin, []byte(`}`)))
+
+		var s2 estype.Shape
+
+		err = json.Unmarshal(bin, &s2)
+		require.NoError(err)
+
+		point = s2.Geometry.(geom.Point)
+		require.InDelta(-377.03653, point.X(), 0.001)
+		require.InDelta(1038.897676, point.Y(), 0.001)
+	}
+
+	lines := [][]byte{
+		[]byte(`{
+			"type" : "LineString",
+			"coordinates" : [[-377.03653, 1038.897676], [-377.009051, 1038.889939]]
+		}`),
+		[]byte(`"LINESTRING (-377.03653 1038.897676, -377.009051 1038.889939)"`),
+	}
+
+	for _, bin := range lines {
+		var s estype.Shape
+
+		err := json.Unmarshal(bin, &s)
+		require.NoError(err)
+
+		expected := [][2]float64{{-377.03653, 1038.897676}, {-377.009051, 1038.889939}}
+		line := s.Geometry.(geom.LineString)
+		require.Condition(func() bool { return cmp.Equal(expected, line.Vertices()) })
+
+		bin, err := json.Marshal(s)
+		require.NoError(err)
+
+		var s2 estype.Shape
+
+		err = json.Unmarshal(bin, &s2)
+		require.NoError(err)
+
+		line2 := s2.Geometry.(geom.LineString)
+		require.Condition(func() bool { return cmp.Equal(expected, line2.Vertices()) })
+	}
+
+	var s estype.Shape
+	require.Error(json.Unmarshal([]byte(`123`), &s))
+}
@@ -0,0 +1,397 @@
+// Package fromstruct derives Elasticsearch mappings from Go struct types.
+//
+// Each exported field becomes a property named as encoding/json does.
+// Its type is inferred from the Go type, or set by the es struct tag:
+//
+//	type Doc struct {
+//		Name      string    `json:"name" es:"type=keyword,ignore_above=256"`
+//		Body      string    `json:"body" es:"type=text,analyzer=english"`
+//		CreatedAt time.Time `json:"created_at"`
+//		Internal  string    `json:"-"`
+//	}
+//
+// The tag is a comma separated list of key=value. "type" is the field type, and others are its params.
+// Values are booleans, numbers or strings. es:"-" skips the field.
+//
+// Inferred types are:
+//   - string: keyword
+//   - bool, estype.Boolean and estype.BooleanStr: boolean
+//   - int, int64, uint32: long. int32, uint16: integer. int16, uint8: short. int8: byte.
+//   - uint, uint64: unsigned_long
+//   - float64: double. float32: float.
+//   - []byte: binary
+//   - time.Time and types converted from it: date.
+//     Built-in date types of estype get date_nanos or the format the type is named after.
+//   - netip.Addr: ip
+//   - estype.Geopoint: geo_point. estype.Geoshape: geo_shape.
+//   - estype.Point: point. estype.Shape: shape.
+//   - estype.Completion: completion.
+//   - other structs: object with properties
+//   - maps: object without properties, with dynamic true
+//
+// Pointers, slices, arrays and estype.Field[T] are unwrapped, since any field can have arrays of values.
+package fromstruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	builtinformat "github.com/ngicks/elastic-type/es_type/builtin_format"
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+// TagName is the struct tag key read by Mappings.
+const TagName = "es"
+
+// Mappings derives mappings from v, a struct or a pointer to a struct.
+//
+// err is returned if a field has a Go type whose Elasticsearch type can not be inferred,
+// or if a tag is malformed. If derived mappings have problems, err is ValidationErrors of mapping package.
+func Mappings(v any) (mapping.Mappings, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return mapping.Mappings{}, fmt.Errorf("fromstruct: v must be a struct or a pointer to a struct, but is %T", v)
+	}
+
+	props, err := Properties(t)
+	if err != nil {
+		return mapping.Mappings{}, err
+	}
+	m := mapping.Mappings{}
+	if len(props) > 0 {
+		m.Properties = &props
+	}
+	if err := m.Validate(); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// Properties derives properties from fields of t, which must be a struct type.
+func Properties(t reflect.Type) (mapping.Properties, error) {
+	d := &deriver{visiting: map[reflect.Type]bool{}}
+	return d.properties("", t)
+}
+
+type deriver struct {
+	// visiting detects recursive types, which can not be mapped.
+	visiting map[reflect.Type]bool
+}
+
+func (d *deriver) properties(path string, t reflect.Type) (mapping.Properties, error) {
+	if d.visiting[t] {
+		return nil, fmt.Errorf("fromstruct: %s: recursive type %s", displayPath(path), t)
+	}
+	d.visiting[t] = true
+	defer delete(d.visiting, t)
+
+	props := mapping.Properties{}
+	if err := d.fields(path, t, props); err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+func (d *deriver) fields(path string, t reflect.Type, props mapping.Properties) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		esTag := field.Tag.Get(TagName)
+		if esTag == "-" {
+			continue
+		}
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				// Fields of embedded structs are promoted, as encoding/json does.
+				if err := d.fields(path, ft, props); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := path + name
+		prop, err := d.property(fieldPath, field.Type, esTag)
+		if err != nil {
+			return err
+		}
+		props[name] = prop
+	}
+	return nil
+}
+
+func (d *deriver) property(path string, t reflect.Type, tag string) (mapping.Property, error) {
+	params, err := parseTag(tag)
+	if err != nil {
+		return mapping.Property{}, fmt.Errorf("fromstruct: %s: %w", path, err)
+	}
+
+	t = unwrap(t)
+
+	ty, _ := params["type"].(string)
+	if ty == "" {
+		inferred, format, ok := infer(t)
+		if !ok {
+			return mapping.Property{}, fmt.Errorf(
+				"fromstruct: %s: can not infer type from %s. set it by tag, e.g. %s:\"type=keyword\"",
+				path, t, TagName,
+			)
+		}
+		ty = string(inferred)
+		if format != "" {
+			if _, ok := params["format"]; !ok {
+				params["format"] = format
+			}
+		}
+	}
+
+	if t.Kind() == reflect.Map && mapping.EsType(ty) == mapping.Object {
+		if _, ok := params["dynamic"]; !ok {
+			params["dynamic"] = true
+		}
+	}
+
+	switch mapping.EsType(ty) {
+	case mapping.Object, mapping.Nested, mapping.Passthrough:
+		if t.Kind() == reflect.Struct && !isKnownStruct(t) {
+			props, err := d.properties(path+".", t)
+			if err != nil {
+				return mapping.Property{}, err
+			}
+			if len(props) > 0 {
+				params["properties"] = props
+			}
+		}
+	}
+	if mapping.EsType(ty) != mapping.Object {
+		params["type"] = ty
+	} else {
+		delete(params, "type")
+	}
+
+	bin, err := json.Marshal(params)
+	if err != nil {
+		return mapping.Property{}, fmt.Errorf("fromstruct: %s: %w", path, err)
+	}
+	var prop mapping.Property
+	if err := json.Unmarshal(bin, &prop); err != nil {
+		return mapping.Property{}, fmt.Errorf("fromstruct: %s: %w", path, err)
+	}
+
+	// Params not modeled in the param struct are kept in Unknown. Those are most likely typos.
+	if len(prop.Unknown) > 0 {
+		keys := make([]string, 0, len(prop.Unknown))
+		for key := range prop.Unknown {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return mapping.Property{}, fmt.Errorf("fromstruct: %s: %s is not a param of %s", path, strings.Join(keys, ", "), ty)
+	}
+	return prop, nil
+}
+
+// parseTag parses key=value pairs of tag.
+func parseTag(tag string) (map[string]any, error) {
+	params := map[string]any{}
+	if tag == "" {
+		return params, nil
+	}
+	for _, pair := range strings.Split(tag, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("malformed tag %q: must be key=value", pair)
+		}
+		if _, ok := params[key]; ok {
+			return nil, fmt.Errorf("malformed tag: duplicate key %q", key)
+		}
+		params[key] = parseValue(key, value)
+	}
+	return params, nil
+}
+
+func parseValue(key, value string) any {
+	switch key {
+	case "type", "format", "analyzer", "search_analyzer", "normalizer", "path":
+		return value
+	}
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
+
+func jsonName(field reflect.StructField) (name string, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, false
+}
+
+var (
+	elemTyperType  = reflect.TypeOf((*estype.ElemTyper)(nil)).Elem()
+	timeType       = reflect.TypeOf(time.Time{})
+	addrType       = reflect.TypeOf(netip.Addr{})
+	geopointType   = reflect.TypeOf(estype.Geopoint{})
+	geoshapeType   = reflect.TypeOf(estype.Geoshape{})
+	pointType      = reflect.TypeOf(estype.Point{})
+	shapeType      = reflect.TypeOf(estype.Shape{})
+	completionType = reflect.TypeOf(estype.Completion{})
+	bytesType      = reflect.TypeOf([]byte{})
+	estypePkgPath  = geopointType.PkgPath()
+)
+
+// unwrap unwraps pointers, slices, arrays and estype.Field[T], except for []byte.
+func unwrap(t reflect.Type) reflect.Type {
+	for {
+		switch {
+		case t.Kind() == reflect.Pointer:
+			t = t.Elem()
+		case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t != bytesType:
+			t = t.Elem()
+		case isField(t):
+			t = reflect.Zero(t).Interface().(estype.ElemTyper).ElemType()
+		default:
+			return t
+		}
+	}
+}
+
+func isField(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(elemTyperType)
+}
+
+func isKnownStruct(t reflect.Type) bool {
+	_, _, ok := inferStruct(t)
+	return ok
+}
+
+// infer infers Elasticsearch type from t. format is non empty if the type needs a date format.
+func infer(t reflect.Type) (ty mapping.EsType, format string, ok bool) {
+	if t == bytesType {
+		return mapping.Binary, "", true
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return mapping.Keyword, "", true
+	case reflect.Bool:
+		return mapping.Boolean, "", true
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return mapping.Long, "", true
+	case reflect.Int32, reflect.Uint16:
+		return mapping.Integer, "", true
+	case reflect.Int16, reflect.Uint8:
+		return mapping.Short, "", true
+	case reflect.Int8:
+		return mapping.Byte, "", true
+	case reflect.Uint, reflect.Uint64:
+		return mapping.UnsignedLong, "", true
+	case reflect.Float64:
+		return mapping.Double, "", true
+	case reflect.Float32:
+		return mapping.Float, "", true
+	case reflect.Map:
+		return mapping.Object, "", true
+	case reflect.Struct:
+		if ty, format, ok := inferStruct(t); ok {
+			return ty, format, true
+		}
+		return mapping.Object, "", true
+	}
+	return "", "", false
+}
+
+func inferStruct(t reflect.Type) (ty mapping.EsType, format string, ok bool) {
+	switch {
+	case t == addrType:
+		return mapping.IP, "", true
+	case t == geopointType:
+		return mapping.Geopoint, "", true
+	case t == geoshapeType:
+		return mapping.Geoshape, "", true
+	case t == pointType:
+		return mapping.Point, "", true
+	case t == shapeType:
+		return mapping.Shape, "", true
+	case t == completionType:
+		return mapping.Completion, "", true
+	case t.ConvertibleTo(timeType):
+		if t.PkgPath() != estypePkgPath {
+			return mapping.Date, "", true
+		}
+		// Built-in date types of estype are named after the format.
+		switch t.Name() {
+		case "StrictDateOptionalTimeEpochMillis":
+			return mapping.Date, "", true
+		case "StrictDateOptionalTimeNanosEpochMillis":
+			return mapping.DateNanoseconds, "", true
+		}
+		format := toSnakeCase(t.Name())
+		if _, ok := builtinformat.Formatters[format]; ok || format == builtinformat.EpochMillis || format == builtinformat.EpochSecond {
+			return mapping.Date, format, true
+		}
+		return mapping.Date, "", true
+	}
+	return "", "", false
+}
+
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func displayPath(path string) string {
+	path = strings.TrimSuffix(path, ".")
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
@@ -0,0 +1,116 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+// ErrAliasTarget is returned when the target of an alias field does not exist, or is not a concrete field.
+var ErrAliasTarget = errors.New("invalid alias target")
+
+// aliasField returns a GeneratedField for the alias prop. Its type is resolved later by resolveAliases,
+// after types of all fields are generated.
+func aliasField(name string, prop mapping.Property, root rootContext, fieldNames []string) (GeneratedField, error) {
+	param := prop.Param.(*mapping.AliasParams)
+	if param.Path == "" {
+		return GeneratedField{}, fmt.Errorf("%w: alias at %q has empty path", ErrAliasTarget, fieldPath(fieldNames))
+	}
+
+	target, ok := root.properties.Lookup(param.Path)
+	switch {
+	case !ok:
+		return GeneratedField{}, fmt.Errorf(
+			"%w: target %q of alias at %q does not exist",
+			ErrAliasTarget, param.Path, fieldPath(fieldNames),
+		)
+	case target.IsObjectLike():
+		return GeneratedField{}, fmt.Errorf(
+			"%w: target %q of alias at %q is %s, but must be a concrete field",
+			ErrAliasTarget, param.Path, fieldPath(fieldNames), typeOf(target),
+		)
+	case target.Type == mapping.Alias:
+		return GeneratedField{}, fmt.Errorf(
+			"%w: target %q of alias at %q is an alias",
+			ErrAliasTarget, param.Path, fieldPath(fieldNames),
+		)
+	}
+
+	return GeneratedField{
+		Name:        name,
+		Prop:        prop,
+		AliasPath:   param.Path,
+		AliasTarget: target,
+	}, nil
+}
+
+// resolveAliases sets TyName and Imports of alias fields in highLevelTy[0].Fields to those of their targets.
+// If the target has no generated type, e.g. a multi-field or a field under a dynamic object,
+// the type is generated and appended to the returned types.
+func resolveAliases(
+	highLevelTy, rawTy, testDef []GeneratedType,
+	globalOpt GlobalOption,
+	tyName string,
+) ([]GeneratedType, []GeneratedType, []GeneratedType, error) {
+	root := highLevelTy[0].Fields
+
+	var resolve func(fields []GeneratedField, fieldNames []string) error
+	resolve = func(fields []GeneratedField, fieldNames []string) error {
+		for i := range fields {
+			field := &fields[i]
+			names := append(fieldNames[:len(fieldNames):len(fieldNames)], field.Name)
+			if field.Children != nil {
+				if err := resolve(field.Children, names); err != nil {
+					return err
+				}
+			}
+			if field.AliasPath == "" {
+				continue
+			}
+
+			if target, ok := lookupGeneratedField(root, strings.Split(field.AliasPath, ".")); ok && target.TyName != "" {
+				field.TyName = target.TyName
+				field.Imports = target.Imports
+				continue
+			}
+
+			gen, td, err := Field(field.AliasTarget, names, globalOpt, globalOpt.Overlay(field.AliasTarget, FieldOption{}))
+			if err != nil {
+				return err
+			}
+			field.TyName = gen.TyName
+			field.Imports = gen.Imports
+			highLevelTy = append(highLevelTy, gen)
+			rawTy = append(rawTy, GeneratedType{Imports: gen.Imports})
+			testDef = append(testDef, td)
+		}
+		return nil
+	}
+
+	if err := resolve(root, []string{tyName}); err != nil {
+		return nil, nil, nil, err
+	}
+	return highLevelTy, rawTy, testDef, nil
+}
+
+// lookupGeneratedField finds a field at the path in fields.
+// Longer names are tried first, so that names containing dots take precedence.
+func lookupGeneratedField(fields []GeneratedField, segments []string) (GeneratedField, bool) {
+	for i := len(segments); i > 0; i-- {
+		name := strings.Join(segments[:i], ".")
+		for _, field := range fields {
+			if field.Name != name {
+				continue
+			}
+			if i == len(segments) {
+				return field, true
+			}
+			if found, ok := lookupGeneratedField(field.Children, segments[i:]); ok {
+				return found, true
+			}
+		}
+	}
+	return GeneratedField{}, false
+}
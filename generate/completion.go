@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+// CompletionFromParam generates a type for completion fields.
+// If param has contexts, the generated type only accepts contexts declared in param.
+// Otherwise it is estype.Completion.
+func CompletionFromParam(param mapping.CompletionParams, tyName string) GeneratedType {
+	if len(param.Contexts) == 0 {
+		return GeneratedType{TyName: estypePrefix + "Completion", Imports: estypeImport}
+	}
+
+	tmplParam := completionTemplateParam{
+		TyName:   capitalize(tyName),
+		Contexts: param.Contexts,
+	}
+	buf := bytes.NewBuffer(make([]byte, 0))
+	if err := completionTemplate.Execute(buf, tmplParam); err != nil {
+		panic(err)
+	}
+
+	return GeneratedType{
+		TyName:  tmplParam.TyName,
+		TyDef:   buf.String(),
+		Imports: append([]string{`"encoding/json"`}, estypeImport...),
+	}
+}
+
+type completionTemplateParam struct {
+	TyName   string
+	Contexts []mapping.CompletionContext
+}
+
+var completionTemplate = template.Must(template.New("completionTemplate").Parse(`
+// {{.TyName}} is a value of the completion field. Only contexts declared in the mapping are accepted.
+type {{.TyName}} estype.Completion
+
+var contexts{{.TyName}} = estype.CompletionContextTypes{
+{{- range .Contexts}}
+	{{printf "%q" .Name}}: {{printf "%q" .Type}},
+{{- end}}
+}
+
+func (c {{.TyName}}) MarshalJSON() ([]byte, error) {
+	if err := contexts{{.TyName}}.Check(estype.Completion(c)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(estype.Completion(c))
+}
+
+func (c *{{.TyName}}) UnmarshalJSON(data []byte) error {
+	var v estype.Completion
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := contexts{{.TyName}}.Check(v); err != nil {
+		return err
+	}
+	*c = {{.TyName}}(v)
+	return nil
+}
+`))
@@ -34,6 +34,25 @@ func DateFromParam(
 	}
 
 	formats := *prop.Format
+	switch formats {
+	case builtinformat.EpochMillis, builtinformat.EpochSecond:
+		// Values are always marshalled into numbers. Use built-in types.
+		if marshallingFormat != "" {
+			return GeneratedType{}, fmt.Errorf(
+				"preferred format %s is not applicable to format %s, which has no string format",
+				marshallingFormat,
+				formats,
+			)
+		}
+		tyName := estypePrefix + "EpochMillis"
+		if formats == builtinformat.EpochSecond {
+			tyName = estypePrefix + "EpochSecond"
+		}
+		return GeneratedType{
+			TyName:  tyName,
+			Imports: estypeImport,
+		}, nil
+	}
 	layouts, hasNumFormat, isMillis, err := ParseFormatsString(formats)
 	if err != nil {
 		return GeneratedType{}, err
@@ -118,12 +137,17 @@ func ParseFormatsString(formats string) (layouts *flextime.LayoutSet, hasNumForm
 
 // ParseFormats returns a parsed time layout set with number formats (`epoch_millis` || `epoch_second`) removed.
 //
+// It returns an error if formats has no format other than number formats.
+//
 // hasNumFormats is true if the formats has epoch_millis or epoch_seconds.
 // isMillis is true if and only if hasNumFormats is true and one of formats if epoch_millis.
 func ParseFormats(formats []string) (layouts *flextime.LayoutSet, hasNumFormat, isMillis bool, err error) {
 	// TODO: Expand strFormats to be [][2]string or whatever, and allow non built-in format to have `[]`.
 	// Currently `[]`s in user-defined formats are interpreted as optional parts.
 	strFormats, hasNumFormat, isMillis, _ := toTimeTokenFormat(formats)
+	if len(strFormats) == 0 {
+		return nil, false, false, fmt.Errorf("formats %v have no format other than epoch_millis or epoch_second", formats)
+	}
 
 	first, rest := strFormats[0], strFormats[1:]
 
@@ -0,0 +1,144 @@
+package generate
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+// DenseVectorFromParam generates a type for dense_vector fields, whose length is checked against dims of param.
+// The type has Score method, computing _score of kNN search as Elasticsearch does with similarity of param.
+//
+// Elements are float32 for float, and int8 for byte and bit. Bit vectors are packed into dims / 8 bytes.
+// Similarity defaults to l2_norm for bit, and cosine otherwise.
+func DenseVectorFromParam(param mapping.DenseVectorParams, tyName string) GeneratedType {
+	elementType := mapping.ElementFloat
+	if param.ElementType != nil {
+		elementType = *param.ElementType
+	}
+
+	tmplParam := denseVectorTemplateParam{
+		TyName:      capitalize(tyName),
+		ElementType: string(elementType),
+		Dims:        param.Dims,
+	}
+	switch {
+	case param.Similarity != nil:
+		tmplParam.Similarity = string(*param.Similarity)
+	case elementType == mapping.ElementBit:
+		tmplParam.Similarity = string(mapping.L2Norm)
+	default:
+		tmplParam.Similarity = string(mapping.Cosine)
+	}
+	// Elasticsearch checks vectors for the similarity only if they are indexed.
+	if param.Similarity != nil || (param.Index != nil && *param.Index) {
+		tmplParam.Checked = tmplParam.Similarity
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	if err := denseVectorTemplate.Execute(buf, tmplParam); err != nil {
+		panic(err)
+	}
+
+	return GeneratedType{
+		TyName:  tmplParam.TyName,
+		TyDef:   buf.String(),
+		Imports: append([]string{`"encoding/json"`}, estypeImport...),
+	}
+}
+
+type denseVectorTemplateParam struct {
+	TyName string
+	// Dims is zero if dims is omitted, in which case length of vectors is not checked.
+	Dims        int
+	ElementType string
+	Similarity  string
+	// Checked is the similarity vectors are checked for on decode and encode, or empty if not indexed.
+	Checked string
+}
+
+var denseVectorTemplate = template.Must(template.New("denseVectorTemplate").Parse(`
+{{- if eq .ElementType "float"}}
+// {{.TyName}} is a value of the dense_vector field of {{if .Dims}}{{.Dims}}{{else}}any number of{{end}} float elements, compared by {{.Similarity}}.
+type {{.TyName}} []float32
+
+func (v {{.TyName}}) MarshalJSON() ([]byte, error) {
+	if err := estype.CheckFloatVector(v, {{if .Dims}}{{.Dims}}{{else}}len(v){{end}}, "{{.Checked}}"); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]float32(v))
+}
+
+func (v *{{.TyName}}) UnmarshalJSON(data []byte) error {
+	var vec []float32
+	if err := json.Unmarshal(data, &vec); err != nil {
+		return err
+	}
+	if err := estype.CheckFloatVector(vec, {{if .Dims}}{{.Dims}}{{else}}len(vec){{end}}, "{{.Checked}}"); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// Score returns _score of kNN search for v as a document vector, computed by {{.Similarity}} as Elasticsearch does.
+func (v {{.TyName}}) Score(query {{.TyName}}) (float32, error) {
+	return estype.FloatVectorScore("{{.Similarity}}", query, v)
+}
+{{- else if eq .ElementType "byte"}}
+// {{.TyName}} is a value of the dense_vector field of {{if .Dims}}{{.Dims}}{{else}}any number of{{end}} byte elements, compared by {{.Similarity}}.
+type {{.TyName}} []int8
+
+func (v {{.TyName}}) MarshalJSON() ([]byte, error) {
+	if err := estype.CheckByteVector(v, {{if .Dims}}{{.Dims}}{{else}}len(v){{end}}, "{{.Checked}}"); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]int8(v))
+}
+
+func (v *{{.TyName}}) UnmarshalJSON(data []byte) error {
+	vec, err := estype.UnmarshalByteVectorJSON(data)
+	if err != nil {
+		return err
+	}
+	if err := estype.CheckByteVector(vec, {{if .Dims}}{{.Dims}}{{else}}len(vec){{end}}, "{{.Checked}}"); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// Score returns _score of kNN search for v as a document vector, computed by {{.Similarity}} as Elasticsearch does.
+func (v {{.TyName}}) Score(query {{.TyName}}) (float32, error) {
+	return estype.ByteVectorScore("{{.Similarity}}", query, v)
+}
+{{- else}}
+// {{.TyName}} is a value of the dense_vector field of {{if .Dims}}{{.Dims}}{{else}}any number of{{end}} bit elements, packed into bytes.
+type {{.TyName}} []int8
+
+func (v {{.TyName}}) MarshalJSON() ([]byte, error) {
+	if err := estype.CheckBitVector(v, {{if .Dims}}{{.Dims}}{{else}}len(v) * 8{{end}}); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]int8(v))
+}
+
+func (v *{{.TyName}}) UnmarshalJSON(data []byte) error {
+	vec, err := estype.UnmarshalByteVectorJSON(data)
+	if err != nil {
+		return err
+	}
+	if err := estype.CheckBitVector(vec, {{if .Dims}}{{.Dims}}{{else}}len(vec) * 8{{end}}); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// Score returns _score of kNN search for v as a document vector, computed from the hamming distance as Elasticsearch does.
+func (v {{.TyName}}) Score(query {{.TyName}}) (float32, error) {
+	return estype.BitVectorScore(query, v)
+}
+{{- end}}
+`))
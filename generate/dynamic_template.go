@@ -0,0 +1,279 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/ngicks/elastic-type/internal/wildcard"
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+type dynamicTemplateField struct {
+	// Index is the index of the template in dynamic_templates.
+	Index        int
+	TemplateName string
+	GoName       string
+	TyName       string
+}
+
+type dynamicTemplateParam struct {
+	TyName   string
+	Path     string
+	Matchers string
+	Fields   []dynamicTemplateField
+}
+
+// dynamicTemplateObject generates types for a dynamic object whose fields are typed by dynamic templates.
+// It returns nil types if no template has a statically known type applicable to fields of the object.
+func dynamicTemplateObject(
+	tyName string,
+	globalOpt GlobalOption,
+	root rootContext,
+	fieldNames []string,
+) (highLevelTy, rawTy, testDef []GeneratedType, err error) {
+	templates, err := root.dynamicTemplates.Templates()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(templates) == 0 {
+		return nil, nil, nil, nil
+	}
+
+	objPath := strings.Join(fieldNames[1:], ".")
+	param := dynamicTemplateParam{
+		TyName: tyName,
+		Path:   objPath,
+	}
+
+	var subHighLevelTypes, subRawTypes, subTestDefs []GeneratedType
+	highImports := append([]string{}, estypeImport...)
+	rawImports := append([]string{`"encoding/json"`}, estypeImport...)
+	var matchers []string
+	var dynamicFields []DynamicField
+
+	usedGoName := map[string]bool{"Other": true}
+	for i, t := range templates {
+		matchers = append(matchers, dynamicTemplateMatcherLiteral(t))
+
+		if !couldMatchChildOf(t.Template, objPath) {
+			continue
+		}
+		prop, ok, err := t.Template.Property()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("dynamic template %s: %w", t.Name, err)
+		}
+		if !ok || prop.IsObjectLike() {
+			// Matched fields are stored in Other.
+			continue
+		}
+
+		gen, testDef, err := Field(prop, append(fieldNames, t.Name), globalOpt, FieldOption{})
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("dynamic template %s: %w", t.Name, err)
+		}
+		subHighLevelTypes = append(subHighLevelTypes, gen)
+		subRawTypes = append(subRawTypes, GeneratedType{Imports: gen.Imports})
+		subTestDefs = append(subTestDefs, testDef)
+		highImports = append(highImports, gen.Imports...)
+		rawImports = append(rawImports, gen.Imports...)
+
+		goName := toPascalCaseDelimiter(t.Name)
+		for usedGoName[goName] {
+			goName += "Template"
+		}
+		usedGoName[goName] = true
+
+		param.Fields = append(param.Fields, dynamicTemplateField{
+			Index:        i,
+			TemplateName: t.Name,
+			GoName:       goName,
+			TyName:       gen.TyName,
+		})
+		dynamicFields = append(dynamicFields, DynamicField{
+			TemplateName: t.Name,
+			GoName:       goName,
+			Prop:         prop,
+			TyName:       gen.TyName,
+		})
+	}
+
+	if len(param.Fields) == 0 {
+		return nil, nil, nil, nil
+	}
+
+	detection, detectionImports, err := dynamicDetectionLiteral(root.detection)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	rawImports = append(rawImports, detectionImports...)
+	param.Matchers = "estype.DynamicTemplateTable{\n" +
+		detection +
+		"\tMatchers: []estype.DynamicTemplateMatcher{\n" + strings.Join(matchers, "") + "\t},\n}"
+
+	highDef := bytes.NewBuffer(make([]byte, 0))
+	rawDef := bytes.NewBuffer(make([]byte, 0))
+	err = dynamicTemplateHighTemplate.Execute(highDef, param)
+	if err != nil {
+		panic(err)
+	}
+	err = dynamicTemplateRawTemplate.Execute(rawDef, param)
+	if err != nil {
+		panic(err)
+	}
+
+	highTy := GeneratedType{TyName: tyName, TyDef: highDef.String(), Imports: highImports, DynamicFields: dynamicFields}
+	return append([]GeneratedType{highTy}, subHighLevelTypes...),
+		append([]GeneratedType{{TyName: tyName + "Raw", TyDef: rawDef.String(), Imports: rawImports}}, subRawTypes...),
+		subTestDefs,
+		nil
+}
+
+func dynamicTemplateMatcherLiteral(t mapping.NamedDynamicTemplate) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\t{\n\t\tName: %q,\n", t.Name)
+	for _, f := range []struct {
+		name     string
+		patterns mapping.Patterns
+	}{
+		{"MatchMappingType", t.Template.MatchMappingType},
+		{"Match", t.Template.Match},
+		{"Unmatch", t.Template.Unmatch},
+		{"PathMatch", t.Template.PathMatch},
+		{"PathUnmatch", t.Template.PathUnmatch},
+	} {
+		if len(f.patterns) > 0 {
+			fmt.Fprintf(&b, "\t\t%s: %#v,\n", f.name, []string(f.patterns))
+		}
+	}
+	if t.Template.MatchPattern != nil && *t.Template.MatchPattern == mapping.Regex {
+		b.WriteString("\t\tMatchRegex: true,\n")
+	}
+	b.WriteString("\t},\n")
+	return b.String()
+}
+
+// dynamicDetectionLiteral returns a Detection field of estype.DynamicTemplateTable for d, and imports it needs.
+// It returns an empty string if d is the default.
+func dynamicDetectionLiteral(d mapping.DynamicDetection) (string, []string, error) {
+	if d.DateDetection != nil && !*d.DateDetection {
+		return "\tDetection: estype.DynamicDetection{DateDetectionDisabled: true},\n", nil, nil
+	}
+	if d.DynamicDateFormats == nil {
+		return "", nil, nil
+	}
+
+	var formats []string
+	for _, f := range d.DynamicDateFormats {
+		formats = append(formats, strings.Split(f, "||")...)
+	}
+	layouts, _, _, err := ParseFormats(formats)
+	if err != nil {
+		return "", nil, fmt.Errorf("dynamic_date_formats: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("\tDetection: estype.DynamicDetection{\n\t\tParseDate: flextime.NewFlextime(\n")
+	for i, layout := range layouts.Layout() {
+		if i == 0 {
+			fmt.Fprintf(&b, "\t\t\ttypeparamcommon.Must(flextime.NewLayoutSet(%q))", layout)
+		} else {
+			fmt.Fprintf(&b, ".\n\t\t\t\tAddLayout(typeparamcommon.Must(flextime.NewLayoutSet(%q)))", layout)
+		}
+	}
+	b.WriteString(",\n\t\t).Parse,\n\t},\n")
+	return b.String(), []string{
+		`"github.com/ngicks/flextime"`,
+		`typeparamcommon "github.com/ngicks/type-param-common"`,
+	}, nil
+}
+
+// couldMatchChildOf reports whether path_match of t could match a direct child of objPath.
+// It tests the pattern against objPath + "." + the last element of the pattern,
+// whose wildcards are replaced by a placeholder.
+func couldMatchChildOf(t mapping.DynamicTemplate, objPath string) bool {
+	if len(t.PathMatch) == 0 {
+		return true
+	}
+	for _, pattern := range t.PathMatch {
+		last := pattern[strings.LastIndex(pattern, ".")+1:]
+		witness := objPath + "." + strings.ReplaceAll(last, "*", "x")
+		if wildcard.SimpleMatch(pattern, witness) {
+			return true
+		}
+	}
+	return false
+}
+
+var dynamicTemplateHighTemplate = template.Must(template.New("dynamicTemplateHighTemplate").Parse(`
+type {{.TyName}} struct {
+{{- range .Fields}}
+	// {{.GoName}} holds fields matched to the dynamic template "{{.TemplateName}}".
+	{{.GoName}} map[string][]{{.TyName}}
+{{- end}}
+	// Other holds fields matched to none of dynamic templates with a statically known type.
+	Other map[string][]any
+}
+
+func (t {{.TyName}}) ToRaw() {{.TyName}}Raw {
+	return {{.TyName}}Raw{
+{{- range .Fields}}
+		{{.GoName}}: estype.DynamicFieldsToRaw(t.{{.GoName}}),
+{{- end}}
+		Other: estype.DynamicFieldsToRaw(t.Other),
+	}
+}
+`))
+
+var dynamicTemplateRawTemplate = template.Must(template.New("dynamicTemplateRawTemplate").Parse(`
+type {{.TyName}}Raw struct {
+{{- range .Fields}}
+	{{.GoName}} map[string]estype.Field[{{.TyName}}]
+{{- end}}
+	Other map[string]estype.Field[any]
+}
+
+var dynamicTemplates{{.TyName}} = {{.Matchers}}
+
+func (r {{.TyName}}Raw) MarshalJSON() ([]byte, error) {
+	out := map[string]any{}
+{{- range .Fields}}
+	estype.CollectDynamicFields(out, r.{{.GoName}})
+{{- end}}
+	estype.CollectDynamicFields(out, r.Other)
+	return json.Marshal(out)
+}
+
+func (r *{{.TyName}}Raw) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*r = {{.TyName}}Raw{}
+	for name, value := range fields {
+		var err error
+		switch dynamicTemplates{{.TyName}}.Match({{printf "%q" .Path}}+"."+name, name, value) {
+{{- range .Fields}}
+		case {{.Index}}:
+			err = estype.UnmarshalDynamicField(&r.{{.GoName}}, name, value)
+{{- end}}
+		default:
+			err = estype.UnmarshalDynamicField(&r.Other, name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t {{.TyName}}Raw) ToPlain() {{.TyName}} {
+	return {{.TyName}}{
+{{- range .Fields}}
+		{{.GoName}}: estype.DynamicFieldsToPlain(t.{{.GoName}}),
+{{- end}}
+		Other: estype.DynamicFieldsToPlain(t.Other),
+	}
+}
+`))
@@ -1,7 +1,10 @@
 package generate
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/ngicks/elastic-type/mapping"
 	"github.com/ngicks/type-param-common/slice"
@@ -25,9 +28,27 @@ func Field(
 	globalOpt GlobalOption,
 	opt FieldOption,
 ) (rawTy, testDef GeneratedType, err error) {
+	target, err := globalOpt.targetVersion()
+	if err != nil {
+		return GeneratedType{}, GeneratedType{}, err
+	}
+	if !prop.Type.AvailableIn(target) {
+		if globalOpt.UnknownTypeAsRaw.True() {
+			return rawMessage, GeneratedType{}, nil
+		}
+		addedIn, _ := prop.Type.AddedIn()
+		return GeneratedType{}, GeneratedType{}, fmt.Errorf(
+			"%w: %q at %q is added in %s, but TargetVersion is %s",
+			ErrUnknownType, prop.Type, fieldPath(fieldNames), addedIn, target,
+		)
+	}
+
 	if rawTy, ok := fieldTypeTable[prop.Type]; ok {
 		return rawTy, GeneratedType{}, nil
 	}
+	if rawTy, ok := registeredType(prop.Type); ok {
+		return rawTy, GeneratedType{}, nil
+	}
 
 	switch prop.Type {
 	case mapping.AggregateMetricDouble:
@@ -47,6 +68,24 @@ func Field(
 			},
 			GeneratedType{},
 			nil
+	case mapping.DenseVector:
+		param := prop.Param.(*mapping.DenseVectorParams)
+		if param.ElementType != nil {
+			if addedIn, ok := param.ElementType.AddedIn(); ok && target.Before(addedIn) {
+				return GeneratedType{}, GeneratedType{}, fmt.Errorf(
+					"element_type %q at %q is added in %s, but TargetVersion is %s",
+					*param.ElementType, fieldPath(fieldNames), addedIn, target,
+				)
+			}
+		}
+		return DenseVectorFromParam(*param, globalOpt.TypeNameGenerator.Gen(fieldNames)), GeneratedType{}, nil
+	case mapping.SemanticText:
+		// Before 8.18, _source of documents has an object with text and inference results,
+		// in place of the input string(s).
+		if target.Before(mapping.StackVersion{Major: 8, Minor: 18}) {
+			return GeneratedType{TyName: "any"}, GeneratedType{}, nil
+		}
+		return GeneratedType{TyName: "string"}, GeneratedType{}, nil
 	case mapping.Date, mapping.DateNanoseconds:
 		gen, err := DateFromParam(
 			*prop.Param.(*mapping.DateParams),
@@ -54,43 +93,118 @@ func Field(
 			opt.PreferredTimeMarshallingFormat,
 			opt.PreferTimeEpochMarshalling.True(),
 		)
-		testDef := DateTest(gen.TyName, "")
 		if err != nil {
 			return GeneratedType{}, GeneratedType{}, err
 		}
-		return gen, testDef, nil
+		if gen.TyDef == "" {
+			// built-in estype date types are tested in its own package.
+			return gen, GeneratedType{}, nil
+		}
+		return gen, DateTest(gen.TyName, ""), nil
+	case mapping.Completion:
+		gen := CompletionFromParam(*prop.Param.(*mapping.CompletionParams), globalOpt.TypeNameGenerator.Gen(fieldNames))
+		return gen, GeneratedType{}, nil
+	case mapping.Join:
+		gen, err := JoinFromParam(*prop.Param.(*mapping.JoinParams), globalOpt.TypeNameGenerator.Gen(fieldNames))
+		if err != nil {
+			return GeneratedType{}, GeneratedType{}, fmt.Errorf("join at %q: %w", fieldPath(fieldNames), err)
+		}
+		return gen, GeneratedType{}, nil
+	case mapping.DateRange:
+		param := prop.Param.(*mapping.DateRangeParams)
+		gen, err := DateFromParam(
+			mapping.DateParams{Type: mapping.Date, Format: param.Format},
+			globalOpt.TypeNameGenerator.Gen(fieldNames)+"Bound",
+			opt.PreferredTimeMarshallingFormat,
+			opt.PreferTimeEpochMarshalling.True(),
+		)
+		if err != nil {
+			return GeneratedType{}, GeneratedType{}, err
+		}
+		dateTyName := gen.TyName
+		gen.TyName = estypePrefix + "Range[" + dateTyName + "]"
+		gen.Imports = append(append([]string{}, gen.Imports...), estypeImport...)
+		if gen.TyDef == "" {
+			return gen, GeneratedType{}, nil
+		}
+		return gen, DateTest(dateTyName, ""), nil
+	}
+
+	if globalOpt.UnknownTypeAsRaw.True() {
+		return rawMessage, GeneratedType{}, nil
+	}
+	return GeneratedType{}, GeneratedType{}, fmt.Errorf(
+		"%w: %q at %q. register it by RegisterType, or set UnknownTypeAsRaw to generate json.RawMessage",
+		ErrUnknownType, prop.Type, fieldPath(fieldNames),
+	)
+}
+
+var rawMessage = GeneratedType{TyName: "json.RawMessage", Imports: []string{`"encoding/json"`}}
+
+// fieldPath returns the dotted path to the field. fieldNames[0] is the index name.
+func fieldPath(fieldNames []string) string {
+	if len(fieldNames) == 0 {
+		return ""
 	}
+	return strings.Join(fieldNames[1:], ".")
+}
+
+// ErrUnknownType is returned when a property has a type which is neither built-in nor registered by RegisterType.
+var ErrUnknownType = errors.New("unknown type")
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[mapping.EsType]GeneratedType{}
+)
+
+// RegisterType registers a Go type generated for fields of ty, a type which is not built into this package.
+// gen.TyName is the type name used in generated code, and gen.Imports are import specs it needs,
+// e.g. GeneratedType{TyName: "myplugin.Value", Imports: []string{`"example.com/myplugin"`}}.
+//
+// Use it along with mapping.RegisterType, which registers params of ty.
+// RegisterType panics if ty is already registered.
+func RegisterType(ty mapping.EsType, gen GeneratedType) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[ty]; ok {
+		panic(fmt.Sprintf("generate: RegisterType called twice for type %q", ty))
+	}
+	registry[ty] = gen
+}
 
-	// must not be reached
-	return GeneratedType{}, GeneratedType{}, fmt.Errorf("unknown type: %s", prop.Type)
+func registeredType(ty mapping.EsType) (GeneratedType, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	gen, ok := registry[ty]
+	return gen, ok
 }
 
 var fieldTypeTable = map[mapping.EsType]GeneratedType{
-	// FIXME: add special handling for this.
-	// Alias type needs knowledge about referenced field...
-	// Do nothing here?
+	// Generate resolves aliases to types of their targets. This is only for Field called directly.
 	mapping.Alias:           {TyName: "any"},
 	mapping.Binary:          {TyName: "[]byte"},
-	mapping.Completion:      {TyName: "string"},
-	mapping.DenseVector:     {TyName: "[]float64"}, // TODO: read dim and use an array instead of a slice?
 	mapping.Flattened:       {TyName: anyMap},
 	mapping.Geopoint:        {TyName: estypePrefix + "Geopoint", Imports: estypeImport},
 	mapping.Geoshape:        {TyName: estypePrefix + "Geoshape", Imports: estypeImport},
 	mapping.IP:              {TyName: "netip.Addr", Imports: []string{`"net/netip"`}},
-	mapping.Histogram:       {TyName: anyMap}, // TODO: implement
-	mapping.Join:            {TyName: anyMap}, // TODO: implement
+	mapping.Histogram:       {TyName: estypePrefix + "Histogram", Imports: estypeImport},
 	mapping.Percolator:      {TyName: anyMap}, // TODO: implement
-	mapping.Point:           {TyName: anyMap}, // TODO: implement
+	mapping.Point:           {TyName: estypePrefix + "Point", Imports: estypeImport},
 	mapping.RankFeature:     {TyName: "float64"},
 	mapping.RankFeatures:    {TyName: float64Map},
 	mapping.SearchAsYouType: {TyName: "string"},
-	mapping.Shape:           {TyName: estypePrefix + "Geoshape", Imports: estypeImport},
+	mapping.Shape:           {TyName: estypePrefix + "Shape", Imports: estypeImport},
 	mapping.TokenCount:      {TyName: "int64"},
 	mapping.Version:         {TyName: "string"}, // should this be sem ver package?
 	mapping.Keyword:         {TyName: "string"},
 	mapping.ConstantKeyword: {TyName: "string"}, // The field can be stored if and only if value is same as specified in param.
 	mapping.Wildcard:        {TyName: "string"},
 	mapping.Text:            {TyName: "string"},
+	mapping.MatchOnlyText:   {TyName: "string"},
+	mapping.AnnotatedText:   {TyName: "string"}, // Values are text with annotations in markdown-like syntax.
+	mapping.CountedKeyword:  {TyName: "string"},
+	mapping.SparseVector:    {TyName: float64Map},
 	// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/number.html
 	mapping.Long:         {TyName: "int64"},
 	mapping.Integer:      {TyName: "int32"},
@@ -101,12 +215,11 @@ var fieldTypeTable = map[mapping.EsType]GeneratedType{
 	mapping.HalfFloat:    {TyName: "float32"}, // TODO: use float16 package?
 	mapping.UnsignedLong: {TyName: "uint64"},
 	mapping.ScaledFloat:  {TyName: "float64"},
-	// TODO: implement
 	// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/range.html
-	mapping.IntegerRange: {TyName: anyMap},
-	mapping.FloatRange:   {TyName: anyMap},
-	mapping.LongRange:    {TyName: anyMap},
-	mapping.DoubleRange:  {TyName: anyMap},
-	mapping.DateRange:    {TyName: anyMap},
-	mapping.IpRange:      {TyName: anyMap},
+	// date_range is generated in Field, since its bounds are dates in the format of the field.
+	mapping.IntegerRange: {TyName: estypePrefix + "Range[int32]", Imports: estypeImport},
+	mapping.FloatRange:   {TyName: estypePrefix + "Range[float32]", Imports: estypeImport},
+	mapping.LongRange:    {TyName: estypePrefix + "Range[int64]", Imports: estypeImport},
+	mapping.DoubleRange:  {TyName: estypePrefix + "Range[float64]", Imports: estypeImport},
+	mapping.IpRange:      {TyName: estypePrefix + "IPRange", Imports: estypeImport},
 }
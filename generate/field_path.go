@@ -0,0 +1,185 @@
+package generate
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+// GenerateFieldPaths generates a typed tree of field paths for types generated by Generate.
+// highLevelTy must be the one returned from Generate.
+//
+// For the root type, it generates <TyName>FieldPaths and a variable <TyName>Fields,
+// so that paths can be referred like AllFields.Nested.Age.Path().
+// Each node is, or embeds, esquery.FieldPath which also carries the Elasticsearch type of the field.
+// Multi-fields defined in the fields parameter, and implicit sub-fields of search_as_you_type
+// (._2gram up to ._<max_shingle_size>gram, and ._index_prefix), are children of the field they belong to.
+func GenerateFieldPaths(highLevelTy []GeneratedType) []GeneratedType {
+	if len(highLevelTy) == 0 {
+		return nil
+	}
+	root := highLevelTy[0]
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	err := fieldPathsVarTemplate.Execute(buf, root.TyName)
+	if err != nil {
+		panic(err)
+	}
+
+	return append(
+		fieldPathsType(root.TyName, root.Fields, ""),
+		GeneratedType{TyName: root.TyName + "Fields", TyDef: buf.String()},
+	)
+}
+
+type fieldPathParam struct {
+	FieldName   string
+	TyName      string
+	Constructor string
+}
+
+type fieldPathsTemplateParam struct {
+	TyName string
+	// EsType is type of the node itself. Empty for the root.
+	EsType mapping.EsType
+	Fields []fieldPathParam
+}
+
+func fieldPathsType(tyName string, fields []GeneratedField, esType mapping.EsType) []GeneratedType {
+	var subTypes []GeneratedType
+
+	param := fieldPathsTemplateParam{
+		TyName: tyName,
+		EsType: esType,
+	}
+
+	for _, field := range fields {
+		pathExpr := `esquery.JoinPath(prefix, "` + field.Name + `")`
+		fieldEsType := field.EsType()
+		if field.Prop.IsObject() {
+			fieldEsType = mapping.Object
+		}
+
+		var subTyName string
+		if field.Prop.IsObjectLike() && field.Children != nil {
+			subTyName = field.TyName
+			subTypes = append(subTypes, fieldPathsType(subTyName, field.Children, fieldEsType)...)
+		} else if subFields := subFieldsOf(field.Prop); len(subFields) > 0 {
+			// Suffixed so that it does not collide with names of generated object types,
+			// e.g. DocNameKeyword for an object nameKeyword next to a field name_keyword.
+			subTyName = tyName + toPascalCaseDelimiter(field.Name) + "Sub"
+			subTypes = append(subTypes, fieldPathsType(subTyName, subFields, fieldEsType)...)
+		}
+
+		if subTyName != "" {
+			param.Fields = append(param.Fields, fieldPathParam{
+				FieldName:   toPascalCaseDelimiter(field.Name),
+				TyName:      subTyName + "FieldPaths",
+				Constructor: "New" + subTyName + "FieldPaths(" + pathExpr + ")",
+			})
+		} else {
+			param.Fields = append(param.Fields, fieldPathParam{
+				FieldName:   goFieldNameOfSubField(field.Name),
+				TyName:      "esquery.FieldPath",
+				Constructor: "esquery.NewFieldPath(" + pathExpr + `, "` + string(fieldEsType) + `")`,
+			})
+		}
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	err := fieldPathsTemplate.Execute(buf, param)
+	if err != nil {
+		panic(err)
+	}
+
+	return append([]GeneratedType{{
+		TyName:  tyName + "FieldPaths",
+		TyDef:   buf.String(),
+		Imports: esqueryImport,
+	}}, subTypes...)
+}
+
+// subFieldsOf returns sub-fields of a non object-like property, sorted by name.
+func subFieldsOf(prop mapping.Property) []GeneratedField {
+	var subFields []GeneratedField
+	for name, subProp := range prop.MultiFields() {
+		subFields = append(subFields, GeneratedField{Name: name, Prop: subProp})
+	}
+
+	if param, ok := prop.Param.(*mapping.SearchAsYouTypeParams); ok {
+		// see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/search-as-you-type.html
+		maxShingleSize := 3
+		if param.MaxShingleSize != nil {
+			maxShingleSize = *param.MaxShingleSize
+		}
+		for i := 2; i <= maxShingleSize; i++ {
+			subFields = append(subFields, GeneratedField{
+				Name: "_" + strconv.FormatInt(int64(i), 10) + "gram",
+				Prop: mapping.Property{Type: mapping.SearchAsYouType},
+			})
+		}
+		subFields = append(subFields, GeneratedField{
+			Name: "_index_prefix",
+			Prop: mapping.Property{Type: mapping.SearchAsYouType},
+		})
+	}
+
+	sort.Slice(subFields, func(i, j int) bool { return subFields[i].Name < subFields[j].Name })
+	return subFields
+}
+
+// goFieldNameOfSubField is toPascalCaseDelimiter,
+// but also converts implicit sub-fields of search_as_you_type into valid Go identifiers,
+// e.g. IndexPrefix for _index_prefix and GramN for _Ngram.
+func goFieldNameOfSubField(name string) string {
+	if name == "_index_prefix" {
+		return "IndexPrefix"
+	}
+	if size, ok := shingleSizeOf(name); ok {
+		return "Gram" + size
+	}
+	return toPascalCaseDelimiter(name)
+}
+
+// shingleSizeOf returns N of _Ngram, a shingle sub-field of search_as_you_type.
+func shingleSizeOf(name string) (string, bool) {
+	if !strings.HasPrefix(name, "_") || !strings.HasSuffix(name, "gram") {
+		return "", false
+	}
+	size := name[1 : len(name)-len("gram")]
+	if _, err := strconv.ParseUint(size, 10, 32); err != nil {
+		return "", false
+	}
+	return size, true
+}
+
+var fieldPathsTemplate = template.Must(template.New("fieldPathsTemplate").Parse(`
+// {{.TyName}}FieldPaths is a tree of field paths of {{.TyName}}.
+type {{.TyName}}FieldPaths struct {
+{{- if .EsType}}
+	esquery.FieldPath
+{{- end}}
+{{range .Fields}}	{{.FieldName}} {{.TyName}}
+{{end -}}
+}
+
+// New{{.TyName}}FieldPaths returns {{.TyName}}FieldPaths whose paths are prefixed with prefix.
+func New{{.TyName}}FieldPaths(prefix string) {{.TyName}}FieldPaths {
+	return {{.TyName}}FieldPaths{
+{{- if .EsType}}
+		FieldPath: esquery.NewFieldPath(prefix, "{{.EsType}}"),
+{{- end}}
+{{range .Fields}}		{{.FieldName}}: {{.Constructor}},
+{{end -}}
+	}
+}
+`))
+
+var fieldPathsVarTemplate = template.Must(template.New("fieldPathsVarTemplate").Parse(`
+// {{.}}Fields is the field path tree of {{.}}.
+var {{.}}Fields = New{{.}}FieldPaths("")
+`))
@@ -1,6 +1,8 @@
 package generate
 
 import (
+	"fmt"
+
 	"github.com/ngicks/elastic-type/mapping"
 )
 
@@ -9,6 +11,70 @@ type GeneratedType struct {
 	TyDef   string
 	Imports []string
 	Option  FieldOption
+	// Fields describes properties of the type.
+	// It is only populated for high level types generated from the mapping root, Object or Nested.
+	Fields []GeneratedField
+	// DynamicFields describes map fields of a dynamic object type typed by dynamic templates.
+	DynamicFields []DynamicField
+}
+
+// DynamicField is a map field of a dynamic object type, holding fields matched to a dynamic template.
+type DynamicField struct {
+	TemplateName string
+	// GoName is the name of the map field in generated types.
+	GoName string
+	// Prop is the mapping of the template.
+	Prop mapping.Property
+	// TyName is the name of the high level type of values.
+	TyName string
+}
+
+// GeneratedField describes a property of a generated object type.
+type GeneratedField struct {
+	// Name is the property name, also used as the json key.
+	Name string
+	Prop mapping.Property
+	// TyName is the name of the high level type of a field value.
+	// For Object or Nested, it is name of the sub type.
+	TyName  string
+	Imports []string
+	// Children is fields of the sub type.
+	// It is nil if the property is not object-like or is dynamically mapped.
+	Children []GeneratedField
+	// DynamicFields is map fields of the sub type if the property is a dynamic object typed by dynamic templates.
+	DynamicFields []DynamicField
+	// SourceExcluded is true if the field is excluded from _source by includes / excludes of the mapping.
+	// Then the field is not in raw and high level types, but its type is still generated.
+	SourceExcluded bool
+	// AliasPath is the dotted path to the target if the property is an alias, or empty otherwise.
+	// Aliases never appear in _source, so they are not in raw and high level types.
+	// TyName and Imports are those of the target.
+	AliasPath string
+	// AliasTarget is the property of the target if the property is an alias.
+	AliasTarget mapping.Property
+}
+
+// EsType returns type of the field. For aliases, it is type of the target.
+func (f GeneratedField) EsType() mapping.EsType {
+	if f.AliasPath != "" {
+		return f.AliasTarget.Type
+	}
+	return f.Prop.Type
+}
+
+// rootContext is state derived from the root of the input mapping.
+// It is shared by all objects generated from the mapping.
+type rootContext struct {
+	// dynamicTemplates is used to type fields of dynamic objects.
+	dynamicTemplates mapping.DynamicTemplates
+	// source is used to leave fields excluded from _source out of generated types.
+	source *mapping.SourceField
+	// routing, if set, makes the root type have RoutingRequired method.
+	routing *mapping.RoutingField
+	// properties is used to resolve targets of alias fields.
+	properties mapping.Properties
+	// detection is used to detect types of fields of dynamic objects, as dynamic templates do.
+	detection mapping.DynamicDetection
 }
 
 // Generate generates Go struct types from an Elasticsearch mapping.
@@ -40,5 +106,30 @@ func Generate(
 	if opts == nil {
 		opts = MapOption{}
 	}
-	return object(*mapping.Properties, globalOpt, opts, []string{tyName}, mapping.Dynamic)
+	templates, err := mapping.DynamicTemplates.Templates()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, t := range templates {
+		// Otherwise generated types silently never match the template.
+		if err := t.Template.ValidatePatterns(); err != nil {
+			return nil, nil, nil, fmt.Errorf("dynamic template %s: %w", t.Name, err)
+		}
+	}
+	detection, err := mapping.DynamicDetection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	root := rootContext{
+		dynamicTemplates: mapping.DynamicTemplates,
+		source:           mapping.Source,
+		routing:          mapping.Routing,
+		properties:       *mapping.Properties,
+		detection:        detection,
+	}
+	highLevelTy, rawTy, testDef, err = object(*mapping.Properties, globalOpt, root, opts, []string{tyName}, mapping.Dynamic)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return resolveAliases(highLevelTy, rawTy, testDef, globalOpt, tyName)
 }
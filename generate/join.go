@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+// JoinFromParam generates a type for join fields, which only accepts relations declared in param.
+// Constructors are generated for each relation, named New<TyName><Relation>, e.g. NewDocRelQuestion.
+func JoinFromParam(param mapping.JoinParams, tyName string) (GeneratedType, error) {
+	parents, err := joinParents(param.Relations)
+	if err != nil {
+		return GeneratedType{}, err
+	}
+
+	tmplParam := joinTemplateParam{TyName: capitalize(tyName)}
+	for _, name := range sortedMapKeys(parents) {
+		tmplParam.Relations = append(tmplParam.Relations, joinRelation{
+			Name:        name,
+			Parent:      parents[name],
+			Constructor: "New" + tmplParam.TyName + toPascalCaseDelimiter(name),
+		})
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	if err := joinTemplate.Execute(buf, tmplParam); err != nil {
+		panic(err)
+	}
+
+	return GeneratedType{
+		TyName:  tmplParam.TyName,
+		TyDef:   buf.String(),
+		Imports: append([]string{`"encoding/json"`}, estypeImport...),
+	}, nil
+}
+
+// joinParents returns relation names mapped to their parent names. Root relations are mapped to "".
+func joinParents(relations map[string]any) (map[string]string, error) {
+	parents := map[string]string{}
+	for _, parent := range sortedMapKeys(relations) {
+		children, err := mapping.JoinChildren(relations[parent])
+		if err != nil {
+			return nil, fmt.Errorf("relation of %q %w", parent, err)
+		}
+
+		if _, ok := parents[parent]; !ok {
+			parents[parent] = ""
+		}
+		for _, child := range children {
+			if p := parents[child]; p != "" && p != parent {
+				return nil, fmt.Errorf("relation %q has multiple parents, %q and %q", child, p, parent)
+			}
+			parents[child] = parent
+		}
+	}
+	return parents, nil
+}
+
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type joinTemplateParam struct {
+	TyName    string
+	Relations []joinRelation
+}
+
+type joinRelation struct {
+	Name        string
+	Parent      string
+	Constructor string
+}
+
+var joinTemplate = template.Must(template.New("joinTemplate").Parse(`
+// {{.TyName}} is a value of the join field. Only relations declared in the mapping are accepted.
+//
+// Child documents must be indexed with routing, the id of their parent in most cases,
+// so that they are in the same shard as their parents.
+type {{.TyName}} estype.Join
+
+var relations{{.TyName}} = estype.JoinRelations{
+{{- range .Relations}}
+	{{printf "%q" .Name}}: {{printf "%q" .Parent}},
+{{- end}}
+}
+{{range .Relations}}
+{{- if .Parent}}
+// {{.Constructor}} returns {{$.TyName}} of the {{printf "%q" .Name}} relation, a child of the {{printf "%q" .Parent}} document whose id is parentID.
+// The document must be indexed with routing, usually parentID.
+func {{.Constructor}}(parentID string) {{$.TyName}} {
+	return {{$.TyName}}{Name: {{printf "%q" .Name}}, Parent: parentID}
+}
+{{else}}
+// {{.Constructor}} returns {{$.TyName}} of the {{printf "%q" .Name}} relation, which has no parent.
+func {{.Constructor}}() {{$.TyName}} {
+	return {{$.TyName}}{Name: {{printf "%q" .Name}}}
+}
+{{end}}
+{{- end}}
+func (j {{.TyName}}) MarshalJSON() ([]byte, error) {
+	if err := relations{{.TyName}}.Check(estype.Join(j)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(estype.Join(j))
+}
+
+func (j *{{.TyName}}) UnmarshalJSON(data []byte) error {
+	var v estype.Join
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := relations{{.TyName}}.Check(v); err != nil {
+		return err
+	}
+	*j = {{.TyName}}(v)
+	return nil
+}
+`))
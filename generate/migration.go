@@ -0,0 +1,482 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+// MigrationOption is an option for GenerateMigration.
+type MigrationOption struct {
+	// OldImportPath is the import path of the package where old types are generated.
+	// Leave it empty if old types are in the same package as the converter.
+	OldImportPath string
+	// NewImportPath is the import path of the package where new types are generated.
+	// Leave it empty if new types are in the same package as the converter.
+	NewImportPath string
+}
+
+// MigrationPlan is a plan to move documents from an old index to a new one,
+// needed when changes between mappings are breaking.
+type MigrationPlan struct {
+	OldIndex string
+	NewIndex string
+	// Changes is the result of mapping.Diff.
+	Changes mapping.Changes
+	// IndexBody is the body of the create index API for the new index.
+	IndexBody mapping.IndexSettings
+	// ReindexBody is the body of the _reindex API, which copies documents from the old index to the new one.
+	ReindexBody map[string]any
+	// Converter is functions that convert old types into new ones, to be written by WriteTypes.
+	Converter []GeneratedType
+	// Unconvertible lists fields the converter leaves zero value. A human should fill them in.
+	Unconvertible []UnconvertibleField
+}
+
+// UnconvertibleField is a field which can not be carried over from the old type to the new one.
+type UnconvertibleField struct {
+	// Path is the dotted path to the field.
+	// For fields of a dynamic object typed by a dynamic template, it is followed by the template name,
+	// e.g. "attrs (dynamic template timestamps)".
+	Path   string
+	Reason string
+}
+
+func (f UnconvertibleField) String() string {
+	return f.Path + ": " + f.Reason
+}
+
+// String returns human-readable report of p.
+func (p MigrationPlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "migration from %s to %s\n\n", p.OldIndex, p.NewIndex)
+	b.WriteString(p.Changes.String())
+	if len(p.Unconvertible) == 0 {
+		b.WriteString("\nall fields are converted.\n")
+		return b.String()
+	}
+	b.WriteString("\nunconvertible fields, left zero by the converter:\n")
+	for _, f := range p.Unconvertible {
+		b.WriteString("  - " + f.String() + "\n")
+	}
+	return b.String()
+}
+
+// GenerateMigration generates a migration plan from oldMappings to newMappings.
+// oldHighLevelTy and newHighLevelTy must be ones returned from Generate for each mappings.
+//
+// Converter has Convert<OldTyName>Raw for every pair of object types which exists in both old and new,
+// and Convert<OldTyName> for the root, which converts high level types through ToRaw and ToPlain.
+// A field is carried over if the field of the same name exists in the new type and its value is convertible,
+// i.e. same Go type, widening numeric conversion, date to date, or object-like to object-like.
+// Others are listed in Unconvertible and are left zero.
+func GenerateMigration(
+	oldMappings, newMappings mapping.Mappings,
+	oldIndex, newIndex string,
+	oldHighLevelTy, newHighLevelTy []GeneratedType,
+	opt MigrationOption,
+) MigrationPlan {
+	plan := MigrationPlan{
+		OldIndex:  oldIndex,
+		NewIndex:  newIndex,
+		Changes:   mapping.Diff(oldMappings, newMappings),
+		IndexBody: mapping.IndexSettings{Mappings: &newMappings},
+		ReindexBody: map[string]any{
+			"source": map[string]any{"index": oldIndex},
+			"dest":   map[string]any{"index": newIndex},
+		},
+	}
+
+	if len(oldHighLevelTy) == 0 || len(newHighLevelTy) == 0 {
+		return plan
+	}
+
+	g := &converterGenerator{
+		oldPkg: pkgQualifier(opt.OldImportPath),
+		newPkg: pkgQualifier(opt.NewImportPath),
+	}
+	imports := append([]string{}, estypeImport...)
+	for _, importPath := range []string{opt.OldImportPath, opt.NewImportPath} {
+		if importPath != "" {
+			imports = append(imports, `"`+importPath+`"`)
+		}
+	}
+
+	oldRoot, newRoot := oldHighLevelTy[0], newHighLevelTy[0]
+	g.object("", oldRoot.TyName, newRoot.TyName, oldRoot.Fields, newRoot.Fields)
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	err := convertRootTemplate.Execute(buf, convertRootTemplateParam{
+		OldName: oldRoot.TyName,
+		Old:     g.oldPkg + oldRoot.TyName,
+		New:     g.newPkg + newRoot.TyName,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	plan.Converter = append(
+		[]GeneratedType{{TyName: "Convert" + oldRoot.TyName, TyDef: buf.String(), Imports: imports}},
+		g.converters...,
+	)
+	plan.Unconvertible = g.unconvertible
+	return plan
+}
+
+func pkgQualifier(importPath string) string {
+	if importPath == "" {
+		return ""
+	}
+	return path.Base(importPath) + "."
+}
+
+type converterGenerator struct {
+	oldPkg, newPkg string
+	converters     []GeneratedType
+	unconvertible  []UnconvertibleField
+}
+
+type convertFieldParam struct {
+	FieldName string
+	Expr      string
+}
+
+type convertTemplateParam struct {
+	OldName       string
+	OldRaw        string
+	NewRaw        string
+	Fields        []convertFieldParam
+	Unconvertible []string
+}
+
+type convertRootTemplateParam struct {
+	OldName string
+	Old     string
+	New     string
+}
+
+func (g *converterGenerator) object(objPath, oldTyName, newTyName string, oldFields, newFields []GeneratedField) {
+	param := convertTemplateParam{
+		OldName: oldTyName,
+		OldRaw:  g.oldPkg + oldTyName + "Raw",
+		NewRaw:  g.newPkg + newTyName + "Raw",
+	}
+
+	oldByName := map[string]GeneratedField{}
+	for _, f := range oldFields {
+		oldByName[f.Name] = f
+	}
+	newByName := map[string]GeneratedField{}
+	for _, f := range newFields {
+		newByName[f.Name] = f
+	}
+
+	// The converter is added before sub converters, same order as Generate.
+	idx := len(g.converters)
+	g.converters = append(g.converters, GeneratedType{})
+
+	for _, newField := range newFields {
+		if newField.SourceExcluded || newField.AliasPath != "" {
+			// not in the raw type.
+			continue
+		}
+		fieldPath := joinPath(objPath, newField.Name)
+		oldField, ok := oldByName[newField.Name]
+		if !ok || oldField.SourceExcluded || oldField.AliasPath != "" {
+			g.addUnconvertible(&param.Unconvertible, fieldPath, "no field in the old type")
+			continue
+		}
+
+		expr, reason := g.field(fieldPath, "in."+toPascalCaseDelimiter(oldField.Name), oldField, newField)
+		if reason != "" {
+			g.addUnconvertible(&param.Unconvertible, fieldPath, reason)
+			continue
+		}
+		param.Fields = append(param.Fields, convertFieldParam{
+			FieldName: toPascalCaseDelimiter(newField.Name),
+			Expr:      expr,
+		})
+	}
+
+	for _, oldField := range oldFields {
+		if oldField.AliasPath != "" {
+			continue
+		}
+		if newField, ok := newByName[oldField.Name]; !ok || newField.AliasPath != "" {
+			g.addUnconvertible(&param.Unconvertible, joinPath(objPath, oldField.Name), "no field in the new type, dropped")
+		}
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	err := convertTemplate.Execute(buf, param)
+	if err != nil {
+		panic(err)
+	}
+	g.converters[idx] = GeneratedType{
+		TyName: "Convert" + oldTyName + "Raw",
+		TyDef:  buf.String(),
+	}
+}
+
+// dynamicObject adds a converter between raw types of dynamic objects typed by dynamic templates.
+// Map fields are matched by template names. Fields matched to none of templates are carried over as is.
+func (g *converterGenerator) dynamicObject(objPath, oldTyName, newTyName string, oldFields, newFields []DynamicField) {
+	param := convertDynamicTemplateParam{
+		OldName: oldTyName,
+		OldRaw:  g.oldPkg + oldTyName + "Raw",
+		NewRaw:  g.newPkg + newTyName + "Raw",
+	}
+
+	oldByName := map[string]DynamicField{}
+	for _, f := range oldFields {
+		oldByName[f.TemplateName] = f
+	}
+	newByName := map[string]DynamicField{}
+	for _, f := range newFields {
+		newByName[f.TemplateName] = f
+	}
+
+	idx := len(g.converters)
+	g.converters = append(g.converters, GeneratedType{})
+
+	for _, newField := range newFields {
+		templatePath := objPath + " (dynamic template " + newField.TemplateName + ")"
+		oldField, ok := oldByName[newField.TemplateName]
+		if !ok {
+			g.addUnconvertible(&param.Unconvertible, templatePath, "no dynamic template in the old type")
+			continue
+		}
+		expr, reason := g.field(
+			templatePath,
+			"v",
+			GeneratedField{Name: oldField.TemplateName, Prop: oldField.Prop, TyName: oldField.TyName},
+			GeneratedField{Name: newField.TemplateName, Prop: newField.Prop, TyName: newField.TyName},
+		)
+		if reason != "" {
+			g.addUnconvertible(&param.Unconvertible, templatePath, reason)
+			continue
+		}
+		param.Fields = append(param.Fields, convertDynamicFieldParam{
+			OldGoName: oldField.GoName,
+			NewGoName: newField.GoName,
+			NewTyName: g.qualify(g.newPkg, newField.TyName),
+			Expr:      expr,
+		})
+	}
+	for _, oldField := range oldFields {
+		if _, ok := newByName[oldField.TemplateName]; !ok {
+			g.addUnconvertible(
+				&param.Unconvertible,
+				objPath+" (dynamic template "+oldField.TemplateName+")",
+				"no dynamic template in the new type, dropped",
+			)
+		}
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	err := convertDynamicTemplate.Execute(buf, param)
+	if err != nil {
+		panic(err)
+	}
+	g.converters[idx] = GeneratedType{
+		TyName: "Convert" + oldTyName + "Raw",
+		TyDef:  buf.String(),
+	}
+}
+
+// addUnconvertible records the field and appends it to unconvertible, listed in the doc comment of the converter.
+func (g *converterGenerator) addUnconvertible(unconvertible *[]string, fieldPath, reason string) {
+	f := UnconvertibleField{Path: fieldPath, Reason: reason}
+	g.unconvertible = append(g.unconvertible, f)
+	*unconvertible = append(*unconvertible, f.String())
+}
+
+// field returns an expression converting in, a field value of old raw type, into a field value of new raw type.
+// reason is non empty if it is not convertible.
+func (g *converterGenerator) field(fieldPath, in string, oldField, newField GeneratedField) (expr, reason string) {
+	oldTy, newTy := typeOf(oldField.Prop), typeOf(newField.Prop)
+
+	if oldField.Prop.IsObjectLike() != newField.Prop.IsObjectLike() {
+		return "", fmt.Sprintf("type changed from %s to %s", oldTy, newTy)
+	}
+
+	if oldField.Prop.IsObjectLike() {
+		switch {
+		case oldField.Children != nil && newField.Children != nil:
+			g.object(fieldPath, oldField.TyName, newField.TyName, oldField.Children, newField.Children)
+			return "estype.MapField(" + in + ", Convert" + oldField.TyName + "Raw)", ""
+		case oldField.Children == nil && newField.Children == nil:
+			if len(oldField.DynamicFields) == 0 && len(newField.DynamicFields) == 0 {
+				// Both are plain dynamic objects. Underlying types are identical.
+				return g.conversion(in, oldField.TyName+"Raw", newField.TyName+"Raw"), ""
+			}
+			if len(oldField.DynamicFields) == 0 || len(newField.DynamicFields) == 0 {
+				return "", "changed between dynamic objects with and without dynamic templates"
+			}
+			g.dynamicObject(fieldPath, oldField.TyName, newField.TyName, oldField.DynamicFields, newField.DynamicFields)
+			return "estype.MapField(" + in + ", Convert" + oldField.TyName + "Raw)", ""
+		default:
+			return "", "changed between dynamic and static object"
+		}
+	}
+
+	oldGoTy, newGoTy := oldField.TyName, newField.TyName
+	switch {
+	case !isLocalType(oldGoTy) && oldGoTy == newGoTy:
+		return in, ""
+	case isDate(oldTy) && isDate(newTy):
+		// Every date type has time.Time as its underlying type.
+		return g.conversion(in, oldGoTy, newGoTy), ""
+	case oldTy == mapping.Boolean && newTy == mapping.Boolean:
+		return g.conversion(in, oldGoTy, newGoTy), ""
+	case isWideningNumeric(oldGoTy, newGoTy):
+		return g.conversion(in, oldGoTy, newGoTy), ""
+	case oldTy == newTy && isLocalType(oldGoTy) && equalParam(oldField.Prop, newField.Prop):
+		return g.conversion(in, oldGoTy, newGoTy), ""
+	}
+	return "", fmt.Sprintf("not convertible from %s (%s) to %s (%s)", oldTy, oldGoTy, newTy, newGoTy)
+}
+
+func (g *converterGenerator) conversion(in, oldGoTy, newGoTy string) string {
+	oldGoTy, newGoTy = g.qualify(g.oldPkg, oldGoTy), g.qualify(g.newPkg, newGoTy)
+	return fmt.Sprintf("estype.MapField(%s, func(v %s) %s { return %s(v) })", in, oldGoTy, newGoTy, newGoTy)
+}
+
+func (g *converterGenerator) qualify(pkg, goTy string) string {
+	if isLocalType(goTy) {
+		return pkg + goTy
+	}
+	return goTy
+}
+
+// isLocalType reports whether goTy is a type generated in the same package as the type which has it as a field.
+func isLocalType(goTy string) bool {
+	return goTy != "" && goTy[0] >= 'A' && goTy[0] <= 'Z' && !strings.Contains(goTy, ".")
+}
+
+func typeOf(prop mapping.Property) mapping.EsType {
+	if prop.IsObject() {
+		return mapping.Object
+	}
+	return prop.Type
+}
+
+func isDate(ty mapping.EsType) bool {
+	return ty == mapping.Date || ty == mapping.DateNanoseconds
+}
+
+func equalParam(l, r mapping.Property) bool {
+	lBin, lErr := l.MarshalJSON()
+	rBin, rErr := r.MarshalJSON()
+	return lErr == nil && rErr == nil && bytes.Equal(lBin, rBin)
+}
+
+type numericKind struct {
+	kind byte
+	bits int
+}
+
+var numericKinds = map[string]numericKind{
+	"int8":    {'i', 8},
+	"int16":   {'i', 16},
+	"int32":   {'i', 32},
+	"int64":   {'i', 64},
+	"uint64":  {'u', 64},
+	"float32": {'f', 24}, // bits of mantissa
+	"float64": {'f', 53},
+}
+
+// isWideningNumeric reports whether every value of from can be represented as to.
+func isWideningNumeric(from, to string) bool {
+	f, ok := numericKinds[from]
+	if !ok {
+		return false
+	}
+	t, ok := numericKinds[to]
+	if !ok {
+		return false
+	}
+	switch {
+	case f.kind == t.kind:
+		return f.bits <= t.bits
+	case f.kind == 'i' && t.kind == 'f':
+		return f.bits < t.bits
+	}
+	return false
+}
+
+func joinPath(objPath, name string) string {
+	if objPath == "" {
+		return name
+	}
+	return objPath + "." + name
+}
+
+var convertTemplate = template.Must(template.New("convertTemplate").Parse(`
+// Convert{{.OldName}}Raw converts {{.OldRaw}} into {{.NewRaw}}.
+{{- if .Unconvertible}}
+//
+// Fields below are not converted:
+{{- range .Unconvertible}}
+//   - {{.}}
+{{- end}}
+{{- end}}
+func Convert{{.OldName}}Raw(in {{.OldRaw}}) {{.NewRaw}} {
+	return {{.NewRaw}}{
+{{- range .Fields}}
+		{{.FieldName}}: {{.Expr}},
+{{- end}}
+	}
+}
+`))
+
+type convertDynamicFieldParam struct {
+	OldGoName string
+	NewGoName string
+	NewTyName string
+	// Expr converts v, a value of the old map, into a value of the new map.
+	Expr string
+}
+
+type convertDynamicTemplateParam struct {
+	OldName       string
+	OldRaw        string
+	NewRaw        string
+	Fields        []convertDynamicFieldParam
+	Unconvertible []string
+}
+
+var convertDynamicTemplate = template.Must(template.New("convertDynamicTemplate").Parse(`
+// Convert{{.OldName}}Raw converts {{.OldRaw}} into {{.NewRaw}}.
+// Fields matched to none of dynamic templates are carried over as is.
+{{- if .Unconvertible}}
+//
+// Fields below are not converted:
+{{- range .Unconvertible}}
+//   - {{.}}
+{{- end}}
+{{- end}}
+func Convert{{.OldName}}Raw(in {{.OldRaw}}) {{.NewRaw}} {
+	out := {{.NewRaw}}{Other: in.Other}
+{{- range .Fields}}
+	if in.{{.OldGoName}} != nil {
+		out.{{.NewGoName}} = make(map[string]estype.Field[{{.NewTyName}}], len(in.{{.OldGoName}}))
+		for k, v := range in.{{.OldGoName}} {
+			out.{{.NewGoName}}[k] = {{.Expr}}
+		}
+	}
+{{- end}}
+	return out
+}
+`))
+
+var convertRootTemplate = template.Must(template.New("convertRootTemplate").Parse(`
+// Convert{{.OldName}} converts {{.Old}} into {{.New}} through their raw types.
+func Convert{{.OldName}}(in {{.Old}}) {{.New}} {
+	return Convert{{.OldName}}Raw(in.ToRaw()).ToPlain()
+}
+`))
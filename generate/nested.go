@@ -12,6 +12,17 @@ func Nested(
 	opts MapOption,
 	fieldNames []string,
 	dynamicContext mapping.Dynamic,
+) (highLevelTy, rawTy, testDef []GeneratedType, err error) {
+	return nestedWithRoot(p, globalOpt, rootContext{}, opts, fieldNames, dynamicContext)
+}
+
+func nestedWithRoot(
+	p mapping.NestedParams,
+	globalOpt GlobalOption,
+	root rootContext,
+	opts MapOption,
+	fieldNames []string,
+	dynamicContext mapping.Dynamic,
 ) (highLevelTy, rawTy, testDef []GeneratedType, err error) {
 	newDynamic := mapping.OverlayDynamic(dynamicContext, p.Dynamic)
 
@@ -33,5 +44,5 @@ func Nested(
 			[]GeneratedType{},
 			nil
 	}
-	return object(*p.Properties, globalOpt, opts, fieldNames, newDynamic)
+	return object(*p.Properties, globalOpt, root, opts, fieldNames, newDynamic)
 }
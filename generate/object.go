@@ -39,6 +39,7 @@ func fieldOptToConcrete(f FieldOption) concreteFieldOption {
 func object(
 	props mapping.Properties,
 	globalOpt GlobalOption,
+	root rootContext,
 	opts MapOption,
 	fieldNames []string,
 	dynamicContext mapping.Dynamic,
@@ -46,6 +47,7 @@ func object(
 	var subHighLevelTypes, subRawTypes, subTestDefs []GeneratedType
 	highLevelFields := map[string]tyNameWithOption{}
 	rawFields := map[string]tyNameWithOption{}
+	var fields []GeneratedField
 
 	tyName := globalOpt.TypeNameGenerator.Gen(fieldNames)
 
@@ -55,27 +57,62 @@ func object(
 		return keys
 	})
 
+	objPath := strings.Join(fieldNames[1:], ".")
+	target, err := globalOpt.targetVersion()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	for _, tuple := range iter.Collect() {
 		name, param := tuple.Former, tuple.Latter
 		fieldOption := opts[name]
 		overlaidOption := globalOpt.Overlay(param, fieldOption)
+		// Types are still generated for excluded fields, since they can be searched.
+		sourceExcluded := isSourceExcluded(root.source, joinPath(objPath, name), param.IsObjectLike())
+
+		if param.Type == mapping.Alias {
+			// Aliases are only for searching. They never appear in _source.
+			field, err := aliasField(name, param, root, append(fieldNames, name))
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			fields = append(fields, field)
+			continue
+		}
 
-		if param.IsObjectLike() {
+		// Object-like types unavailable in the target version are reported by Field.
+		if param.IsObjectLike() && param.Type.AvailableIn(target) {
 			var subHighLevelTy, subRawTy, subTestDef []GeneratedType
 			var err error
 
 			if param.IsObject() {
-				subHighLevelTy, subRawTy, subTestDef, err = Object(
+				subHighLevelTy, subRawTy, subTestDef, err = objectWithRoot(
 					*param.Param.(*mapping.ObjectParams),
 					globalOpt,
+					root,
+					fieldOption.ChildOption,
+					append(fieldNames, name),
+					dynamicContext,
+				)
+			} else if param.Type == mapping.Passthrough {
+				// In _source, passthrough is same as object.
+				passthrough := param.Param.(*mapping.PassthroughParams)
+				subHighLevelTy, subRawTy, subTestDef, err = objectWithRoot(
+					mapping.ObjectParams{
+						Dynamic:    passthrough.Dynamic,
+						Properties: passthrough.Properties,
+					},
+					globalOpt,
+					root,
 					fieldOption.ChildOption,
 					append(fieldNames, name),
 					dynamicContext,
 				)
 			} else {
-				subHighLevelTy, subRawTy, subTestDef, err = Nested(
+				subHighLevelTy, subRawTy, subTestDef, err = nestedWithRoot(
 					*param.Param.(*mapping.NestedParams),
 					globalOpt,
+					root,
 					fieldOption.ChildOption,
 					append(fieldNames, name),
 					dynamicContext,
@@ -92,16 +129,26 @@ func object(
 			subHighLevelTy[0].Option = overlaidOption
 			subRawTy[0].Option = overlaidOption
 
-			highLevelFields[name] = tyNameWithOption{
-				TyName:   subHighLevelTy[0].TyName,
-				Option:   fieldOptToConcrete(overlaidOption),
-				HasChild: true,
-			}
-			rawFields[name] = tyNameWithOption{
-				TyName:   subRawTy[0].TyName,
-				Option:   fieldOptToConcrete(overlaidOption),
-				HasChild: true,
+			if !sourceExcluded {
+				highLevelFields[name] = tyNameWithOption{
+					TyName:   subHighLevelTy[0].TyName,
+					Option:   fieldOptToConcrete(overlaidOption),
+					HasChild: true,
+				}
+				rawFields[name] = tyNameWithOption{
+					TyName:   subRawTy[0].TyName,
+					Option:   fieldOptToConcrete(overlaidOption),
+					HasChild: true,
+				}
 			}
+			fields = append(fields, GeneratedField{
+				Name:           name,
+				Prop:           param,
+				TyName:         subHighLevelTy[0].TyName,
+				Children:       subHighLevelTy[0].Fields,
+				DynamicFields:  subHighLevelTy[0].DynamicFields,
+				SourceExcluded: sourceExcluded,
+			})
 
 		} else {
 			gen, testDef, err := Field(param, append(fieldNames, name), globalOpt, fieldOption)
@@ -111,14 +158,23 @@ func object(
 				return nil, nil, nil, err
 			}
 
-			highLevelFields[name] = tyNameWithOption{
-				TyName: gen.TyName,
-				Option: fieldOptToConcrete(overlaidOption),
-			}
-			rawFields[name] = tyNameWithOption{
-				TyName: gen.TyName,
-				Option: fieldOptToConcrete(overlaidOption),
+			if !sourceExcluded {
+				highLevelFields[name] = tyNameWithOption{
+					TyName: gen.TyName,
+					Option: fieldOptToConcrete(overlaidOption),
+				}
+				rawFields[name] = tyNameWithOption{
+					TyName: gen.TyName,
+					Option: fieldOptToConcrete(overlaidOption),
+				}
 			}
+			fields = append(fields, GeneratedField{
+				Name:           name,
+				Prop:           param,
+				TyName:         gen.TyName,
+				Imports:        gen.Imports,
+				SourceExcluded: sourceExcluded,
+			})
 
 			subHighLevelTypes = append(subHighLevelTypes, gen)
 			subRawTypes = append(subRawTypes, GeneratedType{Imports: gen.Imports})
@@ -132,6 +188,18 @@ func object(
 		HighLevelFields: highLevelFields,
 		RawFields:       rawFields,
 	}
+	for name, field := range rawFields {
+		// Documents may have dotted keys for sub fields of object-like fields, e.g. {"user.name": "x"}.
+		// Field names contain dots in objects with subobjects: false.
+		if field.HasChild || strings.Contains(name, ".") {
+			param.NormalizeKeys = true
+		}
+	}
+	if len(fieldNames) == 1 && root.routing != nil {
+		// only for the root.
+		param.HasRouting = true
+		param.RoutingRequired = root.routing.Required != nil && *root.routing.Required
+	}
 	err = objectRawTemplate.Execute(buf, param)
 	if err != nil {
 		panic(err)
@@ -142,6 +210,9 @@ func object(
 		TyDef:   buf.String(),
 		Imports: estypeImport,
 	}
+	if param.NormalizeKeys {
+		thisTypeRaw.Imports = append([]string{`"encoding/json"`}, estypeImport...)
+	}
 
 	buf.Reset()
 	err = objectTemplate.Execute(buf, param)
@@ -153,6 +224,7 @@ func object(
 		TyName:  tyName,
 		TyDef:   buf.String(),
 		Imports: estypeImport,
+		Fields:  fields,
 	}
 
 	return append([]GeneratedType{thisType}, subHighLevelTypes...),
@@ -167,6 +239,17 @@ func Object(
 	opts MapOption,
 	fieldNames []string,
 	dynamicContext mapping.Dynamic,
+) (highLevelTy, rawTy, testDef []GeneratedType, err error) {
+	return objectWithRoot(p, globalOpt, rootContext{}, opts, fieldNames, dynamicContext)
+}
+
+func objectWithRoot(
+	p mapping.ObjectParams,
+	globalOpt GlobalOption,
+	root rootContext,
+	opts MapOption,
+	fieldNames []string,
+	dynamicContext mapping.Dynamic,
 ) (highLevelTy, rawTy, testDef []GeneratedType, err error) {
 	newDynamic := mapping.OverlayDynamic(dynamicContext, p.Dynamic)
 
@@ -176,6 +259,11 @@ func Object(
 		// TODO: research what will happen then.
 		tyName := capitalize(globalOpt.TypeNameGenerator.Gen(fieldNames))
 
+		highLevelTy, rawTy, testDef, err := dynamicTemplateObject(tyName, globalOpt, root, fieldNames)
+		if err != nil || highLevelTy != nil {
+			return highLevelTy, rawTy, testDef, err
+		}
+
 		params := struct {
 			TyName    string
 			RawTyName string
@@ -186,7 +274,6 @@ func Object(
 
 		highDef := bytes.NewBuffer(make([]byte, 0))
 		rawDef := bytes.NewBuffer(make([]byte, 0))
-		var err error
 		err = objectHighMapTemplate.Execute(highDef, params)
 		if err != nil {
 			panic(err)
@@ -211,7 +298,11 @@ func Object(
 			[]GeneratedType{},
 			nil
 	}
-	return object(*p.Properties, globalOpt, opts, fieldNames, newDynamic)
+	var props mapping.Properties
+	if p.Properties != nil {
+		props = *p.Properties
+	}
+	return object(props, globalOpt, root, opts, fieldNames, newDynamic)
 }
 
 var objectHighMapTemplate = template.Must(template.New("objectHighMapTemplate").Parse(`
@@ -236,7 +327,7 @@ func (t {{.RawTyName}}) ToPlain() {{.TyName}} {
 	return out
 }`))
 
-var caseDelimiter = regexp.MustCompile("[_-]")
+var caseDelimiter = regexp.MustCompile("[_@.-]")
 
 func capitalize(v string) string {
 	if length := len(v); length == 0 {
@@ -267,6 +358,11 @@ type objectTemplateParam struct {
 	TyName          string
 	HighLevelFields map[string]tyNameWithOption
 	RawFields       map[string]tyNameWithOption
+	// HasRouting is true if _routing is defined in the mapping.
+	HasRouting      bool
+	RoutingRequired bool
+	// NormalizeKeys is true if the raw type normalizes keys of input documents by estype.NormalizeKeys.
+	NormalizeKeys bool
 }
 
 var funcMap = template.FuncMap{
@@ -287,6 +383,25 @@ type {{.TyName}}Raw struct {
 func (r {{.TyName}}Raw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
+{{- if .NormalizeKeys}}
+
+var keys{{.TyName}}Raw = estype.ObjectKeys{
+{{- range $propName, $typeNameOpt := .RawFields}}
+	"{{$propName}}": {{$typeNameOpt.HasChild}},
+{{- end}}
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *{{.TyName}}Raw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keys{{.TyName}}Raw)
+	if err != nil {
+		return err
+	}
+	type plain {{.TyName}}Raw
+	return json.Unmarshal(data, (*plain)(r))
+}
+{{- end}}
 
 func (t {{.TyName}}Raw) ToPlain() {{.TyName}} {
 	return {{.TyName}}{
@@ -313,6 +428,14 @@ func (t {{.TyName}}Raw) ToPlain() {{.TyName}} {
 {{end}}
 	}
 }
+{{- if .HasRouting}}
+
+// RoutingRequired reports whether _routing.required is true in the mapping.
+// If true, index, get, update and delete requests for documents of this type must specify routing.
+func (t {{.TyName}}Raw) RoutingRequired() bool {
+	return {{.RoutingRequired}}
+}
+{{- end}}
 `))
 
 var objectTemplate = template.Must(template.New("objectTemplate").Funcs(funcMap).Parse(`
@@ -347,4 +470,12 @@ func (t {{.TyName}}) ToRaw() {{.TyName}}Raw {
 {{end}}		
 	}
 }
+{{- if .HasRouting}}
+
+// RoutingRequired reports whether _routing.required is true in the mapping.
+// If true, index, get, update and delete requests for documents of this type must specify routing.
+func (t {{.TyName}}) RoutingRequired() bool {
+	return {{.RoutingRequired}}
+}
+{{- end}}
 `))
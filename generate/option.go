@@ -177,6 +177,21 @@ type GlobalOption struct {
 	PreferTimeEpochMarshalling optStr            // prefer Date types to marshal into epoch millis or epoch second.
 	TypeOption                 TypeOption        // Default options for the type.
 	TypeNameGenerator          TypeNameGenerator // Defaults to FieldName().
+	// UnknownTypeAsRaw makes fields of unknown types, which are neither built-in nor registered by RegisterType,
+	// generated as json.RawMessage. Otherwise Generate returns ErrUnknownType for them.
+	UnknownTypeAsRaw optStr
+	// TargetVersion is the Elasticsearch version generated code targets, e.g. "8.15".
+	// Types added after the version are treated as unknown types.
+	// Defaults to mapping.DefaultStackVersion.
+	TargetVersion string
+}
+
+func (g GlobalOption) targetVersion() (mapping.StackVersion, error) {
+	v, err := mapping.ParseStackVersion(g.TargetVersion)
+	if err != nil {
+		return mapping.StackVersion{}, fmt.Errorf("TargetVersion: %w", err)
+	}
+	return v, nil
 }
 
 // Overlay overlays options.
@@ -274,6 +289,17 @@ var defaultTypeOption = TypeOption{
 	mapping.ConstantKeyword: OptionForType{},
 	mapping.Wildcard:        OptionForType{},
 	mapping.Text:            OptionForType{},
+	mapping.MatchOnlyText:   OptionForType{},
+	mapping.AnnotatedText:   OptionForType{},
+	mapping.CountedKeyword:  OptionForType{},
+	mapping.SemanticText:    OptionForType{},
+	mapping.SparseVector: OptionForType{
+		IsSingle: True,
+	},
+	mapping.Passthrough: OptionForType{
+		IsRequired: True,
+		IsSingle:   False,
+	},
 	mapping.Long: OptionForType{
 		IsSingle: True,
 	},
@@ -0,0 +1,137 @@
+package generate
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+var esqueryImport = []string{`esquery "github.com/ngicks/elastic-type/es_query"`}
+
+// GenerateQuery generates typed query helpers for types generated by Generate.
+// highLevelTy must be the one returned from Generate.
+//
+// For the root type and every Object or Nested type with statically mapped properties,
+// it generates <TyName>Query, a struct consists of per-field helpers of es_query package,
+// and New<TyName>Query which takes a path prefix (an empty string for the root).
+// Each helper is instantiated with the high level type of its field,
+// e.g. Range of a date field only accepts the generated date type.
+func GenerateQuery(highLevelTy []GeneratedType) []GeneratedType {
+	if len(highLevelTy) == 0 {
+		return nil
+	}
+	root := highLevelTy[0]
+	return queryType(root.TyName, root.Fields, "")
+}
+
+type queryFieldParam struct {
+	FieldName   string
+	HelperTy    string
+	Constructor string
+}
+
+type queryTemplateParam struct {
+	TyName   string
+	Embedded string
+	Fields   []queryFieldParam
+}
+
+func queryType(tyName string, fields []GeneratedField, embedded string) []GeneratedType {
+	var subTypes []GeneratedType
+	imports := append([]string{}, esqueryImport...)
+
+	param := queryTemplateParam{
+		TyName:   tyName,
+		Embedded: embedded,
+	}
+
+	for _, field := range fields {
+		pathExpr := `esquery.JoinPath(prefix, "` + field.Name + `")`
+		var helperTy, constructor string
+
+		switch {
+		case field.Prop.IsObjectLike() && field.Children != nil:
+			embedded := "ObjectField"
+			if field.Prop.Type == mapping.Nested {
+				embedded = "NestedField"
+			}
+			subTypes = append(subTypes, queryType(field.TyName, field.Children, embedded)...)
+			helperTy = field.TyName + "Query"
+			constructor = "New" + helperTy + "(" + pathExpr + ")"
+		case field.Prop.IsObject():
+			helperTy = "esquery.ObjectField"
+			constructor = "esquery.NewObjectField(" + pathExpr + ")"
+		case field.Prop.Type == mapping.Nested:
+			helperTy = "esquery.NestedField"
+			constructor = "esquery.NewNestedField(" + pathExpr + ")"
+		default:
+			helper, typed := queryHelperName(field.EsType())
+			if typed {
+				helper += "[" + field.TyName + "]"
+				imports = append(imports, field.Imports...)
+			}
+			helperTy = "esquery." + helper
+			constructor = "esquery.New" + helper + "(" + pathExpr + ")"
+		}
+
+		param.Fields = append(param.Fields, queryFieldParam{
+			FieldName:   toPascalCaseDelimiter(field.Name),
+			HelperTy:    helperTy,
+			Constructor: constructor,
+		})
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	err := queryTemplate.Execute(buf, param)
+	if err != nil {
+		panic(err)
+	}
+
+	return append([]GeneratedType{{
+		TyName:  tyName + "Query",
+		TyDef:   buf.String(),
+		Imports: imports,
+	}}, subTypes...)
+}
+
+// queryHelperName returns name of a helper type in es_query package for esType.
+// typed is true if the helper takes a type parameter of the field value.
+func queryHelperName(esType mapping.EsType) (helper string, typed bool) {
+	switch esType {
+	case mapping.Text, mapping.SearchAsYouType, mapping.MatchOnlyText, mapping.AnnotatedText:
+		return "TextField", true
+	case mapping.Keyword, mapping.ConstantKeyword, mapping.CountedKeyword, mapping.Wildcard, mapping.Version:
+		return "KeywordField", true
+	case mapping.Date, mapping.DateNanoseconds, mapping.IP, mapping.TokenCount,
+		mapping.Long, mapping.Integer, mapping.Short, mapping.Byte,
+		mapping.Double, mapping.Float, mapping.HalfFloat, mapping.ScaledFloat, mapping.UnsignedLong:
+		return "RangeField", true
+	case mapping.Boolean:
+		return "TermField", true
+	}
+	return "ExistsField", false
+}
+
+var queryTemplate = template.Must(template.New("queryTemplate").Parse(`
+// {{.TyName}}Query is a set of typed query helpers for fields of {{.TyName}}.
+type {{.TyName}}Query struct {
+{{- if .Embedded}}
+	esquery.{{.Embedded}}
+{{- end}}
+{{range .Fields}}	{{.FieldName}} {{.HelperTy}}
+{{end -}}
+}
+
+// New{{.TyName}}Query returns {{.TyName}}Query whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func New{{.TyName}}Query(prefix string) {{.TyName}}Query {
+	return {{.TyName}}Query{
+{{- if .Embedded}}
+		{{.Embedded}}: esquery.New{{.Embedded}}(prefix),
+{{- end}}
+{{range .Fields}}		{{.FieldName}}: {{.Constructor}},
+{{end -}}
+	}
+}
+`))
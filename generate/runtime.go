@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+type runtimeFieldParam struct {
+	Name   string
+	GoName string
+	TyName string
+}
+
+type runtimeTemplateParam struct {
+	TyName string
+	Fields []runtimeFieldParam
+}
+
+// GenerateRuntime generates a companion type for runtime fields defined in the runtime section of mappings.
+// highLevelTy must be the one returned from Generate for the same mappings.
+//
+// Runtime field values are not in _source, they only come back through the fields option of the search API.
+// The generated type, <TyName>Runtime, is to be unmarshalled from "fields" of each search hit,
+// where every value is an array.
+// Sub-fields of composite are expanded into dotted names, as the fields response does.
+// Date fields get generated date types honoring their format.
+//
+// It returns nil if mappings has no runtime fields.
+func GenerateRuntime(
+	mappings mapping.Mappings,
+	highLevelTy []GeneratedType,
+	globalOpt GlobalOption,
+) (runtimeTy, testDef []GeneratedType, err error) {
+	if len(mappings.Runtime) == 0 || len(highLevelTy) == 0 {
+		return nil, nil, nil
+	}
+
+	tyName := highLevelTy[0].TyName + "Runtime"
+	param := runtimeTemplateParam{TyName: tyName}
+	var subTypes []GeneratedType
+	imports := []string{}
+
+	fields := map[string]mapping.RuntimeField{}
+	for name, field := range mappings.Runtime {
+		if field.Type == mapping.RuntimeComposite {
+			for subName, subField := range field.Fields {
+				fields[name+"."+subName] = subField
+			}
+			continue
+		}
+		fields[name] = field
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		goName := toPascalCaseDelimiter(strings.ReplaceAll(name, ".", "_"))
+		gen, err := runtimeFieldType(fields[name], tyName+goName, globalOpt)
+		if err != nil {
+			return nil, nil, err
+		}
+		if gen.TyDef != "" {
+			subTypes = append(subTypes, gen)
+			testDef = append(testDef, DateTest(gen.TyName, ""))
+		}
+		imports = append(imports, gen.Imports...)
+		param.Fields = append(param.Fields, runtimeFieldParam{
+			Name:   name,
+			GoName: goName,
+			TyName: gen.TyName,
+		})
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	err = runtimeTemplate.Execute(buf, param)
+	if err != nil {
+		panic(err)
+	}
+
+	return append([]GeneratedType{{TyName: tyName, TyDef: buf.String(), Imports: imports}}, subTypes...),
+		testDef,
+		nil
+}
+
+func runtimeFieldType(field mapping.RuntimeField, tyName string, globalOpt GlobalOption) (GeneratedType, error) {
+	if field.Type == mapping.RuntimeLookup {
+		// fetch_fields of documents in the target index.
+		return GeneratedType{TyName: anyMap}, nil
+	}
+
+	esType, ok := field.Type.EsType()
+	if !ok {
+		return GeneratedType{TyName: "any"}, nil
+	}
+
+	if esType == mapping.Date {
+		prop := mapping.Property{Type: mapping.Date, Param: &mapping.DateParams{Type: mapping.Date, Format: field.Format}}
+		opt := globalOpt.Overlay(prop, FieldOption{})
+		return DateFromParam(
+			*prop.Param.(*mapping.DateParams),
+			tyName,
+			opt.PreferredTimeMarshallingFormat,
+			opt.PreferTimeEpochMarshalling.True(),
+		)
+	}
+
+	gen, _, err := Field(mapping.Property{Type: esType}, nil, globalOpt, FieldOption{})
+	return gen, err
+}
+
+var runtimeTemplate = template.Must(template.New("runtimeTemplate").Parse(`
+// {{.TyName}} is runtime fields, to be unmarshalled from "fields" of a search hit.
+// Request them by the fields option of the search API, e.g. "fields": ["*"].
+type {{.TyName}} struct {
+{{- range .Fields}}
+	{{.GoName}} []{{.TyName}} ` + "`" + `json:"{{.Name}},omitempty"` + "`" + `
+{{- end}}
+}
+`))
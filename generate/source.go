@@ -0,0 +1,68 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/ngicks/elastic-type/internal/wildcard"
+	"github.com/ngicks/elastic-type/mapping"
+)
+
+// isSourceExcluded reports whether the field at path is left out of stored _source
+// by enabled, mode, includes and excludes of source.
+//
+// An object is kept if an include pattern could match its descendants.
+func isSourceExcluded(source *mapping.SourceField, path string, objectLike bool) bool {
+	if source == nil {
+		return false
+	}
+	if source.Enabled != nil && !*source.Enabled {
+		return true
+	}
+	if source.Mode != nil && *source.Mode == mapping.SourceDisabled {
+		return true
+	}
+
+	selfAndAncestors := []string{path}
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '.' {
+			selfAndAncestors = append(selfAndAncestors, path[:i])
+		}
+	}
+
+	for _, p := range selfAndAncestors {
+		for _, exclude := range source.Excludes {
+			if wildcard.SimpleMatch(exclude, p) {
+				return true
+			}
+		}
+	}
+
+	if len(source.Includes) == 0 {
+		return false
+	}
+	for _, p := range selfAndAncestors {
+		for _, include := range source.Includes {
+			if wildcard.SimpleMatch(include, p) {
+				return false
+			}
+		}
+	}
+	if objectLike {
+		for _, include := range source.Includes {
+			if couldMatchDescendant(include, path) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func couldMatchDescendant(pattern, path string) bool {
+	prefix := path + "."
+	idx := strings.Index(pattern, "*")
+	if idx < 0 {
+		return strings.HasPrefix(pattern, prefix)
+	}
+	literal := pattern[:idx]
+	return strings.HasPrefix(prefix, literal) || strings.HasPrefix(literal, prefix)
+}
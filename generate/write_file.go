@@ -68,6 +68,33 @@ func WriteFile(
 	highLevelTy, rawTy, testDef []GeneratedType,
 	packageName string,
 ) error {
+	formatter := getFormatter()
+
+	for _, spec := range []struct {
+		outPath string
+		ty      []GeneratedType
+	}{
+		{highLevelTyPath, highLevelTy},
+		{rawTyePath, rawTy},
+		{testDefPath, testDef},
+	} {
+		err := writeTypes(spec.outPath, spec.ty, packageName, formatter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteTypes writes ty into a file at outPath.
+// It is a write-file helper for generated types other than ones WriteFile takes,
+// e.g. query helpers generated by GenerateQuery.
+func WriteTypes(outPath string, ty []GeneratedType, packageName string) error {
+	return writeTypes(outPath, ty, packageName, getFormatter())
+}
+
+func getFormatter() applyFormat {
 	initializeFormatterCommands()
 
 	if len(formatCommands) == 0 {
@@ -77,48 +104,32 @@ func WriteFile(
 			"%v",
 			possibleFormatters,
 		)
-	}
-
-	var formatter func(srcPath string) error
-	if len(formatCommands) > 0 {
-		formatter = formatCommands[0]
-	} else {
-		formatter = func(srcPath string) error {
+		return func(srcPath string) error {
 			return nil
 		}
 	}
 
-	for _, spec := range []struct {
-		outPath string
-		ty      []GeneratedType
-	}{
-		{highLevelTyPath, highLevelTy},
-		{rawTyePath, rawTy},
-		{testDefPath, testDef},
-	} {
-		if spec.outPath == "" {
-			continue
-		}
+	return formatCommands[0]
+}
 
-		imports := extractImports(spec.ty)
-		def := extractDef(spec.ty)
+func writeTypes(outPath string, ty []GeneratedType, packageName string, formatter applyFormat) error {
+	if outPath == "" {
+		return nil
+	}
 
-		if def == "" {
-			continue
-		}
+	imports := extractImports(ty)
+	def := extractDef(ty)
 
-		var err error
-		err = writeFile(spec.outPath, imports, def, packageName)
-		if err != nil {
-			return err
-		}
-		err = formatter(spec.outPath)
-		if err != nil {
-			return err
-		}
+	if def == "" {
+		return nil
 	}
 
-	return nil
+	var err error
+	err = writeFile(outPath, imports, def, packageName)
+	if err != nil {
+		return err
+	}
+	return formatter(outPath)
 }
 
 func extractImports(ty []GeneratedType) string {
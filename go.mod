@@ -11,6 +11,7 @@ require (
 	github.com/ngicks/gommon/pkg/randstr v0.0.0-20221106082638-0fa7a3f83454
 	github.com/ngicks/type-param-common v0.0.17
 	github.com/stretchr/testify v1.8.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -20,5 +21,4 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/prataprc/goparsec v0.0.0-20211219142520-daac0e635e7e // indirect
 	golang.org/x/exp v0.0.0-20220613132600-b0d781184e0d // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,25 @@
+// Package wildcard implements wildcard patterns of Elasticsearch.
+package wildcard
+
+import "strings"
+
+// SimpleMatch reports whether s matches pattern, in which only * is a wildcard matching any sequence of characters,
+// as Elasticsearch's Regex.simpleMatch does for e.g. match, path_match and index_patterns.
+func SimpleMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
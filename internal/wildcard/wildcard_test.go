@@ -0,0 +1,32 @@
+package wildcard_test
+
+import (
+	"testing"
+
+	"github.com/ngicks/elastic-type/internal/wildcard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimpleMatch(t *testing.T) {
+	for _, testCase := range []struct {
+		pattern, s string
+		expected   bool
+	}{
+		{"logs", "logs", true},
+		{"logs", "logs-1", false},
+		{"logs-*", "logs-1", true},
+		{"*-1", "logs-1", true},
+		{"*", "", true},
+		{"l*s-*-1", "logs-app-1", true},
+		{"l*s-*-1", "logs-app-2", false},
+		{"a*a", "a", false},
+		{"a.*.c", "a.b.c", true},
+	} {
+		require.Equal(
+			t,
+			testCase.expected,
+			wildcard.SimpleMatch(testCase.pattern, testCase.s),
+			"pattern = %s, s = %s", testCase.pattern, testCase.s,
+		)
+	}
+}
@@ -0,0 +1,415 @@
+package mapping
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Builder builds mappings programmatically.
+//
+//	m, err := mapping.New(mapping.DynamicMode(mapping.Strict)).
+//		Keyword("name", mapping.IgnoreAbove(256)).
+//		Object("addr", func(o *mapping.Builder) {
+//			o.Text("street").Keyword("zip")
+//		}).
+//		Build()
+//
+// Type of each property is filled, as FillType does.
+// Problems are checked as soon as properties are added, in the same way as Validate,
+// and returned from Build all together as ValidationErrors.
+type Builder struct {
+	path  string
+	root  ObjectParams
+	props Properties
+	// v is shared in a tree of builders.
+	v *validator
+	// multiField is true for builders of multi-fields.
+	// Their properties are validated by the parent as multi-fields of its property.
+	multiField bool
+}
+
+// New returns a new Builder. opts are applied to the mapping root, e.g. DynamicMode(Strict).
+func New(opts ...Option) *Builder {
+	b := &Builder{props: Properties{}, v: &validator{}}
+	b.apply("", &b.root, opts)
+	return b
+}
+
+// Option sets a param of a property.
+// param is a pointer to a param struct, e.g. *KeywordParams.
+// It returns an error if the param is not applicable to the type.
+type Option func(param any) error
+
+// Field adds a property of ty with name.
+// ty must be a built-in type or a type registered by RegisterType.
+func (b *Builder) Field(name string, ty EsType, opts ...Option) *Builder {
+	param, ok := newBuiltinParam(ty)
+	if !ok {
+		param, ok = newRegisteredParam(ty)
+	}
+	path := joinPath(b.path, name)
+	if !ok {
+		b.v.add(path, RuleUnknownType, "unknown type %q", ty)
+		return b
+	}
+	if ty != Object {
+		// Some params are shared among types, e.g. NumericParams. FillType can not tell which one.
+		_ = setParam("type", "Type", ty)(param)
+	}
+	if filler, ok := param.(FillTyper); ok {
+		filler.FillType()
+	}
+	b.apply(path, param, opts)
+	b.add(name, Property{Type: ty, Param: param})
+	return b
+}
+
+// Object adds an object property with name. Its properties are added in build.
+func (b *Builder) Object(name string, build func(o *Builder), opts ...Option) *Builder {
+	param := &ObjectParams{}
+	b.apply(joinPath(b.path, name), param, opts)
+	b.add(name, Property{Type: Object, Param: param})
+	param.Properties = b.sub(name, build)
+	return b
+}
+
+// Nested adds a nested property with name. Its properties are added in build.
+func (b *Builder) Nested(name string, build func(n *Builder), opts ...Option) *Builder {
+	param := &NestedParams{Type: Nested}
+	b.apply(joinPath(b.path, name), param, opts)
+	b.add(name, Property{Type: Nested, Param: param})
+	param.Properties = b.sub(name, build)
+	return b
+}
+
+// Keyword adds a keyword property.
+func (b *Builder) Keyword(name string, opts ...Option) *Builder {
+	return b.Field(name, Keyword, opts...)
+}
+
+// Text adds a text property.
+func (b *Builder) Text(name string, opts ...Option) *Builder {
+	return b.Field(name, Text, opts...)
+}
+
+// Boolean adds a boolean property.
+func (b *Builder) Boolean(name string, opts ...Option) *Builder {
+	return b.Field(name, Boolean, opts...)
+}
+
+// Date adds a date property. Use Format to set its formats.
+func (b *Builder) Date(name string, opts ...Option) *Builder {
+	return b.Field(name, Date, opts...)
+}
+
+// Long adds a long property.
+func (b *Builder) Long(name string, opts ...Option) *Builder {
+	return b.Field(name, Long, opts...)
+}
+
+// Integer adds an integer property.
+func (b *Builder) Integer(name string, opts ...Option) *Builder {
+	return b.Field(name, Integer, opts...)
+}
+
+// Double adds a double property.
+func (b *Builder) Double(name string, opts ...Option) *Builder {
+	return b.Field(name, Double, opts...)
+}
+
+// Float adds a float property.
+func (b *Builder) Float(name string, opts ...Option) *Builder {
+	return b.Field(name, Float, opts...)
+}
+
+// IP adds an ip property.
+func (b *Builder) IP(name string, opts ...Option) *Builder {
+	return b.Field(name, IP, opts...)
+}
+
+// Geopoint adds a geo_point property.
+func (b *Builder) Geopoint(name string, opts ...Option) *Builder {
+	return b.Field(name, Geopoint, opts...)
+}
+
+// ScaledFloat adds a scaled_float property. scalingFactor is required by Elasticsearch.
+func (b *Builder) ScaledFloat(name string, scalingFactor float64, opts ...Option) *Builder {
+	return b.Field(name, ScaledFloat, append([]Option{ScalingFactor(scalingFactor)}, opts...)...)
+}
+
+// DenseVector adds a dense_vector property. dims is required by Elasticsearch.
+func (b *Builder) DenseVector(name string, dims int, opts ...Option) *Builder {
+	return b.Field(name, DenseVector, append([]Option{Dims(dims)}, opts...)...)
+}
+
+// Alias adds an alias to the field at path.
+func (b *Builder) Alias(name string, path string) *Builder {
+	return b.Field(name, Alias, setParam("path", "Path", path))
+}
+
+// Build returns the built mappings.
+// err is ValidationErrors if any problem is found while building, or nil otherwise.
+//
+// The returned mappings share properties with b. b should not be modified after Build.
+func (b *Builder) Build() (Mappings, error) {
+	root := b.root
+	if len(b.props) > 0 {
+		props := b.props
+		root.Properties = &props
+	}
+	m := Mappings{ObjectParams: root}
+
+	v := *b.v
+	v.errs = append(ValidationErrors{}, v.errs...)
+	v.dynamicTemplates(m.DynamicTemplates)
+	v.aliasTargets(m.Properties)
+	return m, v.result()
+}
+
+// MustBuild is same as Build but panics if any problem is found.
+func (b *Builder) MustBuild() Mappings {
+	m, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (b *Builder) add(name string, prop Property) {
+	path := joinPath(b.path, name)
+	if name == "" {
+		b.v.add(path, RuleFieldName, "empty field name")
+	}
+	if _, ok := b.props[name]; ok {
+		b.v.add(path, RuleFieldName, "duplicate field name")
+	}
+	b.props[name] = prop
+	if !b.multiField {
+		b.v.property(path, prop)
+	}
+}
+
+func (b *Builder) sub(name string, build func(b *Builder)) *Properties {
+	sub := &Builder{path: joinPath(b.path, name), props: Properties{}, v: b.v, multiField: b.multiField}
+	if build != nil {
+		build(sub)
+	}
+	if len(sub.props) == 0 {
+		return nil
+	}
+	return &sub.props
+}
+
+func (b *Builder) apply(path string, param any, opts []Option) {
+	for _, opt := range opts {
+		err := opt(param)
+		if err == nil {
+			continue
+		}
+		if errs, ok := err.(ValidationErrors); ok {
+			// from sub builders, e.g. MultiFields.
+			for _, e := range errs {
+				b.v.add(joinPath(path, e.Path), e.Rule, "%s", e.Message)
+			}
+			continue
+		}
+		b.v.add(path, RuleParam, "%s", err)
+	}
+}
+
+// MultiFields sets multi-fields added in build.
+func MultiFields(build func(f *Builder)) Option {
+	return func(param any) error {
+		sub := &Builder{props: Properties{}, v: &validator{}, multiField: true}
+		build(sub)
+		fields := Fields(sub.props)
+		if err := setParam("fields", "Fields", fields)(param); err != nil {
+			return err
+		}
+		if len(sub.v.errs) > 0 {
+			return sub.v.errs
+		}
+		return nil
+	}
+}
+
+// DynamicMode sets dynamic. Use one of TrueBool, FalseBool, Runtime or Strict.
+func DynamicMode(d Dynamic) Option {
+	return setParam("dynamic", "Dynamic", d)
+}
+
+// Enabled sets enabled of object or flattened fields. Fields of disabled objects are not parsed nor indexed.
+func Enabled(enabled bool) Option {
+	return setParam("enabled", "Enabled", enabled)
+}
+
+// Index sets index, whether the field is searchable.
+func Index(index bool) Option {
+	return setParam("index", "Index", index)
+}
+
+// DocValues sets doc_values, whether the field is stored on disk in a column-stride fashion
+// for sorting, aggregations and scripting.
+func DocValues(docValues bool) Option {
+	return setParam("doc_values", "DocValues", docValues)
+}
+
+// Store sets store, whether the field value is stored apart from _source.
+func Store(store bool) Option {
+	return setParam("store", "Store", store)
+}
+
+// Norms sets norms, whether normalization factors are stored for scoring.
+// Norms can be disabled on an existing field, but can not be re-enabled.
+func Norms(norms bool) Option {
+	return setParam("norms", "Norms", norms)
+}
+
+// Coerce sets coerce, whether malformed values, e.g. "5" for integer fields, are converted to the field type.
+func Coerce(coerce bool) Option {
+	return setParam("coerce", "Coerce", coerce)
+}
+
+// IgnoreMalformed sets ignore_malformed, whether malformed values are ignored instead of rejecting the document.
+func IgnoreMalformed(ignoreMalformed bool) Option {
+	return setParam("ignore_malformed", "IgnoreMalformed", ignoreMalformed)
+}
+
+// EagerGlobalOrdinals sets eager_global_ordinals, whether global ordinals are loaded on refresh.
+func EagerGlobalOrdinals(eagerGlobalOrdinals bool) Option {
+	return setParam("eager_global_ordinals", "EagerGlobalOrdinals", eagerGlobalOrdinals)
+}
+
+// TimeSeriesDimension sets time_series_dimension, marking the field as a dimension of time series indices.
+func TimeSeriesDimension(timeSeriesDimension bool) Option {
+	return setParam("time_series_dimension", "TimeSeriesDimension", timeSeriesDimension)
+}
+
+// IgnoreAbove sets ignore_above. Strings longer than it are not indexed nor stored.
+func IgnoreAbove(ignoreAbove int) Option {
+	return setParam("ignore_above", "IgnoreAbove", ignoreAbove)
+}
+
+// NullValue sets null_value of numeric fields, which is indexed in place of explicit nulls.
+func NullValue(nullValue float64) Option {
+	return setParam("null_value", "NullValue", nullValue)
+}
+
+// Analyzer sets analyzer used at index time, and at search time unless SearchAnalyzer is set.
+func Analyzer(analyzer string) Option {
+	return setParam("analyzer", "Analyzer", analyzer)
+}
+
+// SearchAnalyzer sets search_analyzer used at search time.
+func SearchAnalyzer(searchAnalyzer string) Option {
+	return setParam("search_analyzer", "SearchAnalyzer", searchAnalyzer)
+}
+
+// Normalizer sets normalizer of keyword fields.
+func Normalizer(normalizer string) Option {
+	return setParam("normalizer", "Normalizer", normalizer)
+}
+
+// Format sets format of date fields. Multiple formats are separated by ||, e.g. "yyyy-MM-dd||epoch_millis".
+func Format(format string) Option {
+	return setParam("format", "Format", format)
+}
+
+// ScalingFactor sets scaling_factor of scaled_float fields, which values are multiplied by before being stored as long.
+func ScalingFactor(scalingFactor float64) Option {
+	return setParam("scaling_factor", "ScalingFactor", scalingFactor)
+}
+
+// Dims sets dims of dense_vector fields, the number of vector dimensions.
+func Dims(dims int) Option {
+	return setParam("dims", "Dims", dims)
+}
+
+// Similarity sets similarity. It is a similarity algorithm for text and keyword,
+// or a vector similarity for dense_vector.
+func Similarity(similarity string) Option {
+	return func(param any) error {
+		if _, ok := param.(*DenseVectorParams); ok {
+			return setParam("similarity", "Similarity", denseVectorSimilarity(similarity))(param)
+		}
+		return setParam("similarity", "Similarity", similarity)(param)
+	}
+}
+
+// FieldMeta sets meta, metadata about the field. Elasticsearch does not use it by itself.
+func FieldMeta(meta Meta) Option {
+	return setParam("meta", "Meta", meta)
+}
+
+// Relation adds a relation of join from parent to children.
+func Relation(parent string, children ...string) Option {
+	return func(param any) error {
+		join, ok := param.(*JoinParams)
+		if !ok {
+			return fmt.Errorf("relations is not a param of %s", typeName(param))
+		}
+		if join.Relations == nil {
+			join.Relations = map[string]any{}
+		}
+		if len(children) == 1 {
+			join.Relations[parent] = children[0]
+		} else {
+			join.Relations[parent] = children
+		}
+		return nil
+	}
+}
+
+// Contexts sets contexts of completion.
+func Contexts(contexts ...CompletionContext) Option {
+	return setParam("contexts", "Contexts", contexts)
+}
+
+// setParam returns an Option which sets value to the field of the param struct named goName.
+// If the field is a pointer, value is set to a newly allocated one.
+// value must be assignable to the field, or to what the field points to.
+// It is not converted, so that e.g. a float value is not truncated into an int field.
+func setParam(jsonName, goName string, value any) Option {
+	return func(param any) error {
+		rv := reflect.ValueOf(param)
+		if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("%s is not a param of %s", jsonName, typeName(param))
+		}
+		field := rv.Elem().FieldByName(goName)
+		if !field.IsValid() || !field.CanSet() {
+			return fmt.Errorf("%s is not a param of %s", jsonName, typeName(param))
+		}
+
+		target := field.Type()
+		if target.Kind() == reflect.Pointer {
+			target = target.Elem()
+		}
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(target) {
+			return fmt.Errorf("%s of %s must be %s, but is %T", jsonName, typeName(param), target, value)
+		}
+
+		if field.Kind() == reflect.Pointer {
+			ptr := reflect.New(target)
+			ptr.Elem().Set(v)
+			field.Set(ptr)
+		} else {
+			field.Set(v)
+		}
+		return nil
+	}
+}
+
+// typeName returns type of param, as named in Elasticsearch if possible.
+func typeName(param any) string {
+	if _, ok := param.(*ObjectParams); ok {
+		return string(Object)
+	}
+	rv := reflect.ValueOf(param)
+	if rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Struct {
+		if f := rv.Elem().FieldByName("Type"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+	return fmt.Sprintf("%T", param)
+}
@@ -14,8 +14,31 @@ type CompletionParams struct {
 	PreservePositionIncrements *bool `json:"preserve_position_increments,omitempty"`
 	// Defaults to 50 UTF-16 code points.
 	MaxInputLength uint `json:"max_input_length,omitempty"`
+	// Contexts are used to filter or boost suggestions by categories or locations.
+	Contexts []CompletionContext `json:"contexts,omitempty"`
 }
 
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/suggester-context.html
+type CompletionContext struct {
+	// Name is the name of the context, used as a key of contexts in completion values and queries.
+	Name string `json:"name"`
+	// Type is either category or geo.
+	Type CompletionContextType `json:"type"`
+	// Path is a field to index contexts from.
+	// If nil, contexts must be set explicitly in completion values.
+	Path *string `json:"path,omitempty"`
+	// Precision is only for geo contexts. It is a geohash level from 1 to 12, or a distance like "5km".
+	// Defaults to 6.
+	Precision any `json:"precision,omitempty"`
+}
+
+type CompletionContextType string
+
+const (
+	CategoryContext CompletionContextType = "category"
+	GeoContext      CompletionContextType = "geo"
+)
+
 func (p *CompletionParams) FillType() {
 	if p.Type == "" {
 		p.Type = Completion
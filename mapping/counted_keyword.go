@@ -0,0 +1,18 @@
+package mapping
+
+// CountedKeywordParams is available since 8.12.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.12/counted-keyword.html
+type CountedKeywordParams struct {
+	// Type is type of this property. Automatically filled if zero.
+	Type EsType `json:"type,omitempty"`
+	// Index indicates whether the field should be quickly searchable.
+	// Default(nil) is true.
+	Index *bool `json:"index,omitempty"`
+}
+
+func (p *CountedKeywordParams) FillType() {
+	if p.Type == "" {
+		p.Type = CountedKeyword
+	}
+}
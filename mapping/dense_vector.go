@@ -4,7 +4,14 @@ package mapping
 type DenseVectorParams struct {
 	// Type is type of this property. Automatically filled if zero.
 	Type EsType `json:"type,omitempty"`
-	Dims int    `json:"dims"`
+	// ElementType is available since 8.6. "bit" is available since 8.15.
+	// Default(nil) is Float.
+	ElementType *denseVectorElementType `json:"element_type,omitempty"`
+	// Dims is the number of dimensions. Required before 8.11, and must be 1 to 2048.
+	// Since 8.11, it can be omitted to be set by the first indexed vector, and must be 1 to 4096.
+	// Zero means omitted.
+	// For ElementBit, it is the number of bits and must be a multiple of 8.
+	Dims int `json:"dims,omitempty"`
 	// If true, you can search this field using the kNN search API.
 	// Defaults to false.
 	Index *bool `json:"index,omitempty"`
@@ -19,6 +26,17 @@ func (p *DenseVectorParams) FillType() {
 	}
 }
 
+type denseVectorElementType string
+
+const (
+	ElementFloat denseVectorElementType = "float"
+	// ElementByte is stored as a signed 8-bit integer, -128 to 127.
+	ElementByte denseVectorElementType = "byte"
+	// ElementBit is stored as a bit vector, whose dims must be a multiple of 8.
+	// Values are packed into dims / 8 bytes, given as signed 8-bit integers or a hex string.
+	ElementBit denseVectorElementType = "bit"
+)
+
 type denseVectorSimilarity string
 
 const (
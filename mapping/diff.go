@@ -0,0 +1,282 @@
+package mapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind classifies a Change by how it can be applied to an existing index.
+type ChangeKind string
+
+const (
+	// Additive is a new field or multi-field.
+	// It can be applied by PUT /<index_name>/_mapping.
+	Additive ChangeKind = "additive"
+	// InPlace is an update of a parameter which is updatable on an existing field,
+	// e.g. ignore_above, meta or search_analyzer.
+	// It can be applied by PUT /<index_name>/_mapping.
+	InPlace ChangeKind = "in_place"
+	// Breaking is a change which Elasticsearch rejects on an existing field,
+	// e.g. type change, analyzer change or object <-> nested.
+	// It requires creating a new index and reindexing.
+	Breaking ChangeKind = "breaking"
+	// Removed is a field or multi-field which exists only in the old mapping.
+	// PUT /<index_name>/_mapping does not reject it, but the field is kept by Elasticsearch
+	// since fields can not be removed from an existing index.
+	// Removing it for real requires creating a new index and reindexing.
+	Removed ChangeKind = "removed"
+)
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/indices-put-mapping.html#updating-field-mappings
+var inPlaceParams = map[string]bool{
+	"_meta":                 true,
+	"coerce":                true,
+	"date_detection":        true,
+	"dynamic":               true,
+	"dynamic_date_formats":  true,
+	"dynamic_templates":     true,
+	"eager_global_ordinals": true,
+	"fielddata":             true,
+	"ignore_above":          true,
+	"ignore_malformed":      true,
+	"meta":                  true,
+	"numeric_detection":     true,
+	"runtime":               true,
+	"search_analyzer":       true,
+	"search_quote_analyzer": true,
+}
+
+// Change is a difference of a field between two mappings.
+type Change struct {
+	// Path is the dotted path to the changed field. Multi-fields are joined with dot as well.
+	// Empty for the mapping root.
+	Path string
+	Kind ChangeKind
+	// Param is the name of the changed parameter.
+	// Empty if the field itself is added, removed, or changed its type.
+	Param string
+	// Old and New are values before and after the change, decoded as JSON.
+	// nil if the value does not exist in that side.
+	Old any
+	New any
+}
+
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "<root>"
+	}
+
+	var desc string
+	switch {
+	case c.Param == "type":
+		desc = fmt.Sprintf("type changed from %s to %s", jsonStr(c.Old), jsonStr(c.New))
+	case c.Param != "" && c.Old == nil:
+		desc = fmt.Sprintf("%s set to %s", c.Param, jsonStr(c.New))
+	case c.Param != "" && c.New == nil:
+		desc = fmt.Sprintf("%s unset from %s", c.Param, jsonStr(c.Old))
+	case c.Param != "":
+		desc = fmt.Sprintf("%s changed from %s to %s", c.Param, jsonStr(c.Old), jsonStr(c.New))
+	case c.Old == nil:
+		desc = "field added"
+	default:
+		desc = "field removed, kept by Elasticsearch"
+	}
+	return fmt.Sprintf("%s: %s: %s", c.Kind, path, desc)
+}
+
+func jsonStr(v any) string {
+	bin, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(bin)
+}
+
+// Changes is a list of Change returned from Diff, sorted by path.
+type Changes []Change
+
+// Breaking reports whether c contains any breaking change, which means the new mapping requires reindexing.
+func (c Changes) Breaking() bool {
+	for _, change := range c {
+		if change.Kind == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns changes of kind.
+func (c Changes) Filter(kind ChangeKind) Changes {
+	var out Changes
+	for _, change := range c {
+		if change.Kind == kind {
+			out = append(out, change)
+		}
+	}
+	return out
+}
+
+// String returns human-readable report of c.
+func (c Changes) String() string {
+	if len(c) == 0 {
+		return "no changes\n"
+	}
+
+	var b strings.Builder
+	for _, kind := range []ChangeKind{Breaking, Removed, InPlace, Additive} {
+		for _, change := range c.Filter(kind) {
+			b.WriteString(change.String())
+			b.WriteByte('\n')
+		}
+	}
+
+	if c.Breaking() {
+		b.WriteString("result: breaking, new index and reindex required\n")
+	} else {
+		b.WriteString("result: compatible, can be applied by PUT /<index_name>/_mapping\n")
+	}
+	if len(c.Filter(Removed)) > 0 {
+		b.WriteString("note: removed fields are kept in the existing index, reindex to drop them\n")
+	}
+	return b.String()
+}
+
+// Diff compares old and new, and classifies each change
+// by whether PUT /<index_name>/_mapping accepts it or not.
+//
+// A field missing in new is reported as Removed, since PUT /<index_name>/_mapping accepts it but the field stays in the index.
+// Params which are not known to be updatable are conservatively reported as Breaking.
+func Diff(old, new Mappings) Changes {
+	var changes Changes
+	changes = diffParams(changes, "", &old, &new)
+	changes = diffProperties(changes, "", propertiesOf(old.Properties), propertiesOf(new.Properties))
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func propertiesOf(p *Properties) Properties {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+func diffProperties(changes Changes, path string, old, new map[string]Property) Changes {
+	names := map[string]struct{}{}
+	for name := range old {
+		names[name] = struct{}{}
+	}
+	for name := range new {
+		names[name] = struct{}{}
+	}
+
+	for _, name := range sortedKeys(names) {
+		fieldPath := joinPath(path, name)
+		oldProp, inOld := old[name]
+		newProp, inNew := new[name]
+
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Path: fieldPath, Kind: Additive, New: toAny(newProp)})
+		case !inNew:
+			changes = append(changes, Change{Path: fieldPath, Kind: Removed, Old: toAny(oldProp)})
+		default:
+			changes = diffProperty(changes, fieldPath, oldProp, newProp)
+		}
+	}
+	return changes
+}
+
+func diffProperty(changes Changes, path string, old, new Property) Changes {
+	oldTy, newTy := typeOf(old), typeOf(new)
+	if oldTy != newTy {
+		return append(changes, Change{
+			Path:  path,
+			Kind:  Breaking,
+			Param: "type",
+			Old:   string(oldTy),
+			New:   string(newTy),
+		})
+	}
+
+	// Properties are compared as a whole, so that changes of unknown params are also reported.
+	changes = diffParams(changes, path, old, new)
+
+	switch oldParam := old.Param.(type) {
+	case *ObjectParams:
+		newParam := new.Param.(*ObjectParams)
+		changes = diffProperties(changes, path, propertiesOf(oldParam.Properties), propertiesOf(newParam.Properties))
+	case *NestedParams:
+		newParam := new.Param.(*NestedParams)
+		changes = diffProperties(changes, path, propertiesOf(oldParam.Properties), propertiesOf(newParam.Properties))
+	case *PassthroughParams:
+		newParam := new.Param.(*PassthroughParams)
+		changes = diffProperties(changes, path, propertiesOf(oldParam.Properties), propertiesOf(newParam.Properties))
+	}
+
+	return diffProperties(changes, path, old.MultiFields(), new.MultiFields())
+}
+
+// typeOf returns type of p. Unknown type stored in ObjectParams is also returned.
+func typeOf(p Property) EsType {
+	if o, ok := p.Param.(*ObjectParams); ok && o.Type != "" {
+		return o.Type
+	}
+	if p.IsObject() {
+		return Object
+	}
+	return p.Type
+}
+
+// diffParams compares params except for type, properties and fields.
+func diffParams(changes Changes, path string, old, new any) Changes {
+	oldMap, newMap := paramMap(old), paramMap(new)
+
+	keys := map[string]struct{}{}
+	for k := range oldMap {
+		keys[k] = struct{}{}
+	}
+	for k := range newMap {
+		keys[k] = struct{}{}
+	}
+
+	for _, k := range sortedKeys(keys) {
+		oldV, newV := oldMap[k], newMap[k]
+		if reflect.DeepEqual(oldV, newV) {
+			continue
+		}
+		kind := Breaking
+		if inPlaceParams[k] || (k == "norms" && newV == false) {
+			// norms can be disabled but can not be re-enabled.
+			kind = InPlace
+		}
+		changes = append(changes, Change{Path: path, Kind: kind, Param: k, Old: oldV, New: newV})
+	}
+	return changes
+}
+
+func paramMap(param any) map[string]any {
+	m, _ := toAny(param).(map[string]any)
+	delete(m, "type")
+	delete(m, "properties")
+	delete(m, "fields")
+	return m
+}
+
+func toAny(v any) any {
+	bin, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out any
+	if err := json.Unmarshal(bin, &out); err != nil {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,203 @@
+package mapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// DynamicTemplates is dynamic_templates of the mapping root.
+// Templates are matched in the order, and the first matching one is used.
+//
+// Each element must have only one key, the name of the template.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/dynamic-templates.html
+type DynamicTemplates []map[string]DynamicTemplate
+
+// NamedDynamicTemplate is an element of DynamicTemplates.
+type NamedDynamicTemplate struct {
+	Name     string
+	Template DynamicTemplate
+}
+
+// Templates returns templates in the order, flattening single-key maps.
+// It returns an error if an element does not have exactly one key.
+func (t DynamicTemplates) Templates() ([]NamedDynamicTemplate, error) {
+	out := make([]NamedDynamicTemplate, 0, len(t))
+	for i, m := range t {
+		if len(m) != 1 {
+			return nil, fmt.Errorf("dynamic_templates[%d]: must have exactly one key but has %d", i, len(m))
+		}
+		for name, template := range m {
+			out = append(out, NamedDynamicTemplate{Name: name, Template: template})
+		}
+	}
+	return out, nil
+}
+
+type matchPattern string
+
+const (
+	// Simple is the default match_pattern, where * is a wildcard.
+	Simple matchPattern = "simple"
+	// Regex makes match and unmatch be treated as Java regular expressions.
+	Regex matchPattern = "regex"
+)
+
+// DynamicMappingType is a type name used in match_mapping_type,
+// which is detected from a JSON value by dynamic mapping.
+type DynamicMappingType string
+
+const (
+	DynamicMappingTypeAny     DynamicMappingType = "*"
+	DynamicMappingTypeBoolean DynamicMappingType = "boolean"
+	DynamicMappingTypeBinary  DynamicMappingType = "binary"
+	DynamicMappingTypeDate    DynamicMappingType = "date"
+	DynamicMappingTypeDouble  DynamicMappingType = "double"
+	DynamicMappingTypeLong    DynamicMappingType = "long"
+	DynamicMappingTypeObject  DynamicMappingType = "object"
+	DynamicMappingTypeString  DynamicMappingType = "string"
+)
+
+// defaultDynamicType is the type which {dynamic_type} is replaced with for each match_mapping_type.
+var defaultDynamicType = map[DynamicMappingType]EsType{
+	DynamicMappingTypeBoolean: Boolean,
+	DynamicMappingTypeBinary:  Binary,
+	DynamicMappingTypeDate:    Date,
+	DynamicMappingTypeDouble:  Float,
+	DynamicMappingTypeLong:    Long,
+	DynamicMappingTypeObject:  Object,
+	DynamicMappingTypeString:  Text,
+}
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/dynamic-templates.html
+type DynamicTemplate struct {
+	// MatchMappingType matches the type detected by dynamic field mapping.
+	MatchMappingType Patterns `json:"match_mapping_type,omitempty"`
+	// Match and Unmatch match the field name.
+	Match   Patterns `json:"match,omitempty"`
+	Unmatch Patterns `json:"unmatch,omitempty"`
+	// PathMatch and PathUnmatch match the full dotted path to the field.
+	PathMatch   Patterns `json:"path_match,omitempty"`
+	PathUnmatch Patterns `json:"path_unmatch,omitempty"`
+	// Defaults to "simple".
+	MatchPattern *matchPattern `json:"match_pattern,omitempty"`
+	// Mapping is the mapping for matched fields.
+	// It is kept as raw JSON since it may not have type,
+	// or may have placeholders like {name} and {dynamic_type}. Use Property to decode it.
+	Mapping json.RawMessage `json:"mapping,omitempty"`
+	// Runtime is the runtime field definition for matched fields, used instead of Mapping.
+	Runtime any `json:"runtime,omitempty"`
+}
+
+// Property decodes Mapping into Property.
+//
+// If the type is missing or is the {dynamic_type} placeholder,
+// it is resolved from MatchMappingType if that is a single concrete type,
+// e.g. long for "long", text for "string".
+// ok is false if Mapping is empty or the type can not be resolved statically.
+func (t DynamicTemplate) Property() (prop Property, ok bool, err error) {
+	if len(t.Mapping) == 0 {
+		return Property{}, false, nil
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(t.Mapping, &raw); err != nil {
+		return Property{}, false, err
+	}
+
+	ty, _ := raw["type"].(string)
+	if ty == "" || ty == "{dynamic_type}" {
+		if len(t.MatchMappingType) != 1 {
+			return Property{}, false, nil
+		}
+		resolved, found := defaultDynamicType[DynamicMappingType(t.MatchMappingType[0])]
+		if !found {
+			return Property{}, false, nil
+		}
+		raw["type"] = string(resolved)
+	}
+
+	bin, err := json.Marshal(raw)
+	if err != nil {
+		return Property{}, false, err
+	}
+	if err := json.Unmarshal(bin, &prop); err != nil {
+		return Property{}, false, err
+	}
+	if _, isUnknown := prop.Param.(*UnknownParams); isUnknown {
+		// unknown type, including ones with other placeholders.
+		return Property{}, false, nil
+	}
+	return prop, true, nil
+}
+
+// ValidatePatterns returns an error if MatchPattern is "regex" and Match or Unmatch has an invalid regular expression.
+// Patterns are compiled in Go's syntax, so Java-only constructs like lookarounds are also reported.
+func (t DynamicTemplate) ValidatePatterns() error {
+	if t.MatchPattern == nil || *t.MatchPattern != Regex {
+		return nil
+	}
+	for _, pattern := range append(append(Patterns{}, t.Match...), t.Unmatch...) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// DynamicDetection is the settings of dynamic field mapping at the mapping root,
+// which affect types detected from values of dynamically mapped fields.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/dynamic-field-mapping.html#date-detection
+type DynamicDetection struct {
+	// DateDetection is date_detection. Default(nil) is true.
+	DateDetection *bool `json:"date_detection,omitempty"`
+	// DynamicDateFormats is dynamic_date_formats, each of which may be formats separated by ||.
+	// Default(nil) is ["strict_date_optional_time", "yyyy/MM/dd HH:mm:ss Z||yyyy/MM/dd Z"].
+	DynamicDateFormats []string `json:"dynamic_date_formats,omitempty"`
+}
+
+// DynamicDetection returns the settings of dynamic field mapping, which are stored in Unknown.
+func (m Mappings) DynamicDetection() (DynamicDetection, error) {
+	var d DynamicDetection
+	for key, dst := range map[string]any{
+		"date_detection":       &d.DateDetection,
+		"dynamic_date_formats": &d.DynamicDateFormats,
+	} {
+		raw, ok := m.Unknown[key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, dst); err != nil {
+			return DynamicDetection{}, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return d, nil
+}
+
+// Patterns is a pattern or a list of patterns.
+// It is unmarshalled from either a string or an array of strings,
+// and marshalled into a string if it has only one element.
+type Patterns []string
+
+func (p Patterns) MarshalJSON() ([]byte, error) {
+	if len(p) == 1 {
+		return json.Marshal(p[0])
+	}
+	return json.Marshal([]string(p))
+}
+
+func (p *Patterns) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*p = Patterns{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return err
+	}
+	*p = multi
+	return nil
+}
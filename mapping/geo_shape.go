@@ -14,6 +14,14 @@ type GeoshapeParams struct {
 	// If true, automatically close an unclosed polygon loop.
 	// Default(nil) is false.
 	Coerce *bool `json:"coerce,omitempty"`
+	// Index indicates whether the field should be quickly searchable.
+	// Fields that only have doc_values enabled can also be queried, albeit slower.
+	// Available since 8.6. Default(nil) is true.
+	Index *bool `json:"index,omitempty"`
+	// DocValues indicates whether it should save field on disk in a column-stride fashion,
+	// so that it can later be used for aggregations or scripting.
+	// Available since 8.6. Default(nil) is true.
+	DocValues *bool `json:"doc_values,omitempty"`
 }
 
 func (p *GeoshapeParams) FillType() {
@@ -1,5 +1,7 @@
 package mapping
 
+import "fmt"
+
 // https://www.elastic.co/guide/en/elasticsearch/reference/8.4/parent-join.html
 type JoinParams struct {
 	// Type is type of this property. Automatically filled if zero.
@@ -19,3 +21,26 @@ func (p *JoinParams) FillType() {
 		p.Type = Join
 	}
 }
+
+// JoinChildren returns children defined in a value of JoinParams.Relations,
+// which is either a string or an array of strings.
+// It returns an error if v or any of its elements is not a string.
+func JoinChildren(v any) ([]string, error) {
+	switch x := v.(type) {
+	case string:
+		return []string{x}, nil
+	case []string:
+		return x, nil
+	case []any:
+		children := make([]string, 0, len(x))
+		for _, elem := range x {
+			s, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf("must be string or []string, but has %T", elem)
+			}
+			children = append(children, s)
+		}
+		return children, nil
+	}
+	return nil, fmt.Errorf("must be string or []string, but is %T", v)
+}
@@ -29,7 +29,53 @@ type MappingSettings map[string]IndexSettings
 //   - https://www.elastic.co/guide/en/elasticsearch/reference/8.4/enabled.html
 //   - https://www.elastic.co/guide/en/elasticsearch/reference/8.4/subobjects.html
 //   - https://www.elastic.co/guide/en/elasticsearch/reference/8.4/properties.html
-type Mappings = ObjectParams
+type Mappings struct {
+	ObjectParams
+	// Runtime defines runtime fields, which are evaluated at query time.
+	Runtime map[string]RuntimeField `json:"runtime,omitempty"`
+	// Source configures the _source meta-field.
+	Source *SourceField `json:"_source,omitempty"`
+	// Routing configures the _routing meta-field.
+	Routing *RoutingField `json:"_routing,omitempty"`
+	// Meta is application specific metadata of the mapping.
+	Meta map[string]any `json:"_meta,omitempty"`
+	// DataStreamTimestamp configures the _data_stream_timestamp meta-field, which is only for data streams.
+	DataStreamTimestamp *DataStreamTimestampField `json:"_data_stream_timestamp,omitempty"`
+	// FieldNames configures the _field_names meta-field.
+	FieldNames *FieldNamesField `json:"_field_names,omitempty"`
+	// Unknown is parameters of the mapping root which are not modeled in this struct,
+	// e.g. date_detection or numeric_detection. They are kept as is and re-emitted by MarshalJSON.
+	Unknown map[string]json.RawMessage `json:"-"`
+}
+
+func (m Mappings) MarshalJSON() ([]byte, error) {
+	type plain Mappings
+	bin, err := json.Marshal(plain(m))
+	if err != nil {
+		return nil, err
+	}
+	return mergeUnknown(bin, m.Unknown)
+}
+
+func (m *Mappings) UnmarshalJSON(data []byte) error {
+	type plain Mappings
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	unknown, err := unknownKeys(data, &p)
+	if err != nil {
+		return err
+	}
+	p.Unknown = unknown
+	*m = Mappings(p)
+	return nil
+}
+
+// RoutingRequired reports whether _routing.required is true.
+func (m Mappings) RoutingRequired() bool {
+	return m.Routing != nil && m.Routing.Required != nil && *m.Routing.Required
+}
 
 type Properties map[string]Property
 
@@ -44,18 +90,47 @@ func (p *Properties) FillType() {
 type Property struct {
 	Type  EsType
 	Param any
+	// Unknown is parameters which are not modeled in the param struct,
+	// e.g. parameters added by newer Elasticsearch versions or by plugins.
+	// They are kept as is and re-emitted by MarshalJSON,
+	// so that a mapping can be read, modified and put back without losing them.
+	Unknown map[string]json.RawMessage
 }
 
 func (p Property) IsObject() bool {
 	return p.Type == Object || p.Type == ""
 }
 
+// IsObjectLike reports whether p has sub properties: object, nested or passthrough.
 func (p Property) IsObjectLike() bool {
-	return p.IsObject() || p.Type == Nested
+	return p.IsObject() || p.Type == Nested || p.Type == Passthrough
+}
+
+// MultiFields returns multi-fields defined in the fields parameter, or nil if the property has none.
+func (p Property) MultiFields() Fields {
+	switch param := p.Param.(type) {
+	case *KeywordParams:
+		if param.Fields != nil {
+			return *param.Fields
+		}
+	case *TextParams:
+		if param.Fields != nil {
+			return *param.Fields
+		}
+	case *MatchOnlyTextParams:
+		if param.Fields != nil {
+			return *param.Fields
+		}
+	}
+	return nil
 }
 
 func (p Property) MarshalJSON() ([]byte, error) {
-	return json.Marshal(p.Param)
+	bin, err := json.Marshal(p.Param)
+	if err != nil {
+		return nil, err
+	}
+	return mergeUnknown(bin, p.Unknown)
 }
 
 func (p *Property) UnmarshalJSON(data []byte) error {
@@ -71,107 +146,149 @@ func (p *Property) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if ty.Type != "" {
-		p.Type = ty.Type
+	if ty.Type == "" {
+		ty.Type = Object
 	}
+	p.Type = ty.Type
 
-	switch ty.Type {
-	default:
-		p.Type = Object
+	if param, ok := newBuiltinParam(ty.Type); ok {
+		p.Param = param
+	} else if param, ok := newRegisteredParam(ty.Type); ok {
+		p.Param = param
+	} else {
+		p.Param = &UnknownParams{}
+	}
+
+	err = json.Unmarshal(data, &p.Param)
+	if err != nil {
+		return err
+	}
 
+	p.Unknown, err = unknownKeys(data, p.Param)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsBuiltinType reports whether ty is a type built into this package.
+func IsBuiltinType(ty EsType) bool {
+	_, ok := newBuiltinParam(ty)
+	return ok
+}
+
+// newBuiltinParam returns a new pointer to the param type for ty.
+// Object is also returned for an empty type.
+func newBuiltinParam(ty EsType) (any, bool) {
+	switch ty {
+	default:
+		return nil, false
+	case "", Object:
 		var o ObjectParams
-		p.Param = &o
+		return &o, true
 	case AggregateMetricDouble:
 		var o AggregateMetricDoubleParams
-		p.Param = &o
+		return &o, true
 	case Alias:
 		var o AliasParams
-		p.Param = &o
+		return &o, true
 	case Binary:
 		var o BinaryParams
-		p.Param = &o
+		return &o, true
 	case Boolean:
 		var o BooleanParams
-		p.Param = &o
+		return &o, true
 	case Completion:
 		var o CompletionParams
-		p.Param = &o
+		return &o, true
 	case Date, DateNanoseconds:
 		var o DateParams
-		p.Param = &o
+		return &o, true
 	case DenseVector:
 		var o DenseVectorParams
-		p.Param = &o
+		return &o, true
 	case Flattened:
 		var o FlattenedParams
-		p.Param = &o
+		return &o, true
 	case Geopoint:
 		var o GeopointParams
-		p.Param = &o
+		return &o, true
 	case Geoshape:
 		var o GeoshapeParams
-		p.Param = &o
+		return &o, true
 	case Histogram:
 		var o HistogramParams
-		p.Param = &o
+		return &o, true
 	case IP:
 		var o IPParams
-		p.Param = &o
+		return &o, true
 	case Join:
 		var o JoinParams
-		p.Param = &o
+		return &o, true
 	case Nested:
 		var o NestedParams
-		p.Param = &o
+		return &o, true
 	case Percolator:
 		var o PercolatorParams
-		p.Param = &o
+		return &o, true
 	case Point:
 		var o PointParams
-		p.Param = &o
+		return &o, true
 	case RankFeature, RankFeatures:
 		var o RankFeatureParams
-		p.Param = &o
+		return &o, true
 	case SearchAsYouType:
 		var o SearchAsYouTypeParams
-		p.Param = &o
+		return &o, true
 	case Shape:
 		var o ShapeParams
-		p.Param = &o
+		return &o, true
 	case TokenCount:
 		var o TokenCountParams
-		p.Param = &o
+		return &o, true
 	case Version:
 		var o VersionParams
-		p.Param = &o
+		return &o, true
 	case Keyword:
 		var o KeywordParams
-		p.Param = &o
+		return &o, true
 	case ConstantKeyword:
 		var o ConstantKeywordParams
-		p.Param = &o
+		return &o, true
 	case Wildcard:
 		var o WildcardParams
-		p.Param = &o
-	case Text:
+		return &o, true
+	case Text, AnnotatedText:
 		var o TextParams
-		p.Param = &o
+		return &o, true
+	case MatchOnlyText:
+		var o MatchOnlyTextParams
+		return &o, true
+	case CountedKeyword:
+		var o CountedKeywordParams
+		return &o, true
+	case SparseVector:
+		var o SparseVectorParams
+		return &o, true
+	case SemanticText:
+		var o SemanticTextParams
+		return &o, true
+	case Passthrough:
+		var o PassthroughParams
+		return &o, true
 	case Long, Integer, Short, Byte, Double, Float, HalfFloat, UnsignedLong:
 		var o NumericParams
-		p.Param = &o
+		return &o, true
 	case ScaledFloat:
 		var o ScaledFloatParams
-		p.Param = &o
-	case IntegerRange, FloatRange, LongRange, DoubleRange, DateRange, IpRange:
+		return &o, true
+	case IntegerRange, FloatRange, LongRange, DoubleRange, IpRange:
 		var o RangeParams
-		p.Param = &o
+		return &o, true
+	case DateRange:
+		var o DateRangeParams
+		return &o, true
 	}
-
-	err = json.Unmarshal(data, &p.Param)
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
 type FillTyper interface {
@@ -228,7 +345,7 @@ var (
 	TrueStr   Dynamic = []byte(`"true"`)
 	FalseStr  Dynamic = []byte(`"false"`)
 	Runtime   Dynamic = []byte(`"runtime"`)
-	Strict    Dynamic = []byte(`"script"`)
+	Strict    Dynamic = []byte(`"strict"`)
 )
 
 var validDynamic = []Dynamic{
@@ -0,0 +1,16 @@
+package mapping
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/text.html#match-only-text-field-type
+type MatchOnlyTextParams struct {
+	// Type is type of this property. Automatically filled if zero.
+	Type   EsType  `json:"type,omitempty"`
+	Fields *Fields `json:"fields,omitempty"`
+	// Meta is metadata about the field.
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+func (p *MatchOnlyTextParams) FillType() {
+	if p.Type == "" {
+		p.Type = MatchOnlyText
+	}
+}
@@ -0,0 +1,41 @@
+package mapping
+
+type sourceMode string
+
+const (
+	SourceStored    sourceMode = "stored"
+	SourceSynthetic sourceMode = "synthetic"
+	SourceDisabled  sourceMode = "disabled"
+)
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/mapping-source-field.html
+type SourceField struct {
+	// Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Includes and Excludes are paths of fields to be included in or excluded from stored _source.
+	// Wildcards (*) are allowed. Excludes take precedence over Includes.
+	Includes []string `json:"includes,omitempty"`
+	Excludes []string `json:"excludes,omitempty"`
+	// [preview]
+	//
+	// Mode can be "stored", "synthetic" or "disabled".
+	Mode *sourceMode `json:"mode,omitempty"`
+}
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/mapping-routing-field.html
+type RoutingField struct {
+	// Required indicates custom routing is required for index, get, update and delete requests of documents.
+	// Defaults to false.
+	Required *bool `json:"required,omitempty"`
+}
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/mapping-data-stream-timestamp-field.html
+type DataStreamTimestampField struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/mapping-field-names-field.html
+type FieldNamesField struct {
+	// Deprecated: disabling _field_names is deprecated.
+	Enabled *bool `json:"enabled,omitempty"`
+}
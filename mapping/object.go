@@ -12,6 +12,8 @@ type ObjectParams struct {
 	// Defaults to true.
 	Subobjects *bool       `json:"subobjects,omitempty"`
 	Properties *Properties `json:"properties,omitempty"`
+	// DynamicTemplates is only valid at the mapping root.
+	DynamicTemplates DynamicTemplates `json:"dynamic_templates,omitempty"`
 }
 
 func (p *ObjectParams) FillType() {
@@ -0,0 +1,26 @@
+package mapping
+
+// PassthroughParams is available since 8.13.
+// Sub-fields of passthrough objects can also be referenced at the top level, without the prefix.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.13/passthrough.html
+type PassthroughParams struct {
+	// Type is type of this property. Automatically filled if zero.
+	Type EsType `json:"type,omitempty"`
+	// Dynamic can be bool(true/false/"true"/"false"), "runtime" or "strict".
+	// Defaults to true.
+	Dynamic    Dynamic     `json:"dynamic,omitempty"`
+	Properties *Properties `json:"properties,omitempty"`
+	// Priority decides which one wins if sub-fields of multiple passthrough objects have the same name.
+	// Required for passthrough objects not at the root since 8.14.
+	Priority *int `json:"priority,omitempty"`
+	// TimeSeriesDimension marks all sub-fields as dimensions of a time series index.
+	// Defaults to false.
+	TimeSeriesDimension *bool `json:"time_series_dimension,omitempty"`
+}
+
+func (p *PassthroughParams) FillType() {
+	if p.Type == "" {
+		p.Type = Passthrough
+	}
+}
@@ -28,9 +28,12 @@ func (p *RangeParams) FillType() {
 	}
 }
 
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/range.html#range-params
 type DateRangeParams struct {
-	Format string `json:"type,omitempty"`
 	RangeParams
+	// Format is same as Format of DateParams, used to parse gt, gte, lt and lte.
+	// Defaults to "strict_date_optional_time||epoch_millis".
+	Format *string `json:"format,omitempty"`
 }
 
 func (p *DateRangeParams) FillType() {
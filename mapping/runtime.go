@@ -0,0 +1,58 @@
+package mapping
+
+// RuntimeType is a type of runtime fields.
+type RuntimeType string
+
+const (
+	RuntimeBoolean   RuntimeType = "boolean"
+	RuntimeComposite RuntimeType = "composite"
+	RuntimeDate      RuntimeType = "date"
+	RuntimeDouble    RuntimeType = "double"
+	RuntimeGeopoint  RuntimeType = "geo_point"
+	RuntimeIP        RuntimeType = "ip"
+	RuntimeKeyword   RuntimeType = "keyword"
+	RuntimeLong      RuntimeType = "long"
+	RuntimeLookup    RuntimeType = "lookup"
+)
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/runtime-mapping-fields.html
+type RuntimeField struct {
+	Type RuntimeType `json:"type"`
+	// Script emits values of the field.
+	// If omitted, the value is retrieved from _source of the field of the same name.
+	Script *Script `json:"script,omitempty"`
+	// Format is only for date. Defaults to "strict_date_optional_time||epoch_millis".
+	Format *string `json:"format,omitempty"`
+	// Fields is sub-fields of composite, emitted by its script. Each sub-field only has type.
+	Fields map[string]RuntimeField `json:"fields,omitempty"`
+	// TargetIndex, InputField, TargetField and FetchFields are only for lookup.
+	TargetIndex *string `json:"target_index,omitempty"`
+	InputField  *string `json:"input_field,omitempty"`
+	TargetField *string `json:"target_field,omitempty"`
+	FetchFields []any   `json:"fetch_fields,omitempty"`
+	// Meta is metadata about the field.
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+// EsType returns the field type corresponding to the runtime type,
+// which has the same value representation.
+// It returns false for composite and lookup.
+func (t RuntimeType) EsType() (EsType, bool) {
+	switch t {
+	case RuntimeBoolean:
+		return Boolean, true
+	case RuntimeDate:
+		return Date, true
+	case RuntimeDouble:
+		return Double, true
+	case RuntimeGeopoint:
+		return Geopoint, true
+	case RuntimeIP:
+		return IP, true
+	case RuntimeKeyword:
+		return Keyword, true
+	case RuntimeLong:
+		return Long, true
+	}
+	return "", false
+}
@@ -1,5 +1,7 @@
 package mapping
 
+import "encoding/json"
+
 // see https://www.elastic.co/guide/en/elasticsearch/reference/8.4/modules-scripting-using.html
 type Script struct {
 	// Defaults to "painless"
@@ -9,3 +11,14 @@ type Script struct {
 	Id     *string         `json:"id,omitempty"`
 	Params *map[string]any `json:"params,omitempty"`
 }
+
+// UnmarshalJSON also accepts a string, which is the shorthand for Source.
+func (s *Script) UnmarshalJSON(data []byte) error {
+	var source string
+	if err := json.Unmarshal(data, &source); err == nil {
+		*s = Script{Source: &source}
+		return nil
+	}
+	type plain Script
+	return json.Unmarshal(data, (*plain)(s))
+}
@@ -0,0 +1,25 @@
+package mapping
+
+// SemanticTextParams is available since 8.15.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.15/semantic-text.html
+type SemanticTextParams struct {
+	// Type is type of this property. Automatically filled if zero.
+	Type EsType `json:"type,omitempty"`
+	// InferenceId is the inference endpoint used to generate embeddings at index time.
+	// Required before 8.16, defaults to ".elser-2-elasticsearch" after.
+	InferenceId *string `json:"inference_id,omitempty"`
+	// SearchInferenceId is the inference endpoint used at query time.
+	// Defaults to InferenceId.
+	SearchInferenceId *string `json:"search_inference_id,omitempty"`
+	// ModelSettings is filled by Elasticsearch once the first document is indexed.
+	ModelSettings map[string]any `json:"model_settings,omitempty"`
+	// Meta is metadata about the field.
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+func (p *SemanticTextParams) FillType() {
+	if p.Type == "" {
+		p.Type = SemanticText
+	}
+}
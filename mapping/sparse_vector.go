@@ -0,0 +1,17 @@
+package mapping
+
+// SparseVectorParams is available since 8.11.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.11/sparse-vector.html
+type SparseVectorParams struct {
+	// Type is type of this property. Automatically filled if zero.
+	Type EsType `json:"type,omitempty"`
+	// Meta is metadata about the field.
+	Meta *Meta `json:"meta,omitempty"`
+}
+
+func (p *SparseVectorParams) FillType() {
+	if p.Type == "" {
+		p.Type = SparseVector
+	}
+}
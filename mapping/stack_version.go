@@ -0,0 +1,98 @@
+package mapping
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StackVersion is a version of Elasticsearch, down to the minor version.
+type StackVersion struct {
+	Major int
+	Minor int
+}
+
+// DefaultStackVersion is the version this package targets by default.
+// Types and params added after it are only accepted by version-aware functions when targeted explicitly.
+var DefaultStackVersion = StackVersion{Major: 8, Minor: 4}
+
+// ParseStackVersion parses "<major>.<minor>" or "<major>.<minor>.<patch>". The patch version is ignored.
+// An empty string is parsed as DefaultStackVersion.
+func ParseStackVersion(s string) (StackVersion, error) {
+	if s == "" {
+		return DefaultStackVersion, nil
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return StackVersion{}, fmt.Errorf("invalid version %q: must be <major>.<minor>[.<patch>]", s)
+	}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return StackVersion{}, fmt.Errorf("invalid version %q: must be <major>.<minor>[.<patch>]", s)
+		}
+		nums[i] = n
+	}
+	return StackVersion{Major: nums[0], Minor: nums[1]}, nil
+}
+
+func (v StackVersion) String() string {
+	return strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor)
+}
+
+// Before reports whether v is older than other.
+func (v StackVersion) Before(other StackVersion) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	return v.Minor < other.Minor
+}
+
+// typeAddedIn is versions types were added in. Types not listed are available in DefaultStackVersion.
+var typeAddedIn = map[EsType]StackVersion{
+	SparseVector:   {Major: 8, Minor: 11},
+	CountedKeyword: {Major: 8, Minor: 12},
+	Passthrough:    {Major: 8, Minor: 13},
+	SemanticText:   {Major: 8, Minor: 15},
+}
+
+// AddedIn returns the version ty was added in.
+// ok is false if ty is available in DefaultStackVersion or is not a built-in type.
+func (ty EsType) AddedIn() (v StackVersion, ok bool) {
+	v, ok = typeAddedIn[ty]
+	return v, ok
+}
+
+// AvailableIn reports whether ty is available in v.
+// Types not built into this package are considered to be available.
+func (ty EsType) AvailableIn(v StackVersion) bool {
+	addedIn, ok := ty.AddedIn()
+	return !ok || !v.Before(addedIn)
+}
+
+// optionalDimsSince is the version since which dims of dense_vector can be omitted, and can be up to 4096.
+var optionalDimsSince = StackVersion{Major: 8, Minor: 11}
+
+// maxDenseVectorDims returns the maximum dims of dense_vector in v, and whether dims is required.
+func maxDenseVectorDims(v StackVersion) (max int, required bool) {
+	if v.Before(optionalDimsSince) {
+		return 2048, true
+	}
+	return 4096, false
+}
+
+// geoShapeIndexSince is the version index and doc_values of geo_shape were added in.
+var geoShapeIndexSince = StackVersion{Major: 8, Minor: 6}
+
+// AddedIn returns the version the element type was added in.
+// ok is false if it is available in DefaultStackVersion.
+func (t denseVectorElementType) AddedIn() (v StackVersion, ok bool) {
+	switch t {
+	case ElementByte:
+		return StackVersion{Major: 8, Minor: 6}, true
+	case ElementBit:
+		return StackVersion{Major: 8, Minor: 15}, true
+	}
+	return StackVersion{}, false
+}
@@ -0,0 +1,350 @@
+package mapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ngicks/elastic-type/internal/wildcard"
+)
+
+// IndexTemplatesResponse is response body of GET /_index_template or GET /_index_template/<name>.
+type IndexTemplatesResponse struct {
+	IndexTemplates []NamedIndexTemplate `json:"index_templates"`
+}
+
+type NamedIndexTemplate struct {
+	Name          string        `json:"name"`
+	IndexTemplate IndexTemplate `json:"index_template"`
+}
+
+// Get returns the index template named name.
+func (r IndexTemplatesResponse) Get(name string) (IndexTemplate, bool) {
+	for _, t := range r.IndexTemplates {
+		if t.Name == name {
+			return t.IndexTemplate, true
+		}
+	}
+	return IndexTemplate{}, false
+}
+
+// Match returns the name and the index template which Elasticsearch applies to a new index named indexName,
+// that is, one with the highest priority among templates whose index_patterns match indexName.
+func (r IndexTemplatesResponse) Match(indexName string) (string, IndexTemplate, bool) {
+	var found *NamedIndexTemplate
+	for i, t := range r.IndexTemplates {
+		if !t.IndexTemplate.Matches(indexName) {
+			continue
+		}
+		if found == nil || t.IndexTemplate.priority() > found.IndexTemplate.priority() {
+			found = &r.IndexTemplates[i]
+		}
+	}
+	if found == nil {
+		return "", IndexTemplate{}, false
+	}
+	return found.Name, found.IndexTemplate, true
+}
+
+// ComponentTemplatesResponse is response body of GET /_component_template or GET /_component_template/<name>.
+type ComponentTemplatesResponse struct {
+	ComponentTemplates []NamedComponentTemplate `json:"component_templates"`
+}
+
+type NamedComponentTemplate struct {
+	Name              string            `json:"name"`
+	ComponentTemplate ComponentTemplate `json:"component_template"`
+}
+
+// Map returns component templates keyed by their names.
+func (r ComponentTemplatesResponse) Map() map[string]ComponentTemplate {
+	out := make(map[string]ComponentTemplate, len(r.ComponentTemplates))
+	for _, t := range r.ComponentTemplates {
+		out[t.Name] = t.ComponentTemplate
+	}
+	return out
+}
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/index-templates.html
+type IndexTemplate struct {
+	IndexPatterns []string `json:"index_patterns"`
+	// ComposedOf is names of component templates, applied in the order.
+	ComposedOf []string `json:"composed_of,omitempty"`
+	// Defaults to 0, the lowest priority.
+	Priority *int `json:"priority,omitempty"`
+	Version  *int `json:"version,omitempty"`
+	// Template is applied after component templates, so that it overrides them.
+	Template *IndexSettings `json:"template,omitempty"`
+	// Meta is user defined metadata about the template.
+	Meta map[string]any `json:"_meta,omitempty"`
+	// DataStream is irrelevant for this package's goal.
+	DataStream      any   `json:"data_stream,omitempty"`
+	AllowAutoCreate *bool `json:"allow_auto_create,omitempty"`
+}
+
+// https://www.elastic.co/guide/en/elasticsearch/reference/8.4/indices-component-template.html
+type ComponentTemplate struct {
+	Template IndexSettings `json:"template"`
+	Version  *int          `json:"version,omitempty"`
+	// Meta is user defined metadata about the template.
+	Meta map[string]any `json:"_meta,omitempty"`
+}
+
+func (t IndexTemplate) priority() int {
+	if t.Priority == nil {
+		return 0
+	}
+	return *t.Priority
+}
+
+// Matches reports whether one of index_patterns matches indexName.
+// Only * is treated as a wildcard, as Elasticsearch does.
+func (t IndexTemplate) Matches(indexName string) bool {
+	for _, pattern := range t.IndexPatterns {
+		if wildcard.SimpleMatch(pattern, indexName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compose composes mappings of component templates listed in composed_of in the order,
+// and then the mappings of t itself, in the same way as Elasticsearch does when creating an index.
+// It returns an error if a component template is missing in components.
+func (t IndexTemplate) Compose(components map[string]ComponentTemplate) (Mappings, error) {
+	var missing []string
+	var composed Mappings
+	for _, name := range t.ComposedOf {
+		component, ok := components[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		if component.Template.Mappings != nil {
+			composed = MergeMappings(composed, *component.Template.Mappings)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return Mappings{}, fmt.Errorf("missing component templates: %s", strings.Join(missing, ", "))
+	}
+
+	if t.Template != nil && t.Template.Mappings != nil {
+		composed = MergeMappings(composed, *t.Template.Mappings)
+	}
+	return composed, nil
+}
+
+// MergeMappings merges overlay into base and returns it as a new Mappings. Neither input is modified.
+//
+// Properties are merged recursively. For Object or Nested fields defined in both,
+// params defined in overlay win and their properties are merged.
+// Other fields, including ones whose type differs between base and overlay, are replaced with overlay's.
+// Runtime fields defined in both are also replaced.
+// _meta is merged recursively, and params of _source defined in overlay win. Other meta-fields are replaced.
+// Unknown params of the root and of merged Object or Nested fields are merged by key.
+func MergeMappings(base, overlay Mappings) Mappings {
+	out := base
+	out.ObjectParams = mergeObject(base.ObjectParams, overlay.ObjectParams)
+	out.Source = mergeSource(base.Source, overlay.Source)
+	if overlay.Routing != nil {
+		out.Routing = overlay.Routing
+	}
+	out.Meta = mergeMeta(base.Meta, overlay.Meta)
+	if overlay.DataStreamTimestamp != nil {
+		out.DataStreamTimestamp = overlay.DataStreamTimestamp
+	}
+	if overlay.FieldNames != nil {
+		out.FieldNames = overlay.FieldNames
+	}
+	out.Unknown = mergeUnknownParams(base.Unknown, overlay.Unknown)
+	if base.Runtime != nil || overlay.Runtime != nil {
+		out.Runtime = map[string]RuntimeField{}
+		for k, v := range base.Runtime {
+			out.Runtime[k] = v
+		}
+		for k, v := range overlay.Runtime {
+			out.Runtime[k] = v
+		}
+	}
+	return out
+}
+
+func mergeObject(base, overlay ObjectParams) ObjectParams {
+	out := base
+	if overlay.Type != "" {
+		out.Type = overlay.Type
+	}
+	if overlay.Dynamic != nil {
+		out.Dynamic = overlay.Dynamic
+	}
+	if overlay.Enabled != nil {
+		out.Enabled = overlay.Enabled
+	}
+	if overlay.Subobjects != nil {
+		out.Subobjects = overlay.Subobjects
+	}
+	out.Properties = mergeProperties(base.Properties, overlay.Properties)
+	out.DynamicTemplates = mergeDynamicTemplates(base.DynamicTemplates, overlay.DynamicTemplates)
+	return out
+}
+
+// mergeDynamicTemplates replaces templates of the same name in place, and appends others.
+func mergeDynamicTemplates(base, overlay DynamicTemplates) DynamicTemplates {
+	if overlay == nil {
+		return base
+	}
+	out := append(DynamicTemplates{}, base...)
+outer:
+	for _, o := range overlay {
+		for name := range o {
+			for i, b := range out {
+				if _, ok := b[name]; ok {
+					out[i] = o
+					continue outer
+				}
+			}
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+func mergeNested(base, overlay NestedParams) NestedParams {
+	out := base
+	if overlay.Dynamic != nil {
+		out.Dynamic = overlay.Dynamic
+	}
+	if overlay.IncludeInParent != nil {
+		out.IncludeInParent = overlay.IncludeInParent
+	}
+	if overlay.IncludeInRoot != nil {
+		out.IncludeInRoot = overlay.IncludeInRoot
+	}
+	out.Properties = mergeProperties(base.Properties, overlay.Properties)
+	return out
+}
+
+func mergePassthrough(base, overlay PassthroughParams) PassthroughParams {
+	out := base
+	if overlay.Dynamic != nil {
+		out.Dynamic = overlay.Dynamic
+	}
+	if overlay.Priority != nil {
+		out.Priority = overlay.Priority
+	}
+	if overlay.TimeSeriesDimension != nil {
+		out.TimeSeriesDimension = overlay.TimeSeriesDimension
+	}
+	out.Properties = mergeProperties(base.Properties, overlay.Properties)
+	return out
+}
+
+func mergeProperties(base, overlay *Properties) *Properties {
+	if base == nil && overlay == nil {
+		return nil
+	}
+
+	out := Properties{}
+	if base != nil {
+		for k, v := range *base {
+			out[k] = v
+		}
+	}
+	if overlay != nil {
+		for k, v := range *overlay {
+			if b, ok := out[k]; ok {
+				out[k] = mergeProperty(b, v)
+			} else {
+				out[k] = v
+			}
+		}
+	}
+	return &out
+}
+
+func mergeProperty(base, overlay Property) Property {
+	switch b := base.Param.(type) {
+	case *ObjectParams:
+		if o, ok := overlay.Param.(*ObjectParams); ok && isObjectType(b.Type) && isObjectType(o.Type) {
+			merged := mergeObject(*b, *o)
+			return Property{Type: overlay.Type, Param: &merged, Unknown: mergeUnknownParams(base.Unknown, overlay.Unknown)}
+		}
+	case *NestedParams:
+		if o, ok := overlay.Param.(*NestedParams); ok {
+			merged := mergeNested(*b, *o)
+			return Property{Type: overlay.Type, Param: &merged, Unknown: mergeUnknownParams(base.Unknown, overlay.Unknown)}
+		}
+	case *PassthroughParams:
+		if o, ok := overlay.Param.(*PassthroughParams); ok {
+			merged := mergePassthrough(*b, *o)
+			return Property{Type: overlay.Type, Param: &merged, Unknown: mergeUnknownParams(base.Unknown, overlay.Unknown)}
+		}
+	}
+	return overlay
+}
+
+// mergeUnknownParams merges unknown params. Ones in overlay win.
+func mergeSource(base, overlay *SourceField) *SourceField {
+	if base == nil {
+		return overlay
+	}
+	if overlay == nil {
+		return base
+	}
+	out := *base
+	if overlay.Enabled != nil {
+		out.Enabled = overlay.Enabled
+	}
+	if overlay.Includes != nil {
+		out.Includes = overlay.Includes
+	}
+	if overlay.Excludes != nil {
+		out.Excludes = overlay.Excludes
+	}
+	if overlay.Mode != nil {
+		out.Mode = overlay.Mode
+	}
+	return &out
+}
+
+// mergeMeta merges overlay into base recursively. Values in overlay win, except that objects in both are merged.
+func mergeMeta(base, overlay map[string]any) map[string]any {
+	if base == nil && overlay == nil {
+		return nil
+	}
+	out := map[string]any{}
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range overlay {
+		baseObj, ok1 := out[k].(map[string]any)
+		overlayObj, ok2 := v.(map[string]any)
+		if ok1 && ok2 {
+			out[k] = mergeMeta(baseObj, overlayObj)
+		} else {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func mergeUnknownParams(base, overlay map[string]json.RawMessage) map[string]json.RawMessage {
+	if base == nil && overlay == nil {
+		return nil
+	}
+	out := map[string]json.RawMessage{}
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range overlay {
+		out[k] = v
+	}
+	return out
+}
+
+// isObjectType reports whether ty, stored in ObjectParams, is object.
+func isObjectType(ty EsType) bool {
+	return ty == "" || ty == Object
+}
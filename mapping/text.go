@@ -1,6 +1,9 @@
 package mapping
 
 // https://www.elastic.co/guide/en/elasticsearch/reference/8.4/text.html#text-params
+//
+// TextParams is also used for annotated_text, which supports the same params as text.
+// https://www.elastic.co/guide/en/elasticsearch/plugins/8.4/mapper-annotated-text-usage.html
 type TextParams struct {
 	// Type is type of this property. Automatically filled if zero.
 	Type EsType `json:"type,omitempty"`
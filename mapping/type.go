@@ -18,13 +18,16 @@ const (
 	IP                    EsType = "ip"
 	Join                  EsType = "join"
 	Nested                EsType = "nested"
-	Object                EsType = "object" // Default value. nil is object.
+	Object                EsType = "object"      // Default value. nil is object.
+	Passthrough           EsType = "passthrough" // Since 8.13
 	Percolator            EsType = "percolator"
 	Point                 EsType = "point"
 	RankFeature           EsType = "rank_feature"
 	RankFeatures          EsType = "rank_features"
 	SearchAsYouType       EsType = "search_as_you_type"
+	SemanticText          EsType = "semantic_text" // Since 8.15
 	Shape                 EsType = "shape"
+	SparseVector          EsType = "sparse_vector" // Since 8.11
 	TokenCount            EsType = "token_count"
 	Version               EsType = "version"
 )
@@ -33,8 +36,11 @@ const (
 const (
 	Keyword         EsType = "keyword"
 	ConstantKeyword EsType = "constant_keyword"
+	CountedKeyword  EsType = "counted_keyword" // Since 8.12
 	Wildcard        EsType = "wildcard"
 	Text            EsType = "text"
+	MatchOnlyText   EsType = "match_only_text"
+	AnnotatedText   EsType = "annotated_text" // Provided by the mapper-annotated-text plugin.
 )
 
 // Numerics field types
@@ -0,0 +1,174 @@
+package mapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// UnknownParams is params for a type which is neither built into this package nor registered by RegisterType,
+// e.g. types added by plugins or by newer Elasticsearch versions, or typos.
+//
+// It keeps the raw JSON of the property, so that the mapping round-trips losslessly.
+type UnknownParams struct {
+	// Type is type of this property.
+	Type EsType
+	// Raw is the JSON object of the property, including the type key.
+	Raw json.RawMessage
+}
+
+func (p UnknownParams) MarshalJSON() ([]byte, error) {
+	if p.Raw == nil {
+		return json.Marshal(map[string]EsType{"type": p.Type})
+	}
+	return p.Raw, nil
+}
+
+func (p *UnknownParams) UnmarshalJSON(data []byte) error {
+	var ty struct {
+		Type EsType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &ty); err != nil {
+		return err
+	}
+	p.Type = ty.Type
+	p.Raw = append(json.RawMessage{}, data...)
+	return nil
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[EsType]func() any{}
+)
+
+// RegisterType registers a param type for ty, a type which is not built into this package.
+// newParam must return a new pointer to a value which json can be unmarshalled into, e.g. &MyPluginParams{}.
+// Properties of ty are unmarshalled into newParam() instead of UnknownParams.
+//
+// RegisterType panics if ty is a built-in type, is already registered, or newParam is nil.
+func RegisterType(ty EsType, newParam func() any) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if newParam == nil {
+		panic("mapping: RegisterType newParam is nil")
+	}
+	if IsBuiltinType(ty) {
+		panic(fmt.Sprintf("mapping: RegisterType called for built-in type %q", ty))
+	}
+	if _, ok := registry[ty]; ok {
+		panic(fmt.Sprintf("mapping: RegisterType called twice for type %q", ty))
+	}
+	registry[ty] = newParam
+}
+
+// IsRegisteredType reports whether ty is registered by RegisterType.
+func IsRegisteredType(ty EsType) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	_, ok := registry[ty]
+	return ok
+}
+
+func newRegisteredParam(ty EsType) (any, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	newParam, ok := registry[ty]
+	if !ok {
+		return nil, false
+	}
+	return newParam(), true
+}
+
+// unknownKeys returns keys of the JSON object data which are not fields of param, or nil if none.
+// UnknownParams keeps everything by itself, so it always returns nil for it.
+func unknownKeys(data []byte, param any) (map[string]json.RawMessage, error) {
+	if _, ok := param.(*UnknownParams); ok {
+		return nil, nil
+	}
+	rt := reflect.TypeOf(param)
+	for rt != nil && rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	if rt == nil || rt.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	known := jsonKeysOf(rt)
+	var unknown map[string]json.RawMessage
+	for k, v := range raw {
+		if known[k] {
+			continue
+		}
+		if unknown == nil {
+			unknown = map[string]json.RawMessage{}
+		}
+		unknown[k] = v
+	}
+	return unknown, nil
+}
+
+var jsonKeysCache sync.Map // map[reflect.Type]map[string]bool
+
+// jsonKeysOf returns JSON keys of fields of struct type rt, including ones of embedded structs.
+func jsonKeysOf(rt reflect.Type) map[string]bool {
+	if cached, ok := jsonKeysCache.Load(rt); ok {
+		return cached.(map[string]bool)
+	}
+	keys := map[string]bool{}
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" && f.Anonymous {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k := range jsonKeysOf(ft) {
+					keys[k] = true
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		keys[name] = true
+	}
+	jsonKeysCache.Store(rt, keys)
+	return keys
+}
+
+// mergeUnknown adds unknown to the JSON object bin. Keys already in bin take precedence.
+func mergeUnknown(bin []byte, unknown map[string]json.RawMessage) ([]byte, error) {
+	if len(unknown) == 0 {
+		return bin, nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(bin, &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		m = map[string]json.RawMessage{}
+	}
+	for k, v := range unknown {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+	return json.Marshal(m)
+}
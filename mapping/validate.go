@@ -0,0 +1,462 @@
+package mapping
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type ValidationRule string
+
+const (
+	// RuleFieldName: field names must not be empty.
+	RuleFieldName ValidationRule = "field_name"
+	// RuleUnknownType: type must be one of built-in types or types registered by RegisterType.
+	RuleUnknownType ValidationRule = "unknown_type"
+	// RuleDynamic: dynamic must be one of true, false, "true", "false", "runtime" or "strict".
+	RuleDynamic ValidationRule = "dynamic"
+	// RuleDenseVectorDims: dims of dense_vector must be 1 to 2048, and is required.
+	// If the target version is 8.11 or later, it can be omitted and must be 1 to 4096.
+	// For bit elements, dims must be a multiple of 8.
+	RuleDenseVectorDims ValidationRule = "dense_vector_dims"
+	// RuleScalingFactor: scaling_factor of scaled_float is required and must be positive.
+	RuleScalingFactor ValidationRule = "scaling_factor"
+	// RuleAliasPath: path of alias is required. The target must exist, must not be object-like or another alias,
+	// and must be in the same nested scope as the alias.
+	RuleAliasPath ValidationRule = "alias_path"
+	// RuleJoinRelations: relations of join must be non-empty and acyclic. Each child can only have one parent.
+	RuleJoinRelations ValidationRule = "join_relations"
+	// RuleJoinUnique: only one join field is allowed per mapping.
+	RuleJoinUnique ValidationRule = "join_unique"
+	// RuleDynamicTemplates: dynamic_templates is only allowed at the mapping root,
+	// each element must have exactly one key, each mapping must be a valid property,
+	// and patterns must be valid regular expressions if match_pattern is regex.
+	RuleDynamicTemplates ValidationRule = "dynamic_templates"
+	// RuleRuntime: runtime fields must have a known type.
+	// format is only allowed for date, composite must have script and fields,
+	// and lookup must have target_index, input_field and target_field.
+	RuleRuntime ValidationRule = "runtime"
+	// RuleSource: mode of _source must be one of "stored", "synthetic" or "disabled".
+	RuleSource ValidationRule = "source"
+	// RuleMaxShingleSize: max_shingle_size of search_as_you_type must be 2 to 4.
+	RuleMaxShingleSize ValidationRule = "max_shingle_size"
+	// RuleIndexPrefixes: min_chars of index_prefixes must be greater than 0,
+	// max_chars must be less than 20, and min_chars must not be greater than max_chars.
+	RuleIndexPrefixes ValidationRule = "index_prefixes"
+	// RuleParam: params set by Builder must be applicable to the type. Only reported by Builder.
+	RuleParam ValidationRule = "param"
+	// RuleVersion: types and params must be available in the target version. Only checked by ValidateFor.
+	RuleVersion ValidationRule = "version"
+	// RuleSubobjects: objects with subobjects: false can only have leaf fields, not object, nested or passthrough.
+	RuleSubobjects ValidationRule = "subobjects"
+	// RuleAggregateMetricDouble: metrics of aggregate_metric_double must be non-empty,
+	// and default_metric must be one of metrics.
+	RuleAggregateMetricDouble ValidationRule = "aggregate_metric_double"
+	// RuleCompletionContexts: contexts of completion must have unique non-empty names and type of category or geo.
+	// precision is only allowed for geo contexts.
+	RuleCompletionContexts ValidationRule = "completion_contexts"
+)
+
+// ValidationError is a problem in a mapping found by Validate.
+type ValidationError struct {
+	// Path is the dotted path to the field. Empty for the mapping root.
+	Path    string
+	Rule    ValidationRule
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "<root>"
+	}
+	return fmt.Sprintf("%s: %s: %s", path, e.Rule, e.Message)
+}
+
+// ValidationErrors is every problem found by Validate.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("mapping has %d problem(s):\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+// Validate walks the mapping, including runtime fields,
+// and returns every problem it finds as ValidationErrors.
+// It returns nil if none found.
+func (m Mappings) Validate() error {
+	return m.validate(&validator{})
+}
+
+// ValidateFor is same as Validate, but also checks that types and params used in the mapping
+// are available in the target version. They are reported as RuleVersion.
+func (m Mappings) ValidateFor(target StackVersion) error {
+	return m.validate(&validator{target: &target})
+}
+
+func (m Mappings) validate(v *validator) error {
+	v.dynamicTemplates(m.DynamicTemplates)
+	v.object("", m.Dynamic, m.Properties)
+	v.subobjects("", m.Subobjects, m.Properties)
+	v.aliasTargets(m.Properties)
+	for _, name := range sortedKeys(m.Runtime) {
+		v.runtime(name, m.Runtime[name])
+	}
+	if m.Source != nil && m.Source.Mode != nil {
+		switch *m.Source.Mode {
+		case SourceStored, SourceSynthetic, SourceDisabled:
+		default:
+			v.add("_source", RuleSource, "unknown mode %q", *m.Source.Mode)
+		}
+	}
+	return v.result()
+}
+
+// Validate walks the mapping and returns every problem it finds as ValidationErrors.
+// It returns nil if none found.
+func (p ObjectParams) Validate() error {
+	v := &validator{}
+	v.dynamicTemplates(p.DynamicTemplates)
+	v.object("", p.Dynamic, p.Properties)
+	v.subobjects("", p.Subobjects, p.Properties)
+	v.aliasTargets(p.Properties)
+	return v.result()
+}
+
+// Validate walks p and returns every problem it finds as ValidationErrors.
+// It returns nil if none found.
+//
+// p is treated as the mapping root.
+func (p Properties) Validate() error {
+	v := &validator{}
+	v.properties("", p)
+	v.aliasTargets(&p)
+	return v.result()
+}
+
+type validator struct {
+	errs      ValidationErrors
+	joinPaths []string
+	// aliases maps paths of alias fields to their targets.
+	aliases map[string]string
+	// target is nil if versions are not checked.
+	target *StackVersion
+}
+
+func (v *validator) result() error {
+	if len(v.joinPaths) > 1 {
+		v.add("", RuleJoinUnique, "multiple join fields: %s", strings.Join(v.joinPaths, ", "))
+	}
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *validator) subobjects(path string, subobjects *bool, props *Properties) {
+	if subobjects == nil || *subobjects || props == nil {
+		return
+	}
+	for _, name := range sortedKeys(*props) {
+		if prop := (*props)[name]; prop.IsObjectLike() {
+			v.add(joinPath(path, name), RuleSubobjects, "%s is not allowed in an object with subobjects: false", typeOf(prop))
+		}
+	}
+}
+
+// aliasTargets checks targets of alias fields found so far against the mapping root.
+func (v *validator) aliasTargets(root *Properties) {
+	if len(v.aliases) == 0 {
+		return
+	}
+	var flat FlatProperties
+	if root != nil {
+		flat = root.Flatten()
+	}
+	for _, path := range sortedKeys(v.aliases) {
+		targetPath := v.aliases[path]
+		target, ok := flat[targetPath]
+		switch {
+		case !ok:
+			v.add(path, RuleAliasPath, "target %q does not exist", targetPath)
+		case target.Kind == WalkObject || target.Kind == WalkNested || target.Kind == WalkPassthrough:
+			v.add(path, RuleAliasPath, "target %q is %s, but must be a concrete field", targetPath, target.Kind)
+		case target.Type == Alias:
+			v.add(path, RuleAliasPath, "target %q is an alias", targetPath)
+		case target.NestedPath != flat[path].NestedPath:
+			v.add(path, RuleAliasPath, "target %q is in nested scope %q, but alias is in %q",
+				targetPath, target.NestedPath, flat[path].NestedPath)
+		}
+	}
+}
+
+func (v *validator) add(path string, rule ValidationRule, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{
+		Path:    path,
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) object(path string, dynamic Dynamic, props *Properties) {
+	if !IsValidDynamic(dynamic) {
+		v.add(path, RuleDynamic, "unknown value %s", string(dynamic))
+	}
+	if props != nil {
+		v.properties(path, *props)
+	}
+}
+
+func (v *validator) properties(path string, props map[string]Property) {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fieldPath := joinPath(path, name)
+		if name == "" {
+			v.add(fieldPath, RuleFieldName, "empty field name")
+		}
+		v.property(fieldPath, props[name])
+	}
+}
+
+func (v *validator) property(path string, prop Property) {
+	if v.target != nil && !prop.Type.AvailableIn(*v.target) {
+		addedIn, _ := prop.Type.AddedIn()
+		v.add(path, RuleVersion, "type %q is added in %s, but target is %s", prop.Type, addedIn, *v.target)
+	}
+
+	switch param := prop.Param.(type) {
+	case *ObjectParams:
+		if param.Type != "" && param.Type != Object {
+			v.add(path, RuleUnknownType, "unknown type %q", param.Type)
+			return
+		}
+		if param.DynamicTemplates != nil {
+			v.add(path, RuleDynamicTemplates, "dynamic_templates is only allowed at the mapping root")
+		}
+		v.object(path, param.Dynamic, param.Properties)
+		v.subobjects(path, param.Subobjects, param.Properties)
+	case *NestedParams:
+		v.object(path, param.Dynamic, param.Properties)
+	case *PassthroughParams:
+		v.object(path, param.Dynamic, param.Properties)
+	case *UnknownParams:
+		v.add(path, RuleUnknownType, "unknown type %q", param.Type)
+	case *DenseVectorParams:
+		target := DefaultStackVersion
+		if v.target != nil {
+			target = *v.target
+		}
+		maxDims, required := maxDenseVectorDims(target)
+		switch {
+		case param.Dims == 0:
+			if required {
+				v.add(path, RuleDenseVectorDims, "dims is required before %s", optionalDimsSince)
+			}
+		case param.Dims < 1 || param.Dims > maxDims:
+			v.add(path, RuleDenseVectorDims, "dims must be 1 to %d but is %d", maxDims, param.Dims)
+		case param.ElementType != nil && *param.ElementType == ElementBit && param.Dims%8 != 0:
+			v.add(path, RuleDenseVectorDims, "dims of bit elements must be a multiple of 8 but is %d", param.Dims)
+		}
+		if v.target != nil && param.ElementType != nil {
+			if addedIn, ok := param.ElementType.AddedIn(); ok && v.target.Before(addedIn) {
+				v.add(path, RuleVersion, "element_type %q is added in %s, but target is %s", *param.ElementType, addedIn, *v.target)
+			}
+		}
+	case *GeoshapeParams:
+		if v.target != nil && v.target.Before(geoShapeIndexSince) {
+			if param.Index != nil {
+				v.add(path, RuleVersion, "index of geo_shape is added in %s, but target is %s", geoShapeIndexSince, *v.target)
+			}
+			if param.DocValues != nil {
+				v.add(path, RuleVersion, "doc_values of geo_shape is added in %s, but target is %s", geoShapeIndexSince, *v.target)
+			}
+		}
+	case *ScaledFloatParams:
+		if param.ScalingFactor <= 0 {
+			v.add(path, RuleScalingFactor, "scaling_factor must be positive but is %v", param.ScalingFactor)
+		}
+	case *AliasParams:
+		if param.Path == "" {
+			v.add(path, RuleAliasPath, "empty path")
+		} else {
+			if v.aliases == nil {
+				v.aliases = map[string]string{}
+			}
+			v.aliases[path] = param.Path
+		}
+	case *JoinParams:
+		v.joinPaths = append(v.joinPaths, path)
+		v.joinRelations(path, param.Relations)
+	case *SearchAsYouTypeParams:
+		if param.MaxShingleSize != nil && (*param.MaxShingleSize < 2 || *param.MaxShingleSize > 4) {
+			v.add(path, RuleMaxShingleSize, "max_shingle_size must be 2 to 4 but is %d", *param.MaxShingleSize)
+		}
+	case *TextParams:
+		if p := param.IndexPrefixes; p != nil {
+			minChars, maxChars := p.MinChars, p.MaxChars
+			if minChars == 0 {
+				minChars = 2
+			}
+			if maxChars == 0 {
+				maxChars = 5
+			}
+			if maxChars >= 20 || minChars > maxChars {
+				v.add(
+					path, RuleIndexPrefixes,
+					"min_chars must be greater than 0 and not greater than max_chars, max_chars must be less than 20, but are %d and %d",
+					minChars, maxChars,
+				)
+			}
+		}
+	case *AggregateMetricDoubleParams:
+		if len(param.Metrics) == 0 {
+			v.add(path, RuleAggregateMetricDouble, "empty metrics")
+		} else {
+			var found bool
+			for _, m := range param.Metrics {
+				if m == param.DefaultMetric {
+					found = true
+				}
+			}
+			if !found {
+				v.add(path, RuleAggregateMetricDouble, "default_metric %q is not one of metrics %v", param.DefaultMetric, param.Metrics)
+			}
+		}
+	case *CompletionParams:
+		v.completionContexts(path, param.Contexts)
+	}
+
+	if fields := prop.MultiFields(); fields != nil {
+		v.properties(path, fields)
+	}
+}
+
+func (v *validator) runtime(path string, field RuntimeField) {
+	switch field.Type {
+	case RuntimeBoolean, RuntimeDate, RuntimeDouble, RuntimeGeopoint, RuntimeIP, RuntimeKeyword, RuntimeLong:
+	case RuntimeComposite:
+		if field.Script == nil || len(field.Fields) == 0 {
+			v.add(path, RuleRuntime, "composite must have script and fields")
+		}
+		for _, name := range sortedKeys(field.Fields) {
+			if field.Fields[name].Type == RuntimeComposite || field.Fields[name].Type == RuntimeLookup {
+				v.add(joinPath(path, name), RuleRuntime, "unsupported type %q for a sub-field of composite", field.Fields[name].Type)
+			}
+		}
+	case RuntimeLookup:
+		if field.TargetIndex == nil || field.InputField == nil || field.TargetField == nil {
+			v.add(path, RuleRuntime, "lookup must have target_index, input_field and target_field")
+		}
+	default:
+		v.add(path, RuleRuntime, "unknown type %q", field.Type)
+	}
+	if field.Format != nil && field.Type != RuntimeDate {
+		v.add(path, RuleRuntime, "format is only allowed for date")
+	}
+}
+
+func (v *validator) dynamicTemplates(t DynamicTemplates) {
+	templates, err := t.Templates()
+	if err != nil {
+		v.add("", RuleDynamicTemplates, "%s", err)
+		return
+	}
+	for _, template := range templates {
+		if _, _, err := template.Template.Property(); err != nil {
+			v.add("", RuleDynamicTemplates, "%s: invalid mapping: %s", template.Name, err)
+		}
+		if err := template.Template.ValidatePatterns(); err != nil {
+			v.add("", RuleDynamicTemplates, "%s: %s", template.Name, err)
+		}
+	}
+}
+
+func (v *validator) completionContexts(path string, contexts []CompletionContext) {
+	names := map[string]bool{}
+	for i, ctx := range contexts {
+		if ctx.Name == "" {
+			v.add(path, RuleCompletionContexts, "contexts[%d] has empty name", i)
+		} else if names[ctx.Name] {
+			v.add(path, RuleCompletionContexts, "duplicate context name %q", ctx.Name)
+		}
+		names[ctx.Name] = true
+
+		switch ctx.Type {
+		case CategoryContext:
+			if ctx.Precision != nil {
+				v.add(path, RuleCompletionContexts, "precision is only allowed for geo contexts, but %q is category", ctx.Name)
+			}
+		case GeoContext:
+		default:
+			v.add(path, RuleCompletionContexts, "type of context %q must be category or geo, but is %q", ctx.Name, ctx.Type)
+		}
+	}
+}
+
+func (v *validator) joinRelations(path string, relations map[string]any) {
+	if len(relations) == 0 {
+		v.add(path, RuleJoinRelations, "empty relations")
+		return
+	}
+
+	parentOf := map[string]string{}
+	for _, parent := range sortedKeys(relations) {
+		children, err := JoinChildren(relations[parent])
+		if err != nil {
+			v.add(path, RuleJoinRelations, "relation of %q: %s", parent, err)
+		}
+		for _, child := range children {
+			if other, ok := parentOf[child]; ok && other != parent {
+				v.add(path, RuleJoinRelations, "%q has multiple parents: %q and %q", child, other, parent)
+				continue
+			}
+			parentOf[child] = parent
+		}
+	}
+
+	reported := map[string]bool{}
+	for _, start := range sortedKeys(relations) {
+		visited := map[string]bool{}
+		for cur := start; ; {
+			if visited[cur] {
+				if !reported[cur] {
+					v.add(path, RuleJoinRelations, "cyclic relation involving %q", cur)
+					for name := range visited {
+						reported[name] = true
+					}
+				}
+				break
+			}
+			visited[cur] = true
+			parent, ok := parentOf[cur]
+			if !ok {
+				break
+			}
+			cur = parent
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,188 @@
+package mapping
+
+import (
+	"errors"
+	"strings"
+)
+
+// WalkKind is the kind of a property visited by Walk.
+type WalkKind int
+
+const (
+	// WalkField is a leaf field, which has no sub properties.
+	WalkField WalkKind = iota
+	// WalkObject is an object field. Its properties are visited after it.
+	WalkObject
+	// WalkNested is a nested field. Its properties are indexed as separate hidden documents.
+	WalkNested
+	// WalkPassthrough is a passthrough field. Its properties are also accessible without its name as a prefix.
+	WalkPassthrough
+	// WalkMultiField is a multi-field defined in the fields parameter of its parent.
+	WalkMultiField
+)
+
+func (k WalkKind) String() string {
+	switch k {
+	case WalkField:
+		return "field"
+	case WalkObject:
+		return "object"
+	case WalkNested:
+		return "nested"
+	case WalkPassthrough:
+		return "passthrough"
+	case WalkMultiField:
+		return "multi_field"
+	}
+	return "unknown"
+}
+
+var (
+	// SkipSubtree is used as a return value from WalkFunc to skip properties and multi-fields of the visited property.
+	SkipSubtree = errors.New("skip subtree")
+	// SkipAll is used as a return value from WalkFunc to stop walking. Walk returns nil in that case.
+	SkipAll = errors.New("skip all")
+)
+
+// WalkFunc is called for each property visited by Walk.
+// path is field names from the root to the property. Multi-fields are appended to their parent's path.
+// fn may retain path; it is not modified after the call.
+//
+// If fn returns SkipSubtree, sub properties and multi-fields of prop are skipped.
+// If fn returns SkipAll, Walk stops and returns nil.
+// Walk stops and returns any other non-nil error.
+type WalkFunc func(path []string, prop Property, kind WalkKind) error
+
+// Walk visits props recursively in depth-first order, calling fn for each property.
+// Properties of object, nested and passthrough fields, and multi-fields, are visited after the field itself.
+// Properties of the same level are visited in lexical order of their names.
+func Walk(props Properties, fn WalkFunc) error {
+	err := walk(nil, props, fn)
+	if err == SkipAll {
+		return nil
+	}
+	return err
+}
+
+func walk(parent []string, props map[string]Property, fn WalkFunc) error {
+	for _, name := range sortedKeys(props) {
+		prop := props[name]
+		path := append(parent[:len(parent):len(parent)], name)
+
+		kind, sub := walkKindOf(prop)
+		if err := fn(path, prop, kind); err != nil {
+			if err == SkipSubtree {
+				continue
+			}
+			return err
+		}
+
+		if sub != nil {
+			if err := walk(path, *sub, fn); err != nil {
+				return err
+			}
+		}
+		if err := walkMultiFields(path, prop.MultiFields(), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkMultiFields(parent []string, fields Fields, fn WalkFunc) error {
+	for _, name := range sortedKeys(fields) {
+		path := append(parent[:len(parent):len(parent)], name)
+		if err := fn(path, fields[name], WalkMultiField); err != nil && err != SkipSubtree {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkKindOf returns kind of prop and its sub properties if any.
+func walkKindOf(prop Property) (WalkKind, *Properties) {
+	switch param := prop.Param.(type) {
+	case *ObjectParams:
+		return WalkObject, param.Properties
+	case *NestedParams:
+		return WalkNested, param.Properties
+	case *PassthroughParams:
+		return WalkPassthrough, param.Properties
+	}
+	return WalkField, nil
+}
+
+// FlatProperty is a property in FlatProperties.
+type FlatProperty struct {
+	Property
+	Kind WalkKind
+	// NestedPath is the dotted path of the closest nested field enclosing the property, or empty if none.
+	// A nested field itself is not enclosed by its own path.
+	// Queries on the property must be wrapped in nested queries of NestedPath.
+	NestedPath string
+}
+
+// FlatProperties maps dotted paths to properties, including multi-fields.
+type FlatProperties map[string]FlatProperty
+
+// Flatten returns all properties in p, including ones of object, nested and passthrough fields, and multi-fields,
+// keyed by their dotted paths, e.g. "user.name.keyword".
+func (p Properties) Flatten() FlatProperties {
+	out := FlatProperties{}
+	// nested paths of the current branch.
+	var nestedPaths []string
+	_ = Walk(p, func(path []string, prop Property, kind WalkKind) error {
+		dotted := strings.Join(path, ".")
+		for len(nestedPaths) > 0 && !strings.HasPrefix(dotted, nestedPaths[len(nestedPaths)-1]+".") {
+			nestedPaths = nestedPaths[:len(nestedPaths)-1]
+		}
+
+		flat := FlatProperty{Property: prop, Kind: kind}
+		if len(nestedPaths) > 0 {
+			flat.NestedPath = nestedPaths[len(nestedPaths)-1]
+		}
+		out[dotted] = flat
+
+		if kind == WalkNested {
+			nestedPaths = append(nestedPaths, dotted)
+		}
+		return nil
+	})
+	return out
+}
+
+// Lookup returns the property at the dotted path, e.g. "user.name.keyword".
+// The path may go through object, nested and passthrough fields, and may end with a multi-field.
+// Field names containing dots are also matched.
+func (p Properties) Lookup(path string) (Property, bool) {
+	if path == "" {
+		return Property{}, false
+	}
+	return lookup(p, strings.Split(path, "."), false)
+}
+
+func lookup(props map[string]Property, segments []string, multiField bool) (Property, bool) {
+	// Longer names are tried first, so that names containing dots take precedence.
+	for i := len(segments); i > 0; i-- {
+		prop, ok := props[strings.Join(segments[:i], ".")]
+		if !ok {
+			continue
+		}
+		rest := segments[i:]
+		if len(rest) == 0 {
+			return prop, true
+		}
+		if multiField {
+			continue
+		}
+		if _, sub := walkKindOf(prop); sub != nil {
+			if found, ok := lookup(*sub, rest, false); ok {
+				return found, true
+			}
+		}
+		if found, ok := lookup(prop.MultiFields(), rest, true); ok {
+			return found, true
+		}
+	}
+	return Property{}, false
+}
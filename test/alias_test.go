@@ -0,0 +1,85 @@
+package test_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	esquery "github.com/ngicks/elastic-type/es_query"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlias(t *testing.T) {
+	require := require.New(t)
+
+	bin := must(os.ReadFile("./example/alias.json"))
+	var settings mapping.MappingSettings
+	require.NoError(json.Unmarshal(bin, &settings))
+	require.NoError(settings["alias_doc"].Mappings.Validate())
+
+	// Aliases are not in _source, so not in document types.
+	var doc example.AliasDocRaw
+	require.NoError(json.Unmarshal([]byte(`{"name":"foo","title":"ignored"}`), &doc))
+	require.Equal(example.AliasDoc{Name: &[]string{"foo"}}, doc.ToPlain())
+
+	// Helpers of aliases are typed as their targets.
+	q := example.NewAliasDocQuery("")
+	var _ esquery.TextField[string] = q.Title
+	var _ esquery.KeywordField[string] = q.TitleRaw
+	var _ esquery.RangeField[example.AliasDocCreatedAt] = q.Published
+	var _ esquery.RangeField[int32] = q.Score
+	var _ esquery.TextField[string] = q.Comments.Text
+
+	require.Equal("published", example.AliasDocFields.Published.Path())
+	require.Equal(mapping.Date, example.AliasDocFields.Published.EsType())
+	require.Equal("comments.text", example.AliasDocFields.Comments.Text.Path())
+	require.Equal(mapping.Text, example.AliasDocFields.Comments.Text.EsType())
+}
+
+func TestAliasInvalidTarget(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		target  string
+		message string
+	}{
+		{"nope", `target "nope" does not exist`},
+		{"obj", `target "obj" is object, but must be a concrete field`},
+		{"items", `target "items" is nested, but must be a concrete field`},
+		{"other", `target "other" is an alias`},
+		{"items.name", `target "items.name" is in nested scope "items", but alias is in ""`},
+	} {
+		var m mapping.Mappings
+		require.NoError(json.Unmarshal([]byte(`{
+			"properties": {
+				"obj": {"properties": {"name": {"type": "keyword"}}},
+				"items": {"type": "nested", "properties": {"name": {"type": "keyword"}}},
+				"other": {"type": "alias", "path": "obj.name"},
+				"a": {"type": "alias", "path": "`+tc.target+`"}
+			}
+		}`), &m))
+
+		var validationErrs mapping.ValidationErrors
+		require.ErrorAs(m.Validate(), &validationErrs, tc.target)
+		require.Len(validationErrs, 1, tc.target)
+		require.Equal(&mapping.ValidationError{
+			Path:    "a",
+			Rule:    mapping.RuleAliasPath,
+			Message: tc.message,
+		}, validationErrs[0])
+
+		_, _, _, err := generate.Generate(m, "alias_index", generate.GlobalOption{}, nil)
+		if tc.target == "items.name" {
+			// nested scope is only checked by Validate.
+			require.NoError(err)
+		} else {
+			require.ErrorIs(err, generate.ErrAliasTarget, tc.target)
+		}
+	}
+
+	_, err := mapping.New().Keyword("name").Alias("a", "no_such_field").Build()
+	require.ErrorContains(err, `target "no_such_field" does not exist`)
+}
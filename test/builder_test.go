@@ -0,0 +1,117 @@
+package test_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	require := require.New(t)
+
+	m, err := mapping.New(mapping.DynamicMode(mapping.Strict)).
+		Keyword("name", mapping.IgnoreAbove(256)).
+		Text("bio", mapping.Analyzer("english"), mapping.MultiFields(func(f *mapping.Builder) {
+			f.Keyword("raw")
+		})).
+		Date("created_at", mapping.Format("strict_date_optional_time||epoch_millis")).
+		ScaledFloat("price", 100).
+		DenseVector("vec", 3, mapping.Index(true), mapping.Similarity("cosine")).
+		Object("addr", func(o *mapping.Builder) {
+			o.Text("street").Keyword("zip")
+		}).
+		Nested("items", func(n *mapping.Builder) {
+			n.Long("count", mapping.Coerce(false))
+		}, mapping.DynamicMode(mapping.FalseBool)).
+		Field("relation", mapping.Join, mapping.Relation("question", "answer")).
+		Alias("full_name", "name").
+		Build()
+	require.NoError(err)
+	require.NoError(m.Validate())
+
+	bin, err := json.Marshal(mapping.IndexSettings{Mappings: &m})
+	require.NoError(err)
+	require.JSONEq(`{
+		"mappings": {
+			"dynamic": "strict",
+			"properties": {
+				"addr": {
+					"properties": {
+						"street": { "type": "text" },
+						"zip": { "type": "keyword" }
+					}
+				},
+				"bio": {
+					"type": "text",
+					"analyzer": "english",
+					"fields": { "raw": { "type": "keyword" } }
+				},
+				"created_at": { "type": "date", "format": "strict_date_optional_time||epoch_millis" },
+				"full_name": { "type": "alias", "path": "name" },
+				"items": {
+					"type": "nested",
+					"dynamic": false,
+					"properties": {
+						"count": { "type": "long", "coerce": false }
+					}
+				},
+				"name": { "type": "keyword", "ignore_above": 256 },
+				"price": { "type": "scaled_float", "scaling_factor": 100 },
+				"relation": { "type": "join", "relations": { "question": "answer" } },
+				"vec": { "type": "dense_vector", "dims": 3, "index": true, "similarity": "cosine" }
+			}
+		}
+	}`, string(bin))
+
+	// Round trip.
+	var decoded mapping.Mappings
+	require.NoError(json.Unmarshal(must(json.Marshal(m)), &decoded))
+	require.Equal(toAnyMap(m), toAnyMap(decoded))
+
+	_, err = mapping.New().
+		Keyword("dup").
+		Keyword("dup").
+		Boolean("flag", mapping.IgnoreAbove(10)).
+		Field("what", "no_such_type").
+		DenseVector("vec", 0).
+		Object("obj", func(o *mapping.Builder) {
+			o.Text("t", mapping.MultiFields(func(f *mapping.Builder) {
+				f.Keyword("k", mapping.Analyzer("standard"))
+			}))
+		}).
+		Build()
+
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(err, &validationErrs)
+	type pathRule struct {
+		Path string
+		Rule mapping.ValidationRule
+	}
+	var found []pathRule
+	for _, e := range validationErrs {
+		found = append(found, pathRule{e.Path, e.Rule})
+	}
+	require.Equal(
+		[]pathRule{
+			{"dup", mapping.RuleFieldName},
+			{"flag", mapping.RuleParam},
+			{"what", mapping.RuleUnknownType},
+			{"vec", mapping.RuleDenseVectorDims},
+			{"obj.t.k", mapping.RuleParam},
+		},
+		found,
+	)
+	require.Contains(validationErrs[1].Message, "ignore_above is not a param of boolean")
+
+	// Values are not converted, e.g. truncated from float to int.
+	m, err = mapping.New().
+		Field("plugin", "test_plugin", mapping.Analyzer("foo"), mapping.ScalingFactor(1.5)).
+		Build()
+	require.ErrorAs(err, &validationErrs)
+	require.Len(validationErrs, 1)
+	require.Equal(mapping.RuleParam, validationErrs[0].Rule)
+	require.Contains(validationErrs[0].Message, "scaling_factor of test_plugin must be int, but is float64")
+	require.Equal("foo", (*m.Properties)["plugin"].Param.(*testPluginParams).Analyzer)
+}
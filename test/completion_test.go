@@ -0,0 +1,88 @@
+package test_test
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletion(t *testing.T) {
+	require := require.New(t)
+
+	input := `{
+		"title": ["Nevermind", {"input": ["Nirvana"], "weight": 34}],
+		"place": {
+			"input": "timmy's",
+			"weight": 10,
+			"contexts": {"place_type": "cafe", "location": "43.662,-79.380"}
+		}
+	}`
+
+	var raw example.SuggestDocRaw
+	require.NoError(json.Unmarshal([]byte(input), &raw))
+	doc := raw.ToPlain()
+
+	titles := *doc.Title
+	require.Len(titles, 2)
+	require.Equal([]string{"Nirvana"}, titles[1].Input)
+	require.Equal(int32(34), *titles[1].Weight)
+
+	place := (*doc.Place)[0]
+	require.Equal(int32(10), *place.Weight)
+	require.Equal([]string{"cafe"}, must(place.Contexts["place_type"].Categories()))
+
+	// weights and contexts survive ToRaw / ToPlain round trip.
+	bin, err := json.Marshal(doc.ToRaw())
+	require.NoError(err)
+	var raw2 example.SuggestDocRaw
+	require.NoError(json.Unmarshal(bin, &raw2))
+	require.Equal(doc, raw2.ToPlain())
+
+	// undeclared contexts are rejected.
+	err = json.Unmarshal([]byte(`{"place": {"input": "a", "contexts": {"genre": "rock"}}}`), &raw)
+	require.ErrorIs(err, estype.ErrUnknownContext)
+	_, err = json.Marshal(example.SuggestDocPlace{
+		Input:    []string{"a"},
+		Contexts: map[string]estype.CompletionContextValues{"genre": estype.CategoryContext("rock")},
+	})
+	require.ErrorIs(err, estype.ErrUnknownContext)
+}
+
+func TestCompletionContextsValidation(t *testing.T) {
+	require := require.New(t)
+
+	_, err := mapping.New().
+		Field("suggest", mapping.Completion, mapping.Contexts(
+			mapping.CompletionContext{Name: "place_type", Type: mapping.CategoryContext, Precision: 4},
+			mapping.CompletionContext{Name: "place_type", Type: mapping.GeoContext},
+			mapping.CompletionContext{Name: "genre", Type: "color"},
+		)).
+		Build()
+	var errs mapping.ValidationErrors
+	require.ErrorAs(err, &errs)
+	require.Len(errs, 3)
+	for _, e := range errs {
+		require.Equal(mapping.RuleCompletionContexts, e.Rule)
+	}
+}
+
+func TestCompletionFromParam(t *testing.T) {
+	require := require.New(t)
+
+	// context names are quoted as Go string literals.
+	gen := generate.CompletionFromParam(mapping.CompletionParams{Contexts: []mapping.CompletionContext{
+		{Name: `place "type"`, Type: mapping.CategoryContext},
+		{Name: `loc\ation`, Type: mapping.GeoContext},
+	}}, "docSuggest")
+	require.Contains(gen.TyDef, `"place \"type\"": "category",`)
+	require.Contains(gen.TyDef, `"loc\\ation": "geo",`)
+	_, err := parser.ParseFile(token.NewFileSet(), "", "package x\n"+gen.TyDef, 0)
+	require.NoError(err)
+}
@@ -0,0 +1,71 @@
+package test_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenseVector(t *testing.T) {
+	require := require.New(t)
+
+	var raw example.VectorDocRaw
+	require.NoError(json.Unmarshal([]byte(`{
+		"unit": [0.6, 0.8],
+		"bits": "0f00",
+		"unindexed": [3, 4]
+	}`), &raw))
+	doc := raw.ToPlain()
+
+	unit := (*doc.Unit)[0]
+	score, err := unit.Score(example.VectorDocUnit{1, 0})
+	require.NoError(err)
+	require.InDelta(0.8, score, 1e-6)
+
+	bits := (*doc.Bits)[0]
+	require.Equal(example.VectorDocBits{0x0f, 0}, bits)
+	score, err = bits.Score(example.VectorDocBits{0, 0})
+	require.NoError(err)
+	require.InDelta(0.75, score, 1e-6)
+
+	// not indexed, so not checked for the similarity. Scored by cosine, the default.
+	score, err = (*doc.Unindexed)[0].Score(example.VectorDocUnindexed{1, 0})
+	require.NoError(err)
+	require.InDelta(0.8, score, 1e-6)
+
+	// dims and normalization are checked on both decode and encode.
+	for _, invalid := range []string{
+		`{"unit": [0.6, 0.8, 0]}`,
+		`{"unit": [3, 4]}`,
+		`{"bits": [1, 2, 3]}`,
+		`{"unindexed": [1]}`,
+	} {
+		require.Error(json.Unmarshal([]byte(invalid), &raw), invalid)
+	}
+	_, err = json.Marshal(example.VectorDocUnit{3, 4})
+	require.ErrorIs(err, estype.ErrVectorNotNormalized)
+	_, err = json.Marshal(example.AllDenseVector{1, 2})
+	require.ErrorIs(err, estype.ErrVectorDims)
+
+	embedding := example.NewerTypesEmbedding{1, 0, 0}
+	score, err = embedding.Score(example.NewerTypesEmbedding{3, 4, 0})
+	require.NoError(err)
+	require.InDelta(0.8, score, 1e-6)
+}
+
+func TestDenseVectorFromParam_omitted_dims(t *testing.T) {
+	require := require.New(t)
+
+	// dims is set by the first indexed vector, so length is not checked.
+	gen := generate.DenseVectorFromParam(mapping.DenseVectorParams{}, "docVec")
+	require.Contains(gen.TyDef, "estype.CheckFloatVector(vec, len(vec),")
+
+	bit := mapping.ElementBit
+	gen = generate.DenseVectorFromParam(mapping.DenseVectorParams{ElementType: &bit, Dims: 16}, "docVec")
+	require.Contains(gen.TyDef, "estype.CheckBitVector(vec, 16)")
+}
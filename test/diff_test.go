@@ -0,0 +1,111 @@
+package test_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	require := require.New(t)
+
+	var settings mapping.IndexSettings
+	require.NoError(json.Unmarshal(test.AllMappings, &settings))
+	require.Empty(mapping.Diff(*settings.Mappings, *settings.Mappings))
+
+	decode := func(s string) mapping.Mappings {
+		var m mapping.Mappings
+		require.NoError(json.Unmarshal([]byte(s), &m))
+		return m
+	}
+
+	old := decode(`{
+		"properties": {
+			"title": { "type": "text", "analyzer": "standard" },
+			"tag": { "type": "keyword", "ignore_above": 256 },
+			"count": { "type": "integer" },
+			"obj": { "properties": { "a": { "type": "keyword" } } },
+			"removed": { "type": "keyword" }
+		}
+	}`)
+	new := decode(`{
+		"dynamic": "strict",
+		"date_detection": false,
+		"numeric_detection": true,
+		"dynamic_date_formats": ["yyyy/MM/dd"],
+		"properties": {
+			"title": {
+				"type": "text",
+				"analyzer": "english",
+				"search_analyzer": "standard",
+				"fields": { "raw": { "type": "keyword" } }
+			},
+			"tag": { "type": "keyword", "ignore_above": 512, "meta": { "unit": "none" } },
+			"count": { "type": "long" },
+			"obj": { "type": "nested", "properties": { "a": { "type": "keyword" } } },
+			"added": { "type": "boolean" }
+		}
+	}`)
+
+	changes := mapping.Diff(old, new)
+
+	type summary struct {
+		Path  string
+		Kind  mapping.ChangeKind
+		Param string
+	}
+	var found []summary
+	for _, c := range changes {
+		found = append(found, summary{c.Path, c.Kind, c.Param})
+	}
+	require.Equal(
+		[]summary{
+			{"", mapping.InPlace, "date_detection"},
+			{"", mapping.InPlace, "dynamic"},
+			{"", mapping.InPlace, "dynamic_date_formats"},
+			{"", mapping.InPlace, "numeric_detection"},
+			{"added", mapping.Additive, ""},
+			{"count", mapping.Breaking, "type"},
+			{"obj", mapping.Breaking, "type"},
+			{"removed", mapping.Removed, ""},
+			{"tag", mapping.InPlace, "ignore_above"},
+			{"tag", mapping.InPlace, "meta"},
+			{"title", mapping.Breaking, "analyzer"},
+			{"title", mapping.InPlace, "search_analyzer"},
+			{"title.raw", mapping.Additive, ""},
+		},
+		found,
+	)
+	require.True(changes.Breaking())
+	require.False(changes.Filter(mapping.InPlace).Breaking())
+
+	report := changes.String()
+	require.Contains(report, `breaking: count: type changed from "integer" to "long"`)
+	require.Contains(report, `in_place: tag: ignore_above changed from 256 to 512`)
+	require.Contains(report, `additive: title.raw: field added`)
+	require.Contains(report, `removed: removed: field removed, kept by Elasticsearch`)
+	require.Contains(report, `result: breaking`)
+
+	// Omitting a field is accepted by PUT /<index_name>/_mapping.
+	changes = mapping.Diff(old, decode(`{
+		"properties": {
+			"title": { "type": "text", "analyzer": "standard" },
+			"tag": { "type": "keyword", "ignore_above": 256 },
+			"count": { "type": "integer" },
+			"obj": { "properties": {} }
+		}
+	}`))
+	require.Equal(
+		mapping.Changes{
+			{Path: "obj.a", Kind: mapping.Removed, Old: map[string]any{"type": "keyword"}},
+			{Path: "removed", Kind: mapping.Removed, Old: map[string]any{"type": "keyword"}},
+		},
+		changes,
+	)
+	require.False(changes.Breaking())
+	require.Contains(changes.String(), "result: compatible")
+	require.Contains(changes.String(), "note: removed fields are kept in the existing index")
+}
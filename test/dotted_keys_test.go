@@ -0,0 +1,49 @@
+package test_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDottedKeys(t *testing.T) {
+	require := require.New(t)
+
+	expected := example.ObjectExample{
+		Manager: &[]example.ObjectExampleManager{{
+			Age: 30,
+			Name: example.ObjectExampleName{
+				First: "Alice",
+				Last:  []string{"Smith"},
+			},
+		}},
+	}
+	for _, doc := range []string{
+		`{"manager":{"age":30,"name":{"first":"Alice","last":"Smith"}}}`,
+		`{"manager.age":30,"manager.name.first":"Alice","manager.name.last":"Smith"}`,
+		`{"manager":{"age":30,"name.first":"Alice"},"manager.name":{"last":"Smith"}}`,
+		`{"manager":{"age":30},"manager.name":{"first":"Alice"},"manager.name.last":"Smith"}`,
+	} {
+		var raw example.ObjectExampleRaw
+		require.NoError(json.Unmarshal([]byte(doc), &raw), doc)
+		require.Equal(expected, raw.ToPlain(), doc)
+	}
+
+	for _, tc := range []struct {
+		doc string
+		key string
+	}{
+		{`{"manager":{"age":30},"manager.age":31}`, "manager.age"},
+		{`{"manager.name":{"first":"A"},"manager.name.first":"B"}`, "name.first"},
+		{`{"manager":[{"age":30}],"manager.age":31}`, "manager"},
+	} {
+		var raw example.ObjectExampleRaw
+		err := json.Unmarshal([]byte(tc.doc), &raw)
+		var conflict *estype.KeyConflictError
+		require.ErrorAs(err, &conflict, tc.doc)
+		require.Equal(tc.key, conflict.Key, tc.doc)
+	}
+}
@@ -0,0 +1,147 @@
+package test_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicTemplate(t *testing.T) {
+	require := require.New(t)
+
+	bin := must(os.ReadFile("./example/dynamic_template.json"))
+	var settings mapping.MappingSettings
+	require.NoError(json.Unmarshal(bin, &settings))
+
+	var stored map[string]any
+	require.NoError(json.Unmarshal(bin, &stored))
+	require.True(cmp.Equal(stored, toAnyMap(settings)), cmp.Diff(stored, toAnyMap(settings)))
+
+	templates, err := settings["dynamic_template"].Mappings.DynamicTemplates.Templates()
+	require.NoError(err)
+	require.Len(templates, 4)
+	prop, ok, err := templates[2].Template.Property()
+	require.NoError(err)
+	require.False(ok, "{dynamic_type} without match_mapping_type can not be resolved")
+	prop, ok, err = templates[3].Template.Property()
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(mapping.Keyword, prop.Type)
+
+	doc := []byte(`{
+		"name": "foo",
+		"attrs": {
+			"created_at": "2022-10-20 16:22:46",
+			"count_a": 12,
+			"raw_b": "bar",
+			"other": true
+		},
+		"labels": {
+			"env": "prod",
+			"count_b": 5
+		}
+	}`)
+
+	var raw example.DynamicTemplateRaw
+	require.NoError(json.Unmarshal(doc, &raw))
+	plain := raw.ToPlain()
+
+	attrs := (*plain.Attrs)[0]
+	require.Equal(
+		[]example.DynamicTemplateTimestamps{
+			example.DynamicTemplateTimestamps(time.Date(2022, 10, 20, 16, 22, 46, 0, time.UTC)),
+		},
+		attrs.Timestamps["created_at"],
+	)
+	require.Equal(map[string][]int64{"count_a": {12}}, attrs.Counts)
+	require.Equal(map[string][]any{"raw_b": {"bar"}, "other": {true}}, attrs.Other)
+
+	labels := (*plain.Labels)[0]
+	require.Equal(map[string][]string{"env": {"prod"}}, labels.Labels)
+	// counts only matches under attrs.
+	require.Equal(map[string][]any{"count_b": {float64(5)}}, labels.Other)
+
+	var expected, marshalled map[string]any
+	require.NoError(json.Unmarshal(doc, &expected))
+	require.NoError(json.Unmarshal(must(json.Marshal(plain.ToRaw())), &marshalled))
+	require.True(cmp.Equal(arrayfy(expected), marshalled), cmp.Diff(arrayfy(expected), marshalled))
+}
+
+// arrayfy wraps non-array values of nested objects into single element arrays,
+// since fields of dynamic objects are marshalled as arrays.
+func arrayfy(v map[string]any) map[string]any {
+	out := map[string]any{}
+	for k, val := range v {
+		switch x := val.(type) {
+		case map[string]any:
+			inner := map[string]any{}
+			for ik, iv := range x {
+				inner[ik] = []any{iv}
+			}
+			out[k] = []any{inner}
+		default:
+			out[k] = []any{val}
+		}
+	}
+	return out
+}
+
+func TestDynamicTemplate_detection(t *testing.T) {
+	require := require.New(t)
+
+	// strings are detected as date by dynamic_date_formats.
+	var raw example.DynamicDetectionRaw
+	require.NoError(json.Unmarshal([]byte(`{
+		"attrs": {
+			"day": "2022/10/20",
+			"iso": "2022-10-20T16:22:46Z",
+			"name": "foo"
+		}
+	}`), &raw))
+	attrs := (*raw.ToPlain().Attrs)[0]
+	require.Equal(
+		map[string][]example.DynamicDetectionDates{
+			"day": {example.DynamicDetectionDates(time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC))},
+		},
+		attrs.Dates,
+	)
+	require.Equal(map[string][]string{"iso": {"2022-10-20T16:22:46Z"}, "name": {"foo"}}, attrs.Strings)
+
+	decode := func(s string) mapping.Mappings {
+		var m mapping.Mappings
+		require.NoError(json.Unmarshal([]byte(s), &m))
+		return m
+	}
+
+	detection, err := decode(`{"date_detection": false, "dynamic_date_formats": ["yyyy/MM/dd"]}`).DynamicDetection()
+	require.NoError(err)
+	require.False(*detection.DateDetection)
+	require.Equal([]string{"yyyy/MM/dd"}, detection.DynamicDateFormats)
+
+	disabled := decode(`{
+		"date_detection": false,
+		"dynamic_templates": [{ "dates": { "match_mapping_type": "date", "mapping": { "type": "date" } } }],
+		"properties": { "attrs": { "type": "object", "dynamic": true } }
+	}`)
+	_, rawTy, _, err := generate.Generate(disabled, "doc", generate.GlobalOption{}, nil)
+	require.NoError(err)
+	require.Contains(rawTy[1].TyDef, "Detection: estype.DynamicDetection{DateDetectionDisabled: true},")
+
+	// invalid regular expressions fail validation and generation.
+	badRegex := decode(`{
+		"dynamic_templates": [{ "ids": { "match_pattern": "regex", "match": "^id_(\\d+$", "mapping": { "type": "keyword" } } }],
+		"properties": { "attrs": { "type": "object", "dynamic": true } }
+	}`)
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(badRegex.Validate(), &validationErrs)
+	require.Equal(mapping.RuleDynamicTemplates, validationErrs[0].Rule)
+	_, _, _, err = generate.Generate(badRegex, "doc", generate.GlobalOption{}, nil)
+	require.ErrorContains(err, `dynamic template ids: invalid regex "^id_(\\d+$"`)
+}
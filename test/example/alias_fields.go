@@ -0,0 +1,64 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// AliasDocFieldPaths is a tree of field paths of AliasDoc.
+type AliasDocFieldPaths struct {
+	Attrs     esquery.FieldPath
+	Comments  AliasDocCommentsFieldPaths
+	CreatedAt esquery.FieldPath
+	Name      AliasDocNameSubFieldPaths
+	Published esquery.FieldPath
+	Score     esquery.FieldPath
+	Title     esquery.FieldPath
+	TitleRaw  esquery.FieldPath
+}
+
+// NewAliasDocFieldPaths returns AliasDocFieldPaths whose paths are prefixed with prefix.
+func NewAliasDocFieldPaths(prefix string) AliasDocFieldPaths {
+	return AliasDocFieldPaths{
+		Attrs:     esquery.NewFieldPath(esquery.JoinPath(prefix, "attrs"), "object"),
+		Comments:  NewAliasDocCommentsFieldPaths(esquery.JoinPath(prefix, "comments")),
+		CreatedAt: esquery.NewFieldPath(esquery.JoinPath(prefix, "created_at"), "date"),
+		Name:      NewAliasDocNameSubFieldPaths(esquery.JoinPath(prefix, "name")),
+		Published: esquery.NewFieldPath(esquery.JoinPath(prefix, "published"), "date"),
+		Score:     esquery.NewFieldPath(esquery.JoinPath(prefix, "score"), "integer"),
+		Title:     esquery.NewFieldPath(esquery.JoinPath(prefix, "title"), "text"),
+		TitleRaw:  esquery.NewFieldPath(esquery.JoinPath(prefix, "title_raw"), "keyword"),
+	}
+}
+
+// AliasDocCommentsFieldPaths is a tree of field paths of AliasDocComments.
+type AliasDocCommentsFieldPaths struct {
+	esquery.FieldPath
+	Body esquery.FieldPath
+	Text esquery.FieldPath
+}
+
+// NewAliasDocCommentsFieldPaths returns AliasDocCommentsFieldPaths whose paths are prefixed with prefix.
+func NewAliasDocCommentsFieldPaths(prefix string) AliasDocCommentsFieldPaths {
+	return AliasDocCommentsFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "nested"),
+		Body:      esquery.NewFieldPath(esquery.JoinPath(prefix, "body"), "text"),
+		Text:      esquery.NewFieldPath(esquery.JoinPath(prefix, "text"), "text"),
+	}
+}
+
+// AliasDocNameSubFieldPaths is a tree of field paths of AliasDocNameSub.
+type AliasDocNameSubFieldPaths struct {
+	esquery.FieldPath
+	Raw esquery.FieldPath
+}
+
+// NewAliasDocNameSubFieldPaths returns AliasDocNameSubFieldPaths whose paths are prefixed with prefix.
+func NewAliasDocNameSubFieldPaths(prefix string) AliasDocNameSubFieldPaths {
+	return AliasDocNameSubFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "text"),
+		Raw:       esquery.NewFieldPath(esquery.JoinPath(prefix, "raw"), "keyword"),
+	}
+}
+
+// AliasDocFields is the field path tree of AliasDoc.
+var AliasDocFields = NewAliasDocFieldPaths("")
@@ -0,0 +1,78 @@
+package example
+
+import (
+	"encoding/json"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/flextime"
+	typeparamcommon "github.com/ngicks/type-param-common"
+)
+
+type AliasDoc struct {
+	Attrs     *[]AliasDocAttrs     `json:"attrs"`
+	Comments  *[]AliasDocComments  `json:"comments"`
+	CreatedAt *[]AliasDocCreatedAt `json:"created_at"`
+	Name      *[]string            `json:"name"`
+}
+
+func (t AliasDoc) ToRaw() AliasDocRaw {
+	return AliasDocRaw{
+		Attrs: estype.MapField(estype.NewField(t.Attrs), func(v AliasDocAttrs) AliasDocAttrsRaw {
+			return v.ToRaw()
+		}),
+		Comments: estype.MapField(estype.NewField(t.Comments), func(v AliasDocComments) AliasDocCommentsRaw {
+			return v.ToRaw()
+		}),
+		CreatedAt: estype.NewField(t.CreatedAt),
+		Name:      estype.NewField(t.Name),
+	}
+}
+
+type AliasDocAttrs map[string][]any
+
+func (t AliasDocAttrs) ToRaw() AliasDocAttrsRaw {
+	out := AliasDocAttrsRaw{}
+	for k, v := range t {
+		out[k] = estype.NewFieldSlice(v, false)
+	}
+	return out
+}
+
+type AliasDocComments struct {
+	Body *[]string `json:"body"`
+}
+
+func (t AliasDocComments) ToRaw() AliasDocCommentsRaw {
+	return AliasDocCommentsRaw{
+		Body: estype.NewField(t.Body),
+	}
+}
+
+// AliasDocCreatedAt represents elasticsearch date.
+type AliasDocCreatedAt time.Time
+
+func (t AliasDocCreatedAt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserAliasDocCreatedAt = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006-01-02`)),
+)
+
+func (t *AliasDocCreatedAt) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserAliasDocCreatedAt.Parse,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	*t = AliasDocCreatedAt(tt)
+	return nil
+}
+
+func (t AliasDocCreatedAt) String() string {
+	return time.Time(t).Format(`2006-01-02`)
+}
@@ -0,0 +1,49 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// AliasDocQuery is a set of typed query helpers for fields of AliasDoc.
+type AliasDocQuery struct {
+	Attrs     esquery.ObjectField
+	Comments  AliasDocCommentsQuery
+	CreatedAt esquery.RangeField[AliasDocCreatedAt]
+	Name      esquery.TextField[string]
+	Published esquery.RangeField[AliasDocCreatedAt]
+	Score     esquery.RangeField[int32]
+	Title     esquery.TextField[string]
+	TitleRaw  esquery.KeywordField[string]
+}
+
+// NewAliasDocQuery returns AliasDocQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewAliasDocQuery(prefix string) AliasDocQuery {
+	return AliasDocQuery{
+		Attrs:     esquery.NewObjectField(esquery.JoinPath(prefix, "attrs")),
+		Comments:  NewAliasDocCommentsQuery(esquery.JoinPath(prefix, "comments")),
+		CreatedAt: esquery.NewRangeField[AliasDocCreatedAt](esquery.JoinPath(prefix, "created_at")),
+		Name:      esquery.NewTextField[string](esquery.JoinPath(prefix, "name")),
+		Published: esquery.NewRangeField[AliasDocCreatedAt](esquery.JoinPath(prefix, "published")),
+		Score:     esquery.NewRangeField[int32](esquery.JoinPath(prefix, "score")),
+		Title:     esquery.NewTextField[string](esquery.JoinPath(prefix, "title")),
+		TitleRaw:  esquery.NewKeywordField[string](esquery.JoinPath(prefix, "title_raw")),
+	}
+}
+
+// AliasDocCommentsQuery is a set of typed query helpers for fields of AliasDocComments.
+type AliasDocCommentsQuery struct {
+	esquery.NestedField
+	Body esquery.TextField[string]
+	Text esquery.TextField[string]
+}
+
+// NewAliasDocCommentsQuery returns AliasDocCommentsQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewAliasDocCommentsQuery(prefix string) AliasDocCommentsQuery {
+	return AliasDocCommentsQuery{
+		NestedField: esquery.NewNestedField(prefix),
+		Body:        esquery.NewTextField[string](esquery.JoinPath(prefix, "body")),
+		Text:        esquery.NewTextField[string](esquery.JoinPath(prefix, "text")),
+	}
+}
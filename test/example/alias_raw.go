@@ -0,0 +1,73 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type AliasDocRaw struct {
+	Attrs     estype.Field[AliasDocAttrsRaw]    `json:"attrs"`
+	Comments  estype.Field[AliasDocCommentsRaw] `json:"comments"`
+	CreatedAt estype.Field[AliasDocCreatedAt]   `json:"created_at"`
+	Name      estype.Field[string]              `json:"name"`
+}
+
+func (r AliasDocRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysAliasDocRaw = estype.ObjectKeys{
+	"attrs":      true,
+	"comments":   true,
+	"created_at": false,
+	"name":       false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *AliasDocRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysAliasDocRaw)
+	if err != nil {
+		return err
+	}
+	type plain AliasDocRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t AliasDocRaw) ToPlain() AliasDoc {
+	return AliasDoc{
+		Attrs: estype.MapField(t.Attrs, func(v AliasDocAttrsRaw) AliasDocAttrs {
+			return v.ToPlain()
+		}).Value(),
+		Comments: estype.MapField(t.Comments, func(v AliasDocCommentsRaw) AliasDocComments {
+			return v.ToPlain()
+		}).Value(),
+		CreatedAt: t.CreatedAt.Value(),
+		Name:      t.Name.Value(),
+	}
+}
+
+type AliasDocAttrsRaw map[string]estype.Field[any]
+
+func (t AliasDocAttrsRaw) ToPlain() AliasDocAttrs {
+	out := AliasDocAttrs{}
+	for k, v := range t {
+		out[k] = v.ValueZero()
+	}
+	return out
+}
+
+type AliasDocCommentsRaw struct {
+	Body estype.Field[string] `json:"body"`
+}
+
+func (r AliasDocCommentsRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t AliasDocCommentsRaw) ToPlain() AliasDocComments {
+	return AliasDocComments{
+		Body: t.Body.Value(),
+	}
+}
@@ -0,0 +1,200 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// AllFieldPaths is a tree of field paths of All.
+type AllFieldPaths struct {
+	Agg             esquery.FieldPath
+	Alias           esquery.FieldPath
+	Blob            esquery.FieldPath
+	Bool            esquery.FieldPath
+	Byte            esquery.FieldPath
+	Comp            esquery.FieldPath
+	ConstantKwd     esquery.FieldPath
+	Date            esquery.FieldPath
+	DateNano        esquery.FieldPath
+	DateRange       esquery.FieldPath
+	DenseVector     esquery.FieldPath
+	Double          esquery.FieldPath
+	DoubleRange     esquery.FieldPath
+	Flattened       esquery.FieldPath
+	Float           esquery.FieldPath
+	FloatRange      esquery.FieldPath
+	Geopoint        esquery.FieldPath
+	Geoshape        esquery.FieldPath
+	HalfFloat       esquery.FieldPath
+	Histogram       esquery.FieldPath
+	Integer         esquery.FieldPath
+	IntegerRange    esquery.FieldPath
+	IpAddr          esquery.FieldPath
+	IpRange         esquery.FieldPath
+	Join            esquery.FieldPath
+	Kwd             esquery.FieldPath
+	Long            esquery.FieldPath
+	LongRange       esquery.FieldPath
+	Nested          AllNestedFieldPaths
+	Object          AllObjectFieldPaths
+	Point           esquery.FieldPath
+	Query           esquery.FieldPath
+	RankFeature     esquery.FieldPath
+	RankFeatures    esquery.FieldPath
+	ScaledFloat     esquery.FieldPath
+	SearchAsYouType AllSearchAsYouTypeSubFieldPaths
+	Shape           esquery.FieldPath
+	Short           esquery.FieldPath
+	Text            esquery.FieldPath
+	TextWTokenCount AllTextWTokenCountSubFieldPaths
+	UnsignedLong    esquery.FieldPath
+	Version         esquery.FieldPath
+	Wildcard        esquery.FieldPath
+}
+
+// NewAllFieldPaths returns AllFieldPaths whose paths are prefixed with prefix.
+func NewAllFieldPaths(prefix string) AllFieldPaths {
+	return AllFieldPaths{
+		Agg:             esquery.NewFieldPath(esquery.JoinPath(prefix, "agg"), "aggregate_metric_double"),
+		Alias:           esquery.NewFieldPath(esquery.JoinPath(prefix, "alias"), "binary"),
+		Blob:            esquery.NewFieldPath(esquery.JoinPath(prefix, "blob"), "binary"),
+		Bool:            esquery.NewFieldPath(esquery.JoinPath(prefix, "bool"), "boolean"),
+		Byte:            esquery.NewFieldPath(esquery.JoinPath(prefix, "byte"), "byte"),
+		Comp:            esquery.NewFieldPath(esquery.JoinPath(prefix, "comp"), "completion"),
+		ConstantKwd:     esquery.NewFieldPath(esquery.JoinPath(prefix, "constant_kwd"), "constant_keyword"),
+		Date:            esquery.NewFieldPath(esquery.JoinPath(prefix, "date"), "date"),
+		DateNano:        esquery.NewFieldPath(esquery.JoinPath(prefix, "dateNano"), "date"),
+		DateRange:       esquery.NewFieldPath(esquery.JoinPath(prefix, "date_range"), "date_range"),
+		DenseVector:     esquery.NewFieldPath(esquery.JoinPath(prefix, "dense_vector"), "dense_vector"),
+		Double:          esquery.NewFieldPath(esquery.JoinPath(prefix, "double"), "double"),
+		DoubleRange:     esquery.NewFieldPath(esquery.JoinPath(prefix, "double_range"), "double_range"),
+		Flattened:       esquery.NewFieldPath(esquery.JoinPath(prefix, "flattened"), "flattened"),
+		Float:           esquery.NewFieldPath(esquery.JoinPath(prefix, "float"), "float"),
+		FloatRange:      esquery.NewFieldPath(esquery.JoinPath(prefix, "float_range"), "float_range"),
+		Geopoint:        esquery.NewFieldPath(esquery.JoinPath(prefix, "geopoint"), "geo_point"),
+		Geoshape:        esquery.NewFieldPath(esquery.JoinPath(prefix, "geoshape"), "geo_shape"),
+		HalfFloat:       esquery.NewFieldPath(esquery.JoinPath(prefix, "half_float"), "half_float"),
+		Histogram:       esquery.NewFieldPath(esquery.JoinPath(prefix, "histogram"), "histogram"),
+		Integer:         esquery.NewFieldPath(esquery.JoinPath(prefix, "integer"), "integer"),
+		IntegerRange:    esquery.NewFieldPath(esquery.JoinPath(prefix, "integer_range"), "integer_range"),
+		IpAddr:          esquery.NewFieldPath(esquery.JoinPath(prefix, "ip_addr"), "ip"),
+		IpRange:         esquery.NewFieldPath(esquery.JoinPath(prefix, "ip_range"), "ip_range"),
+		Join:            esquery.NewFieldPath(esquery.JoinPath(prefix, "join"), "join"),
+		Kwd:             esquery.NewFieldPath(esquery.JoinPath(prefix, "kwd"), "keyword"),
+		Long:            esquery.NewFieldPath(esquery.JoinPath(prefix, "long"), "long"),
+		LongRange:       esquery.NewFieldPath(esquery.JoinPath(prefix, "long_range"), "long_range"),
+		Nested:          NewAllNestedFieldPaths(esquery.JoinPath(prefix, "nested")),
+		Object:          NewAllObjectFieldPaths(esquery.JoinPath(prefix, "object")),
+		Point:           esquery.NewFieldPath(esquery.JoinPath(prefix, "point"), "point"),
+		Query:           esquery.NewFieldPath(esquery.JoinPath(prefix, "query"), "percolator"),
+		RankFeature:     esquery.NewFieldPath(esquery.JoinPath(prefix, "rank_feature"), "rank_feature"),
+		RankFeatures:    esquery.NewFieldPath(esquery.JoinPath(prefix, "rank_features"), "rank_features"),
+		ScaledFloat:     esquery.NewFieldPath(esquery.JoinPath(prefix, "scaled_float"), "scaled_float"),
+		SearchAsYouType: NewAllSearchAsYouTypeSubFieldPaths(esquery.JoinPath(prefix, "search_as_you_type")),
+		Shape:           esquery.NewFieldPath(esquery.JoinPath(prefix, "shape"), "shape"),
+		Short:           esquery.NewFieldPath(esquery.JoinPath(prefix, "short"), "short"),
+		Text:            esquery.NewFieldPath(esquery.JoinPath(prefix, "text"), "text"),
+		TextWTokenCount: NewAllTextWTokenCountSubFieldPaths(esquery.JoinPath(prefix, "text_w_token_count")),
+		UnsignedLong:    esquery.NewFieldPath(esquery.JoinPath(prefix, "unsigned_long"), "unsigned_long"),
+		Version:         esquery.NewFieldPath(esquery.JoinPath(prefix, "version"), "version"),
+		Wildcard:        esquery.NewFieldPath(esquery.JoinPath(prefix, "wildcard"), "wildcard"),
+	}
+}
+
+// AllNestedFieldPaths is a tree of field paths of AllNested.
+type AllNestedFieldPaths struct {
+	esquery.FieldPath
+	Age  esquery.FieldPath
+	Name AllNameFieldPaths
+}
+
+// NewAllNestedFieldPaths returns AllNestedFieldPaths whose paths are prefixed with prefix.
+func NewAllNestedFieldPaths(prefix string) AllNestedFieldPaths {
+	return AllNestedFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		Age:       esquery.NewFieldPath(esquery.JoinPath(prefix, "age"), "integer"),
+		Name:      NewAllNameFieldPaths(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// AllNameFieldPaths is a tree of field paths of AllName.
+type AllNameFieldPaths struct {
+	esquery.FieldPath
+	First esquery.FieldPath
+	Last  esquery.FieldPath
+}
+
+// NewAllNameFieldPaths returns AllNameFieldPaths whose paths are prefixed with prefix.
+func NewAllNameFieldPaths(prefix string) AllNameFieldPaths {
+	return AllNameFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		First:     esquery.NewFieldPath(esquery.JoinPath(prefix, "first"), "text"),
+		Last:      esquery.NewFieldPath(esquery.JoinPath(prefix, "last"), "text"),
+	}
+}
+
+// AllObjectFieldPaths is a tree of field paths of AllObject.
+type AllObjectFieldPaths struct {
+	esquery.FieldPath
+	Age  esquery.FieldPath
+	Name AllObjectNameFieldPaths
+}
+
+// NewAllObjectFieldPaths returns AllObjectFieldPaths whose paths are prefixed with prefix.
+func NewAllObjectFieldPaths(prefix string) AllObjectFieldPaths {
+	return AllObjectFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		Age:       esquery.NewFieldPath(esquery.JoinPath(prefix, "age"), "integer"),
+		Name:      NewAllObjectNameFieldPaths(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// AllObjectNameFieldPaths is a tree of field paths of AllObjectName.
+type AllObjectNameFieldPaths struct {
+	esquery.FieldPath
+	First esquery.FieldPath
+	Last  esquery.FieldPath
+}
+
+// NewAllObjectNameFieldPaths returns AllObjectNameFieldPaths whose paths are prefixed with prefix.
+func NewAllObjectNameFieldPaths(prefix string) AllObjectNameFieldPaths {
+	return AllObjectNameFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		First:     esquery.NewFieldPath(esquery.JoinPath(prefix, "first"), "text"),
+		Last:      esquery.NewFieldPath(esquery.JoinPath(prefix, "last"), "text"),
+	}
+}
+
+// AllSearchAsYouTypeSubFieldPaths is a tree of field paths of AllSearchAsYouTypeSub.
+type AllSearchAsYouTypeSubFieldPaths struct {
+	esquery.FieldPath
+	Gram2       esquery.FieldPath
+	Gram3       esquery.FieldPath
+	IndexPrefix esquery.FieldPath
+}
+
+// NewAllSearchAsYouTypeSubFieldPaths returns AllSearchAsYouTypeSubFieldPaths whose paths are prefixed with prefix.
+func NewAllSearchAsYouTypeSubFieldPaths(prefix string) AllSearchAsYouTypeSubFieldPaths {
+	return AllSearchAsYouTypeSubFieldPaths{
+		FieldPath:   esquery.NewFieldPath(prefix, "search_as_you_type"),
+		Gram2:       esquery.NewFieldPath(esquery.JoinPath(prefix, "_2gram"), "search_as_you_type"),
+		Gram3:       esquery.NewFieldPath(esquery.JoinPath(prefix, "_3gram"), "search_as_you_type"),
+		IndexPrefix: esquery.NewFieldPath(esquery.JoinPath(prefix, "_index_prefix"), "search_as_you_type"),
+	}
+}
+
+// AllTextWTokenCountSubFieldPaths is a tree of field paths of AllTextWTokenCountSub.
+type AllTextWTokenCountSubFieldPaths struct {
+	esquery.FieldPath
+	Length esquery.FieldPath
+}
+
+// NewAllTextWTokenCountSubFieldPaths returns AllTextWTokenCountSubFieldPaths whose paths are prefixed with prefix.
+func NewAllTextWTokenCountSubFieldPaths(prefix string) AllTextWTokenCountSubFieldPaths {
+	return AllTextWTokenCountSubFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "text"),
+		Length:    esquery.NewFieldPath(esquery.JoinPath(prefix, "length"), "token_count"),
+	}
+}
+
+// AllFields is the field path tree of All.
+var AllFields = NewAllFieldPaths("")
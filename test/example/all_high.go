@@ -11,55 +11,53 @@ import (
 )
 
 type All struct {
-	Agg             *estype.AggregateMetricDouble `json:"agg"`
-	Alias           *any                          `json:"alias"`
-	Blob            *[]byte                       `json:"blob"`
-	Bool            *estype.Boolean               `json:"bool"`
-	Byte            *int8                         `json:"byte"`
-	Comp            *string                       `json:"comp"`
-	ConstantKwd     *string                       `json:"constant_kwd"`
-	Date            *AllDate                      `json:"date"`
-	DateNano        *AllDateNano                  `json:"dateNano"`
-	DateRange       *map[string]interface{}       `json:"date_range"`
-	DenseVector     *[]float64                    `json:"dense_vector"`
-	Double          *float64                      `json:"double"`
-	DoubleRange     *map[string]interface{}       `json:"double_range"`
-	Flattened       *map[string]interface{}       `json:"flattened"`
-	Float           *float32                      `json:"float"`
-	FloatRange      *map[string]interface{}       `json:"float_range"`
-	Geopoint        *estype.Geopoint              `json:"geopoint"`
-	Geoshape        *estype.Geoshape              `json:"geoshape"`
-	HalfFloat       *float32                      `json:"half_float"`
-	Histogram       *map[string]interface{}       `json:"histogram"`
-	Integer         *int32                        `json:"integer"`
-	IntegerRange    *map[string]interface{}       `json:"integer_range"`
-	IpAddr          *netip.Addr                   `json:"ip_addr"`
-	IpRange         *map[string]interface{}       `json:"ip_range"`
-	Join            *map[string]interface{}       `json:"join"`
-	Kwd             *string                       `json:"kwd"`
-	Long            *int64                        `json:"long"`
-	LongRange       *map[string]interface{}       `json:"long_range"`
-	Nested          *AllNested                    `json:"nested"`
-	Object          *AllObject                    `json:"object"`
-	Point           *map[string]interface{}       `json:"point"`
-	Query           *map[string]interface{}       `json:"query"`
-	RankFeature     *float64                      `json:"rank_feature"`
-	RankFeatures    *map[string]float64           `json:"rank_features"`
-	ScaledFloat     *float64                      `json:"scaled_float"`
-	SearchAsYouType *string                       `json:"search_as_you_type"`
-	Shape           *estype.Geoshape              `json:"shape"`
-	Short           *int16                        `json:"short"`
-	Text            *string                       `json:"text"`
-	TextWTokenCount *string                       `json:"text_w_token_count"`
-	UnsignedLong    *uint64                       `json:"unsigned_long"`
-	Version         *string                       `json:"version"`
-	Wildcard        *string                       `json:"wildcard"`
+	Agg             *estype.AggregateMetricDouble                           `json:"agg"`
+	Blob            *[]byte                                                 `json:"blob"`
+	Bool            *estype.Boolean                                         `json:"bool"`
+	Byte            *int8                                                   `json:"byte"`
+	Comp            *estype.Completion                                      `json:"comp"`
+	ConstantKwd     *string                                                 `json:"constant_kwd"`
+	Date            *AllDate                                                `json:"date"`
+	DateNano        *AllDateNano                                            `json:"dateNano"`
+	DateRange       *estype.Range[estype.StrictDateOptionalTimeEpochMillis] `json:"date_range"`
+	DenseVector     *AllDenseVector                                         `json:"dense_vector"`
+	Double          *float64                                                `json:"double"`
+	DoubleRange     *estype.Range[float64]                                  `json:"double_range"`
+	Flattened       *map[string]interface{}                                 `json:"flattened"`
+	Float           *float32                                                `json:"float"`
+	FloatRange      *estype.Range[float32]                                  `json:"float_range"`
+	Geopoint        *estype.Geopoint                                        `json:"geopoint"`
+	Geoshape        *estype.Geoshape                                        `json:"geoshape"`
+	HalfFloat       *float32                                                `json:"half_float"`
+	Histogram       *estype.Histogram                                       `json:"histogram"`
+	Integer         *int32                                                  `json:"integer"`
+	IntegerRange    *estype.Range[int32]                                    `json:"integer_range"`
+	IpAddr          *netip.Addr                                             `json:"ip_addr"`
+	IpRange         *estype.IPRange                                         `json:"ip_range"`
+	Join            *AllJoin                                                `json:"join"`
+	Kwd             *string                                                 `json:"kwd"`
+	Long            *int64                                                  `json:"long"`
+	LongRange       *estype.Range[int64]                                    `json:"long_range"`
+	Nested          *AllNested                                              `json:"nested"`
+	Object          *AllObject                                              `json:"object"`
+	Point           *estype.Point                                           `json:"point"`
+	Query           *map[string]interface{}                                 `json:"query"`
+	RankFeature     *float64                                                `json:"rank_feature"`
+	RankFeatures    *map[string]float64                                     `json:"rank_features"`
+	ScaledFloat     *float64                                                `json:"scaled_float"`
+	SearchAsYouType *string                                                 `json:"search_as_you_type"`
+	Shape           *estype.Shape                                           `json:"shape"`
+	Short           *int16                                                  `json:"short"`
+	Text            *string                                                 `json:"text"`
+	TextWTokenCount *string                                                 `json:"text_w_token_count"`
+	UnsignedLong    *uint64                                                 `json:"unsigned_long"`
+	Version         *string                                                 `json:"version"`
+	Wildcard        *string                                                 `json:"wildcard"`
 }
 
 func (t All) ToRaw() AllRaw {
 	return AllRaw{
 		Agg:          estype.NewFieldSinglePointer(t.Agg, false),
-		Alias:        estype.NewFieldSinglePointer(t.Alias, false),
 		Blob:         estype.NewFieldSinglePointer(t.Blob, false),
 		Bool:         estype.NewFieldSinglePointer(t.Bool, false),
 		Byte:         estype.NewFieldSinglePointer(t.Byte, false),
@@ -166,6 +164,74 @@ func (t AllDateNano) String() string {
 	return time.Time(t).Format(`2006-01-02T15:04:05.999999999Z07:00`)
 }
 
+// AllDenseVector is a value of the dense_vector field of 3 float elements, compared by l2_norm.
+type AllDenseVector []float32
+
+func (v AllDenseVector) MarshalJSON() ([]byte, error) {
+	if err := estype.CheckFloatVector(v, 3, "l2_norm"); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]float32(v))
+}
+
+func (v *AllDenseVector) UnmarshalJSON(data []byte) error {
+	var vec []float32
+	if err := json.Unmarshal(data, &vec); err != nil {
+		return err
+	}
+	if err := estype.CheckFloatVector(vec, 3, "l2_norm"); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// Score returns _score of kNN search for v as a document vector, computed by l2_norm as Elasticsearch does.
+func (v AllDenseVector) Score(query AllDenseVector) (float32, error) {
+	return estype.FloatVectorScore("l2_norm", query, v)
+}
+
+// AllJoin is a value of the join field. Only relations declared in the mapping are accepted.
+//
+// Child documents must be indexed with routing, the id of their parent in most cases,
+// so that they are in the same shard as their parents.
+type AllJoin estype.Join
+
+var relationsAllJoin = estype.JoinRelations{
+	"answer":   "question",
+	"question": "",
+}
+
+// NewAllJoinAnswer returns AllJoin of the "answer" relation, a child of the "question" document whose id is parentID.
+// The document must be indexed with routing, usually parentID.
+func NewAllJoinAnswer(parentID string) AllJoin {
+	return AllJoin{Name: "answer", Parent: parentID}
+}
+
+// NewAllJoinQuestion returns AllJoin of the "question" relation, which has no parent.
+func NewAllJoinQuestion() AllJoin {
+	return AllJoin{Name: "question"}
+}
+
+func (j AllJoin) MarshalJSON() ([]byte, error) {
+	if err := relationsAllJoin.Check(estype.Join(j)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(estype.Join(j))
+}
+
+func (j *AllJoin) UnmarshalJSON(data []byte) error {
+	var v estype.Join
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := relationsAllJoin.Check(v); err != nil {
+		return err
+	}
+	*j = AllJoin(v)
+	return nil
+}
+
 type AllNested struct {
 	Age  *int32   `json:"age"`
 	Name *AllName `json:"name"`
@@ -0,0 +1,173 @@
+package example
+
+import (
+	"net/netip"
+
+	esquery "github.com/ngicks/elastic-type/es_query"
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+// AllQuery is a set of typed query helpers for fields of All.
+type AllQuery struct {
+	Agg             esquery.ExistsField
+	Alias           esquery.ExistsField
+	Blob            esquery.ExistsField
+	Bool            esquery.TermField[estype.Boolean]
+	Byte            esquery.RangeField[int8]
+	Comp            esquery.ExistsField
+	ConstantKwd     esquery.KeywordField[string]
+	Date            esquery.RangeField[AllDate]
+	DateNano        esquery.RangeField[AllDateNano]
+	DateRange       esquery.ExistsField
+	DenseVector     esquery.ExistsField
+	Double          esquery.RangeField[float64]
+	DoubleRange     esquery.ExistsField
+	Flattened       esquery.ExistsField
+	Float           esquery.RangeField[float32]
+	FloatRange      esquery.ExistsField
+	Geopoint        esquery.ExistsField
+	Geoshape        esquery.ExistsField
+	HalfFloat       esquery.RangeField[float32]
+	Histogram       esquery.ExistsField
+	Integer         esquery.RangeField[int32]
+	IntegerRange    esquery.ExistsField
+	IpAddr          esquery.RangeField[netip.Addr]
+	IpRange         esquery.ExistsField
+	Join            esquery.ExistsField
+	Kwd             esquery.KeywordField[string]
+	Long            esquery.RangeField[int64]
+	LongRange       esquery.ExistsField
+	Nested          AllNestedQuery
+	Object          AllObjectQuery
+	Point           esquery.ExistsField
+	Query           esquery.ExistsField
+	RankFeature     esquery.ExistsField
+	RankFeatures    esquery.ExistsField
+	ScaledFloat     esquery.RangeField[float64]
+	SearchAsYouType esquery.TextField[string]
+	Shape           esquery.ExistsField
+	Short           esquery.RangeField[int16]
+	Text            esquery.TextField[string]
+	TextWTokenCount esquery.TextField[string]
+	UnsignedLong    esquery.RangeField[uint64]
+	Version         esquery.KeywordField[string]
+	Wildcard        esquery.KeywordField[string]
+}
+
+// NewAllQuery returns AllQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewAllQuery(prefix string) AllQuery {
+	return AllQuery{
+		Agg:             esquery.NewExistsField(esquery.JoinPath(prefix, "agg")),
+		Alias:           esquery.NewExistsField(esquery.JoinPath(prefix, "alias")),
+		Blob:            esquery.NewExistsField(esquery.JoinPath(prefix, "blob")),
+		Bool:            esquery.NewTermField[estype.Boolean](esquery.JoinPath(prefix, "bool")),
+		Byte:            esquery.NewRangeField[int8](esquery.JoinPath(prefix, "byte")),
+		Comp:            esquery.NewExistsField(esquery.JoinPath(prefix, "comp")),
+		ConstantKwd:     esquery.NewKeywordField[string](esquery.JoinPath(prefix, "constant_kwd")),
+		Date:            esquery.NewRangeField[AllDate](esquery.JoinPath(prefix, "date")),
+		DateNano:        esquery.NewRangeField[AllDateNano](esquery.JoinPath(prefix, "dateNano")),
+		DateRange:       esquery.NewExistsField(esquery.JoinPath(prefix, "date_range")),
+		DenseVector:     esquery.NewExistsField(esquery.JoinPath(prefix, "dense_vector")),
+		Double:          esquery.NewRangeField[float64](esquery.JoinPath(prefix, "double")),
+		DoubleRange:     esquery.NewExistsField(esquery.JoinPath(prefix, "double_range")),
+		Flattened:       esquery.NewExistsField(esquery.JoinPath(prefix, "flattened")),
+		Float:           esquery.NewRangeField[float32](esquery.JoinPath(prefix, "float")),
+		FloatRange:      esquery.NewExistsField(esquery.JoinPath(prefix, "float_range")),
+		Geopoint:        esquery.NewExistsField(esquery.JoinPath(prefix, "geopoint")),
+		Geoshape:        esquery.NewExistsField(esquery.JoinPath(prefix, "geoshape")),
+		HalfFloat:       esquery.NewRangeField[float32](esquery.JoinPath(prefix, "half_float")),
+		Histogram:       esquery.NewExistsField(esquery.JoinPath(prefix, "histogram")),
+		Integer:         esquery.NewRangeField[int32](esquery.JoinPath(prefix, "integer")),
+		IntegerRange:    esquery.NewExistsField(esquery.JoinPath(prefix, "integer_range")),
+		IpAddr:          esquery.NewRangeField[netip.Addr](esquery.JoinPath(prefix, "ip_addr")),
+		IpRange:         esquery.NewExistsField(esquery.JoinPath(prefix, "ip_range")),
+		Join:            esquery.NewExistsField(esquery.JoinPath(prefix, "join")),
+		Kwd:             esquery.NewKeywordField[string](esquery.JoinPath(prefix, "kwd")),
+		Long:            esquery.NewRangeField[int64](esquery.JoinPath(prefix, "long")),
+		LongRange:       esquery.NewExistsField(esquery.JoinPath(prefix, "long_range")),
+		Nested:          NewAllNestedQuery(esquery.JoinPath(prefix, "nested")),
+		Object:          NewAllObjectQuery(esquery.JoinPath(prefix, "object")),
+		Point:           esquery.NewExistsField(esquery.JoinPath(prefix, "point")),
+		Query:           esquery.NewExistsField(esquery.JoinPath(prefix, "query")),
+		RankFeature:     esquery.NewExistsField(esquery.JoinPath(prefix, "rank_feature")),
+		RankFeatures:    esquery.NewExistsField(esquery.JoinPath(prefix, "rank_features")),
+		ScaledFloat:     esquery.NewRangeField[float64](esquery.JoinPath(prefix, "scaled_float")),
+		SearchAsYouType: esquery.NewTextField[string](esquery.JoinPath(prefix, "search_as_you_type")),
+		Shape:           esquery.NewExistsField(esquery.JoinPath(prefix, "shape")),
+		Short:           esquery.NewRangeField[int16](esquery.JoinPath(prefix, "short")),
+		Text:            esquery.NewTextField[string](esquery.JoinPath(prefix, "text")),
+		TextWTokenCount: esquery.NewTextField[string](esquery.JoinPath(prefix, "text_w_token_count")),
+		UnsignedLong:    esquery.NewRangeField[uint64](esquery.JoinPath(prefix, "unsigned_long")),
+		Version:         esquery.NewKeywordField[string](esquery.JoinPath(prefix, "version")),
+		Wildcard:        esquery.NewKeywordField[string](esquery.JoinPath(prefix, "wildcard")),
+	}
+}
+
+// AllNestedQuery is a set of typed query helpers for fields of AllNested.
+type AllNestedQuery struct {
+	esquery.ObjectField
+	Age  esquery.RangeField[int32]
+	Name AllNameQuery
+}
+
+// NewAllNestedQuery returns AllNestedQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewAllNestedQuery(prefix string) AllNestedQuery {
+	return AllNestedQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		Age:         esquery.NewRangeField[int32](esquery.JoinPath(prefix, "age")),
+		Name:        NewAllNameQuery(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// AllNameQuery is a set of typed query helpers for fields of AllName.
+type AllNameQuery struct {
+	esquery.ObjectField
+	First esquery.TextField[string]
+	Last  esquery.TextField[string]
+}
+
+// NewAllNameQuery returns AllNameQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewAllNameQuery(prefix string) AllNameQuery {
+	return AllNameQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		First:       esquery.NewTextField[string](esquery.JoinPath(prefix, "first")),
+		Last:        esquery.NewTextField[string](esquery.JoinPath(prefix, "last")),
+	}
+}
+
+// AllObjectQuery is a set of typed query helpers for fields of AllObject.
+type AllObjectQuery struct {
+	esquery.ObjectField
+	Age  esquery.RangeField[int32]
+	Name AllObjectNameQuery
+}
+
+// NewAllObjectQuery returns AllObjectQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewAllObjectQuery(prefix string) AllObjectQuery {
+	return AllObjectQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		Age:         esquery.NewRangeField[int32](esquery.JoinPath(prefix, "age")),
+		Name:        NewAllObjectNameQuery(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// AllObjectNameQuery is a set of typed query helpers for fields of AllObjectName.
+type AllObjectNameQuery struct {
+	esquery.ObjectField
+	First esquery.TextField[string]
+	Last  esquery.TextField[string]
+}
+
+// NewAllObjectNameQuery returns AllObjectNameQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewAllObjectNameQuery(prefix string) AllObjectNameQuery {
+	return AllObjectNameQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		First:       esquery.NewTextField[string](esquery.JoinPath(prefix, "first")),
+		Last:        esquery.NewTextField[string](esquery.JoinPath(prefix, "last")),
+	}
+}
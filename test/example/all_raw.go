@@ -1,65 +1,120 @@
 package example
 
 import (
+	"encoding/json"
 	"net/netip"
 
 	estype "github.com/ngicks/elastic-type/es_type"
 )
 
 type AllRaw struct {
-	Agg             estype.Field[estype.AggregateMetricDouble] `json:"agg" esjson:"single"`
-	Alias           estype.Field[any]                          `json:"alias" esjson:"single"`
-	Blob            estype.Field[[]byte]                       `json:"blob" esjson:"single"`
-	Bool            estype.Field[estype.Boolean]               `json:"bool" esjson:"single"`
-	Byte            estype.Field[int8]                         `json:"byte" esjson:"single"`
-	Comp            estype.Field[string]                       `json:"comp" esjson:"single"`
-	ConstantKwd     estype.Field[string]                       `json:"constant_kwd" esjson:"single"`
-	Date            estype.Field[AllDate]                      `json:"date" esjson:"single"`
-	DateNano        estype.Field[AllDateNano]                  `json:"dateNano" esjson:"single"`
-	DateRange       estype.Field[map[string]interface{}]       `json:"date_range" esjson:"single"`
-	DenseVector     estype.Field[[]float64]                    `json:"dense_vector" esjson:"single"`
-	Double          estype.Field[float64]                      `json:"double" esjson:"single"`
-	DoubleRange     estype.Field[map[string]interface{}]       `json:"double_range" esjson:"single"`
-	Flattened       estype.Field[map[string]interface{}]       `json:"flattened" esjson:"single"`
-	Float           estype.Field[float32]                      `json:"float" esjson:"single"`
-	FloatRange      estype.Field[map[string]interface{}]       `json:"float_range" esjson:"single"`
-	Geopoint        estype.Field[estype.Geopoint]              `json:"geopoint" esjson:"single"`
-	Geoshape        estype.Field[estype.Geoshape]              `json:"geoshape" esjson:"single"`
-	HalfFloat       estype.Field[float32]                      `json:"half_float" esjson:"single"`
-	Histogram       estype.Field[map[string]interface{}]       `json:"histogram" esjson:"single"`
-	Integer         estype.Field[int32]                        `json:"integer" esjson:"single"`
-	IntegerRange    estype.Field[map[string]interface{}]       `json:"integer_range" esjson:"single"`
-	IpAddr          estype.Field[netip.Addr]                   `json:"ip_addr" esjson:"single"`
-	IpRange         estype.Field[map[string]interface{}]       `json:"ip_range" esjson:"single"`
-	Join            estype.Field[map[string]interface{}]       `json:"join" esjson:"single"`
-	Kwd             estype.Field[string]                       `json:"kwd" esjson:"single"`
-	Long            estype.Field[int64]                        `json:"long" esjson:"single"`
-	LongRange       estype.Field[map[string]interface{}]       `json:"long_range" esjson:"single"`
-	Nested          estype.Field[AllNestedRaw]                 `json:"nested" esjson:"single"`
-	Object          estype.Field[AllObjectRaw]                 `json:"object" esjson:"single"`
-	Point           estype.Field[map[string]interface{}]       `json:"point" esjson:"single"`
-	Query           estype.Field[map[string]interface{}]       `json:"query" esjson:"single"`
-	RankFeature     estype.Field[float64]                      `json:"rank_feature" esjson:"single"`
-	RankFeatures    estype.Field[map[string]float64]           `json:"rank_features" esjson:"single"`
-	ScaledFloat     estype.Field[float64]                      `json:"scaled_float" esjson:"single"`
-	SearchAsYouType estype.Field[string]                       `json:"search_as_you_type" esjson:"single"`
-	Shape           estype.Field[estype.Geoshape]              `json:"shape" esjson:"single"`
-	Short           estype.Field[int16]                        `json:"short" esjson:"single"`
-	Text            estype.Field[string]                       `json:"text" esjson:"single"`
-	TextWTokenCount estype.Field[string]                       `json:"text_w_token_count" esjson:"single"`
-	UnsignedLong    estype.Field[uint64]                       `json:"unsigned_long" esjson:"single"`
-	Version         estype.Field[string]                       `json:"version" esjson:"single"`
-	Wildcard        estype.Field[string]                       `json:"wildcard" esjson:"single"`
+	Agg             estype.Field[estype.AggregateMetricDouble]                           `json:"agg" esjson:"single"`
+	Blob            estype.Field[[]byte]                                                 `json:"blob" esjson:"single"`
+	Bool            estype.Field[estype.Boolean]                                         `json:"bool" esjson:"single"`
+	Byte            estype.Field[int8]                                                   `json:"byte" esjson:"single"`
+	Comp            estype.Field[estype.Completion]                                      `json:"comp" esjson:"single"`
+	ConstantKwd     estype.Field[string]                                                 `json:"constant_kwd" esjson:"single"`
+	Date            estype.Field[AllDate]                                                `json:"date" esjson:"single"`
+	DateNano        estype.Field[AllDateNano]                                            `json:"dateNano" esjson:"single"`
+	DateRange       estype.Field[estype.Range[estype.StrictDateOptionalTimeEpochMillis]] `json:"date_range" esjson:"single"`
+	DenseVector     estype.Field[AllDenseVector]                                         `json:"dense_vector" esjson:"single"`
+	Double          estype.Field[float64]                                                `json:"double" esjson:"single"`
+	DoubleRange     estype.Field[estype.Range[float64]]                                  `json:"double_range" esjson:"single"`
+	Flattened       estype.Field[map[string]interface{}]                                 `json:"flattened" esjson:"single"`
+	Float           estype.Field[float32]                                                `json:"float" esjson:"single"`
+	FloatRange      estype.Field[estype.Range[float32]]                                  `json:"float_range" esjson:"single"`
+	Geopoint        estype.Field[estype.Geopoint]                                        `json:"geopoint" esjson:"single"`
+	Geoshape        estype.Field[estype.Geoshape]                                        `json:"geoshape" esjson:"single"`
+	HalfFloat       estype.Field[float32]                                                `json:"half_float" esjson:"single"`
+	Histogram       estype.Field[estype.Histogram]                                       `json:"histogram" esjson:"single"`
+	Integer         estype.Field[int32]                                                  `json:"integer" esjson:"single"`
+	IntegerRange    estype.Field[estype.Range[int32]]                                    `json:"integer_range" esjson:"single"`
+	IpAddr          estype.Field[netip.Addr]                                             `json:"ip_addr" esjson:"single"`
+	IpRange         estype.Field[estype.IPRange]                                         `json:"ip_range" esjson:"single"`
+	Join            estype.Field[AllJoin]                                                `json:"join" esjson:"single"`
+	Kwd             estype.Field[string]                                                 `json:"kwd" esjson:"single"`
+	Long            estype.Field[int64]                                                  `json:"long" esjson:"single"`
+	LongRange       estype.Field[estype.Range[int64]]                                    `json:"long_range" esjson:"single"`
+	Nested          estype.Field[AllNestedRaw]                                           `json:"nested" esjson:"single"`
+	Object          estype.Field[AllObjectRaw]                                           `json:"object" esjson:"single"`
+	Point           estype.Field[estype.Point]                                           `json:"point" esjson:"single"`
+	Query           estype.Field[map[string]interface{}]                                 `json:"query" esjson:"single"`
+	RankFeature     estype.Field[float64]                                                `json:"rank_feature" esjson:"single"`
+	RankFeatures    estype.Field[map[string]float64]                                     `json:"rank_features" esjson:"single"`
+	ScaledFloat     estype.Field[float64]                                                `json:"scaled_float" esjson:"single"`
+	SearchAsYouType estype.Field[string]                                                 `json:"search_as_you_type" esjson:"single"`
+	Shape           estype.Field[estype.Shape]                                           `json:"shape" esjson:"single"`
+	Short           estype.Field[int16]                                                  `json:"short" esjson:"single"`
+	Text            estype.Field[string]                                                 `json:"text" esjson:"single"`
+	TextWTokenCount estype.Field[string]                                                 `json:"text_w_token_count" esjson:"single"`
+	UnsignedLong    estype.Field[uint64]                                                 `json:"unsigned_long" esjson:"single"`
+	Version         estype.Field[string]                                                 `json:"version" esjson:"single"`
+	Wildcard        estype.Field[string]                                                 `json:"wildcard" esjson:"single"`
 }
 
 func (r AllRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysAllRaw = estype.ObjectKeys{
+	"agg":                false,
+	"blob":               false,
+	"bool":               false,
+	"byte":               false,
+	"comp":               false,
+	"constant_kwd":       false,
+	"date":               false,
+	"dateNano":           false,
+	"date_range":         false,
+	"dense_vector":       false,
+	"double":             false,
+	"double_range":       false,
+	"flattened":          false,
+	"float":              false,
+	"float_range":        false,
+	"geopoint":           false,
+	"geoshape":           false,
+	"half_float":         false,
+	"histogram":          false,
+	"integer":            false,
+	"integer_range":      false,
+	"ip_addr":            false,
+	"ip_range":           false,
+	"join":               false,
+	"kwd":                false,
+	"long":               false,
+	"long_range":         false,
+	"nested":             true,
+	"object":             true,
+	"point":              false,
+	"query":              false,
+	"rank_feature":       false,
+	"rank_features":      false,
+	"scaled_float":       false,
+	"search_as_you_type": false,
+	"shape":              false,
+	"short":              false,
+	"text":               false,
+	"text_w_token_count": false,
+	"unsigned_long":      false,
+	"version":            false,
+	"wildcard":           false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *AllRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysAllRaw)
+	if err != nil {
+		return err
+	}
+	type plain AllRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t AllRaw) ToPlain() All {
 	return All{
 		Agg:          t.Agg.ValueSingle(),
-		Alias:        t.Alias.ValueSingle(),
 		Blob:         t.Blob.ValueSingle(),
 		Bool:         t.Bool.ValueSingle(),
 		Byte:         t.Byte.ValueSingle(),
@@ -117,6 +172,22 @@ func (r AllNestedRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysAllNestedRaw = estype.ObjectKeys{
+	"age":  false,
+	"name": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *AllNestedRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysAllNestedRaw)
+	if err != nil {
+		return err
+	}
+	type plain AllNestedRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t AllNestedRaw) ToPlain() AllNested {
 	return AllNested{
 		Age: t.Age.ValueSingle(),
@@ -151,6 +222,22 @@ func (r AllObjectRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysAllObjectRaw = estype.ObjectKeys{
+	"age":  false,
+	"name": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *AllObjectRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysAllObjectRaw)
+	if err != nil {
+		return err
+	}
+	type plain AllObjectRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t AllObjectRaw) ToPlain() AllObject {
 	return AllObject{
 		Age: t.Age.ValueSingle(),
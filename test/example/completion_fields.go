@@ -0,0 +1,22 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// SuggestDocFieldPaths is a tree of field paths of SuggestDoc.
+type SuggestDocFieldPaths struct {
+	Place esquery.FieldPath
+	Title esquery.FieldPath
+}
+
+// NewSuggestDocFieldPaths returns SuggestDocFieldPaths whose paths are prefixed with prefix.
+func NewSuggestDocFieldPaths(prefix string) SuggestDocFieldPaths {
+	return SuggestDocFieldPaths{
+		Place: esquery.NewFieldPath(esquery.JoinPath(prefix, "place"), "completion"),
+		Title: esquery.NewFieldPath(esquery.JoinPath(prefix, "title"), "completion"),
+	}
+}
+
+// SuggestDocFields is the field path tree of SuggestDoc.
+var SuggestDocFields = NewSuggestDocFieldPaths("")
@@ -0,0 +1,46 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type SuggestDoc struct {
+	Place *[]SuggestDocPlace   `json:"place"`
+	Title *[]estype.Completion `json:"title"`
+}
+
+func (t SuggestDoc) ToRaw() SuggestDocRaw {
+	return SuggestDocRaw{
+		Place: estype.NewField(t.Place),
+		Title: estype.NewField(t.Title),
+	}
+}
+
+// SuggestDocPlace is a value of the completion field. Only contexts declared in the mapping are accepted.
+type SuggestDocPlace estype.Completion
+
+var contextsSuggestDocPlace = estype.CompletionContextTypes{
+	"place_type": "category",
+	"location":   "geo",
+}
+
+func (c SuggestDocPlace) MarshalJSON() ([]byte, error) {
+	if err := contextsSuggestDocPlace.Check(estype.Completion(c)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(estype.Completion(c))
+}
+
+func (c *SuggestDocPlace) UnmarshalJSON(data []byte) error {
+	var v estype.Completion
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := contextsSuggestDocPlace.Check(v); err != nil {
+		return err
+	}
+	*c = SuggestDocPlace(v)
+	return nil
+}
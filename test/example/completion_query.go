@@ -0,0 +1,20 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// SuggestDocQuery is a set of typed query helpers for fields of SuggestDoc.
+type SuggestDocQuery struct {
+	Place esquery.ExistsField
+	Title esquery.ExistsField
+}
+
+// NewSuggestDocQuery returns SuggestDocQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewSuggestDocQuery(prefix string) SuggestDocQuery {
+	return SuggestDocQuery{
+		Place: esquery.NewExistsField(esquery.JoinPath(prefix, "place")),
+		Title: esquery.NewExistsField(esquery.JoinPath(prefix, "title")),
+	}
+}
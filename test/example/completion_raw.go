@@ -0,0 +1,21 @@
+package example
+
+import (
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type SuggestDocRaw struct {
+	Place estype.Field[SuggestDocPlace]   `json:"place"`
+	Title estype.Field[estype.Completion] `json:"title"`
+}
+
+func (r SuggestDocRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t SuggestDocRaw) ToPlain() SuggestDoc {
+	return SuggestDoc{
+		Place: t.Place.Value(),
+		Title: t.Title.Value(),
+	}
+}
@@ -0,0 +1,20 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// DynamicDetectionFieldPaths is a tree of field paths of DynamicDetection.
+type DynamicDetectionFieldPaths struct {
+	Attrs esquery.FieldPath
+}
+
+// NewDynamicDetectionFieldPaths returns DynamicDetectionFieldPaths whose paths are prefixed with prefix.
+func NewDynamicDetectionFieldPaths(prefix string) DynamicDetectionFieldPaths {
+	return DynamicDetectionFieldPaths{
+		Attrs: esquery.NewFieldPath(esquery.JoinPath(prefix, "attrs"), "object"),
+	}
+}
+
+// DynamicDetectionFields is the field path tree of DynamicDetection.
+var DynamicDetectionFields = NewDynamicDetectionFieldPaths("")
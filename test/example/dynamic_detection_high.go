@@ -0,0 +1,68 @@
+package example
+
+import (
+	"encoding/json"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/flextime"
+	typeparamcommon "github.com/ngicks/type-param-common"
+)
+
+type DynamicDetection struct {
+	Attrs *[]DynamicDetectionAttrs `json:"attrs"`
+}
+
+func (t DynamicDetection) ToRaw() DynamicDetectionRaw {
+	return DynamicDetectionRaw{
+		Attrs: estype.MapField(estype.NewField(t.Attrs), func(v DynamicDetectionAttrs) DynamicDetectionAttrsRaw {
+			return v.ToRaw()
+		}),
+	}
+}
+
+type DynamicDetectionAttrs struct {
+	// Dates holds fields matched to the dynamic template "dates".
+	Dates map[string][]DynamicDetectionDates
+	// Strings holds fields matched to the dynamic template "strings".
+	Strings map[string][]string
+	// Other holds fields matched to none of dynamic templates with a statically known type.
+	Other map[string][]any
+}
+
+func (t DynamicDetectionAttrs) ToRaw() DynamicDetectionAttrsRaw {
+	return DynamicDetectionAttrsRaw{
+		Dates:   estype.DynamicFieldsToRaw(t.Dates),
+		Strings: estype.DynamicFieldsToRaw(t.Strings),
+		Other:   estype.DynamicFieldsToRaw(t.Other),
+	}
+}
+
+// DynamicDetectionDates represents elasticsearch date.
+type DynamicDetectionDates time.Time
+
+func (t DynamicDetectionDates) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserDynamicDetectionDates = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006/01/02 15:04:05`)).
+		AddLayout(typeparamcommon.Must(flextime.NewLayoutSet(`2006/01/02`))),
+)
+
+func (t *DynamicDetectionDates) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserDynamicDetectionDates.Parse,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	*t = DynamicDetectionDates(tt)
+	return nil
+}
+
+func (t DynamicDetectionDates) String() string {
+	return time.Time(t).Format(`2006/01/02 15:04:05`)
+}
@@ -0,0 +1,18 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// DynamicDetectionQuery is a set of typed query helpers for fields of DynamicDetection.
+type DynamicDetectionQuery struct {
+	Attrs esquery.ObjectField
+}
+
+// NewDynamicDetectionQuery returns DynamicDetectionQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewDynamicDetectionQuery(prefix string) DynamicDetectionQuery {
+	return DynamicDetectionQuery{
+		Attrs: esquery.NewObjectField(esquery.JoinPath(prefix, "attrs")),
+	}
+}
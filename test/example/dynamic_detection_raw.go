@@ -0,0 +1,104 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/flextime"
+	typeparamcommon "github.com/ngicks/type-param-common"
+)
+
+type DynamicDetectionRaw struct {
+	Attrs estype.Field[DynamicDetectionAttrsRaw] `json:"attrs"`
+}
+
+func (r DynamicDetectionRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysDynamicDetectionRaw = estype.ObjectKeys{
+	"attrs": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *DynamicDetectionRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysDynamicDetectionRaw)
+	if err != nil {
+		return err
+	}
+	type plain DynamicDetectionRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t DynamicDetectionRaw) ToPlain() DynamicDetection {
+	return DynamicDetection{
+		Attrs: estype.MapField(t.Attrs, func(v DynamicDetectionAttrsRaw) DynamicDetectionAttrs {
+			return v.ToPlain()
+		}).Value(),
+	}
+}
+
+type DynamicDetectionAttrsRaw struct {
+	Dates   map[string]estype.Field[DynamicDetectionDates]
+	Strings map[string]estype.Field[string]
+	Other   map[string]estype.Field[any]
+}
+
+var dynamicTemplatesDynamicDetectionAttrs = estype.DynamicTemplateTable{
+	Detection: estype.DynamicDetection{
+		ParseDate: flextime.NewFlextime(
+			typeparamcommon.Must(flextime.NewLayoutSet("2006/01/02 15:04:05")).
+				AddLayout(typeparamcommon.Must(flextime.NewLayoutSet("2006/01/02"))),
+		).Parse,
+	},
+	Matchers: []estype.DynamicTemplateMatcher{
+		{
+			Name:             "dates",
+			MatchMappingType: []string{"date"},
+		},
+		{
+			Name:             "strings",
+			MatchMappingType: []string{"string"},
+		},
+	},
+}
+
+func (r DynamicDetectionAttrsRaw) MarshalJSON() ([]byte, error) {
+	out := map[string]any{}
+	estype.CollectDynamicFields(out, r.Dates)
+	estype.CollectDynamicFields(out, r.Strings)
+	estype.CollectDynamicFields(out, r.Other)
+	return json.Marshal(out)
+}
+
+func (r *DynamicDetectionAttrsRaw) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*r = DynamicDetectionAttrsRaw{}
+	for name, value := range fields {
+		var err error
+		switch dynamicTemplatesDynamicDetectionAttrs.Match("attrs"+"."+name, name, value) {
+		case 0:
+			err = estype.UnmarshalDynamicField(&r.Dates, name, value)
+		case 1:
+			err = estype.UnmarshalDynamicField(&r.Strings, name, value)
+		default:
+			err = estype.UnmarshalDynamicField(&r.Other, name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t DynamicDetectionAttrsRaw) ToPlain() DynamicDetectionAttrs {
+	return DynamicDetectionAttrs{
+		Dates:   estype.DynamicFieldsToPlain(t.Dates),
+		Strings: estype.DynamicFieldsToPlain(t.Strings),
+		Other:   estype.DynamicFieldsToPlain(t.Other),
+	}
+}
@@ -0,0 +1,24 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// DynamicTemplateFieldPaths is a tree of field paths of DynamicTemplate.
+type DynamicTemplateFieldPaths struct {
+	Attrs  esquery.FieldPath
+	Labels esquery.FieldPath
+	Name   esquery.FieldPath
+}
+
+// NewDynamicTemplateFieldPaths returns DynamicTemplateFieldPaths whose paths are prefixed with prefix.
+func NewDynamicTemplateFieldPaths(prefix string) DynamicTemplateFieldPaths {
+	return DynamicTemplateFieldPaths{
+		Attrs:  esquery.NewFieldPath(esquery.JoinPath(prefix, "attrs"), "object"),
+		Labels: esquery.NewFieldPath(esquery.JoinPath(prefix, "labels"), "object"),
+		Name:   esquery.NewFieldPath(esquery.JoinPath(prefix, "name"), "keyword"),
+	}
+}
+
+// DynamicTemplateFields is the field path tree of DynamicTemplate.
+var DynamicTemplateFields = NewDynamicTemplateFieldPaths("")
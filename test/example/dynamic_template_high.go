@@ -0,0 +1,118 @@
+package example
+
+import (
+	"encoding/json"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/flextime"
+	typeparamcommon "github.com/ngicks/type-param-common"
+)
+
+type DynamicTemplate struct {
+	Attrs  *[]DynamicTemplateAttrs  `json:"attrs"`
+	Labels *[]DynamicTemplateLabels `json:"labels"`
+	Name   *[]string                `json:"name"`
+}
+
+func (t DynamicTemplate) ToRaw() DynamicTemplateRaw {
+	return DynamicTemplateRaw{
+		Attrs: estype.MapField(estype.NewField(t.Attrs), func(v DynamicTemplateAttrs) DynamicTemplateAttrsRaw {
+			return v.ToRaw()
+		}),
+		Labels: estype.MapField(estype.NewField(t.Labels), func(v DynamicTemplateLabels) DynamicTemplateLabelsRaw {
+			return v.ToRaw()
+		}),
+		Name: estype.NewField(t.Name),
+	}
+}
+
+type DynamicTemplateAttrs struct {
+	// Timestamps holds fields matched to the dynamic template "timestamps".
+	Timestamps map[string][]DynamicTemplateTimestamps
+	// Counts holds fields matched to the dynamic template "counts".
+	Counts map[string][]int64
+	// Other holds fields matched to none of dynamic templates with a statically known type.
+	Other map[string][]any
+}
+
+func (t DynamicTemplateAttrs) ToRaw() DynamicTemplateAttrsRaw {
+	return DynamicTemplateAttrsRaw{
+		Timestamps: estype.DynamicFieldsToRaw(t.Timestamps),
+		Counts:     estype.DynamicFieldsToRaw(t.Counts),
+		Other:      estype.DynamicFieldsToRaw(t.Other),
+	}
+}
+
+// DynamicTemplateTimestamps represents elasticsearch date.
+type DynamicTemplateTimestamps time.Time
+
+func (t DynamicTemplateTimestamps) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserDynamicTemplateTimestamps = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006-01-02 15:04:05`)),
+)
+
+func (t *DynamicTemplateTimestamps) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserDynamicTemplateTimestamps.Parse,
+		time.UnixMilli,
+	)
+	if err != nil {
+		return err
+	}
+	*t = DynamicTemplateTimestamps(tt)
+	return nil
+}
+
+func (t DynamicTemplateTimestamps) String() string {
+	return time.Time(t).Format(`2006-01-02 15:04:05`)
+}
+
+type DynamicTemplateLabels struct {
+	// Timestamps holds fields matched to the dynamic template "timestamps".
+	Timestamps map[string][]DynamicTemplateLabelsTimestamps
+	// Labels holds fields matched to the dynamic template "labels".
+	Labels map[string][]string
+	// Other holds fields matched to none of dynamic templates with a statically known type.
+	Other map[string][]any
+}
+
+func (t DynamicTemplateLabels) ToRaw() DynamicTemplateLabelsRaw {
+	return DynamicTemplateLabelsRaw{
+		Timestamps: estype.DynamicFieldsToRaw(t.Timestamps),
+		Labels:     estype.DynamicFieldsToRaw(t.Labels),
+		Other:      estype.DynamicFieldsToRaw(t.Other),
+	}
+}
+
+// DynamicTemplateLabelsTimestamps represents elasticsearch date.
+type DynamicTemplateLabelsTimestamps time.Time
+
+func (t DynamicTemplateLabelsTimestamps) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserDynamicTemplateLabelsTimestamps = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006-01-02 15:04:05`)),
+)
+
+func (t *DynamicTemplateLabelsTimestamps) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserDynamicTemplateLabelsTimestamps.Parse,
+		time.UnixMilli,
+	)
+	if err != nil {
+		return err
+	}
+	*t = DynamicTemplateLabelsTimestamps(tt)
+	return nil
+}
+
+func (t DynamicTemplateLabelsTimestamps) String() string {
+	return time.Time(t).Format(`2006-01-02 15:04:05`)
+}
@@ -0,0 +1,22 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// DynamicTemplateQuery is a set of typed query helpers for fields of DynamicTemplate.
+type DynamicTemplateQuery struct {
+	Attrs  esquery.ObjectField
+	Labels esquery.ObjectField
+	Name   esquery.KeywordField[string]
+}
+
+// NewDynamicTemplateQuery returns DynamicTemplateQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewDynamicTemplateQuery(prefix string) DynamicTemplateQuery {
+	return DynamicTemplateQuery{
+		Attrs:  esquery.NewObjectField(esquery.JoinPath(prefix, "attrs")),
+		Labels: esquery.NewObjectField(esquery.JoinPath(prefix, "labels")),
+		Name:   esquery.NewKeywordField[string](esquery.JoinPath(prefix, "name")),
+	}
+}
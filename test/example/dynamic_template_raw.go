@@ -0,0 +1,184 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type DynamicTemplateRaw struct {
+	Attrs  estype.Field[DynamicTemplateAttrsRaw]  `json:"attrs"`
+	Labels estype.Field[DynamicTemplateLabelsRaw] `json:"labels"`
+	Name   estype.Field[string]                   `json:"name"`
+}
+
+func (r DynamicTemplateRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysDynamicTemplateRaw = estype.ObjectKeys{
+	"attrs":  true,
+	"labels": true,
+	"name":   false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *DynamicTemplateRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysDynamicTemplateRaw)
+	if err != nil {
+		return err
+	}
+	type plain DynamicTemplateRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t DynamicTemplateRaw) ToPlain() DynamicTemplate {
+	return DynamicTemplate{
+		Attrs: estype.MapField(t.Attrs, func(v DynamicTemplateAttrsRaw) DynamicTemplateAttrs {
+			return v.ToPlain()
+		}).Value(),
+		Labels: estype.MapField(t.Labels, func(v DynamicTemplateLabelsRaw) DynamicTemplateLabels {
+			return v.ToPlain()
+		}).Value(),
+		Name: t.Name.Value(),
+	}
+}
+
+type DynamicTemplateAttrsRaw struct {
+	Timestamps map[string]estype.Field[DynamicTemplateTimestamps]
+	Counts     map[string]estype.Field[int64]
+	Other      map[string]estype.Field[any]
+}
+
+var dynamicTemplatesDynamicTemplateAttrs = estype.DynamicTemplateTable{
+	Matchers: []estype.DynamicTemplateMatcher{
+		{
+			Name:             "timestamps",
+			MatchMappingType: []string{"string"},
+			Match:            []string{"*_at"},
+		},
+		{
+			Name:      "counts",
+			Match:     []string{"count_*"},
+			PathMatch: []string{"attrs.*"},
+		},
+		{
+			Name:  "no_index",
+			Match: []string{"raw_*"},
+		},
+		{
+			Name:             "labels",
+			MatchMappingType: []string{"string"},
+			PathMatch:        []string{"labels.*"},
+		},
+	},
+}
+
+func (r DynamicTemplateAttrsRaw) MarshalJSON() ([]byte, error) {
+	out := map[string]any{}
+	estype.CollectDynamicFields(out, r.Timestamps)
+	estype.CollectDynamicFields(out, r.Counts)
+	estype.CollectDynamicFields(out, r.Other)
+	return json.Marshal(out)
+}
+
+func (r *DynamicTemplateAttrsRaw) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*r = DynamicTemplateAttrsRaw{}
+	for name, value := range fields {
+		var err error
+		switch dynamicTemplatesDynamicTemplateAttrs.Match("attrs"+"."+name, name, value) {
+		case 0:
+			err = estype.UnmarshalDynamicField(&r.Timestamps, name, value)
+		case 1:
+			err = estype.UnmarshalDynamicField(&r.Counts, name, value)
+		default:
+			err = estype.UnmarshalDynamicField(&r.Other, name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t DynamicTemplateAttrsRaw) ToPlain() DynamicTemplateAttrs {
+	return DynamicTemplateAttrs{
+		Timestamps: estype.DynamicFieldsToPlain(t.Timestamps),
+		Counts:     estype.DynamicFieldsToPlain(t.Counts),
+		Other:      estype.DynamicFieldsToPlain(t.Other),
+	}
+}
+
+type DynamicTemplateLabelsRaw struct {
+	Timestamps map[string]estype.Field[DynamicTemplateLabelsTimestamps]
+	Labels     map[string]estype.Field[string]
+	Other      map[string]estype.Field[any]
+}
+
+var dynamicTemplatesDynamicTemplateLabels = estype.DynamicTemplateTable{
+	Matchers: []estype.DynamicTemplateMatcher{
+		{
+			Name:             "timestamps",
+			MatchMappingType: []string{"string"},
+			Match:            []string{"*_at"},
+		},
+		{
+			Name:      "counts",
+			Match:     []string{"count_*"},
+			PathMatch: []string{"attrs.*"},
+		},
+		{
+			Name:  "no_index",
+			Match: []string{"raw_*"},
+		},
+		{
+			Name:             "labels",
+			MatchMappingType: []string{"string"},
+			PathMatch:        []string{"labels.*"},
+		},
+	},
+}
+
+func (r DynamicTemplateLabelsRaw) MarshalJSON() ([]byte, error) {
+	out := map[string]any{}
+	estype.CollectDynamicFields(out, r.Timestamps)
+	estype.CollectDynamicFields(out, r.Labels)
+	estype.CollectDynamicFields(out, r.Other)
+	return json.Marshal(out)
+}
+
+func (r *DynamicTemplateLabelsRaw) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*r = DynamicTemplateLabelsRaw{}
+	for name, value := range fields {
+		var err error
+		switch dynamicTemplatesDynamicTemplateLabels.Match("labels"+"."+name, name, value) {
+		case 0:
+			err = estype.UnmarshalDynamicField(&r.Timestamps, name, value)
+		case 3:
+			err = estype.UnmarshalDynamicField(&r.Labels, name, value)
+		default:
+			err = estype.UnmarshalDynamicField(&r.Other, name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t DynamicTemplateLabelsRaw) ToPlain() DynamicTemplateLabels {
+	return DynamicTemplateLabels{
+		Timestamps: estype.DynamicFieldsToPlain(t.Timestamps),
+		Labels:     estype.DynamicFieldsToPlain(t.Labels),
+		Other:      estype.DynamicFieldsToPlain(t.Other),
+	}
+}
@@ -0,0 +1,24 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// ExampleFieldPaths is a tree of field paths of Example.
+type ExampleFieldPaths struct {
+	Blob esquery.FieldPath
+	Bool esquery.FieldPath
+	Date esquery.FieldPath
+}
+
+// NewExampleFieldPaths returns ExampleFieldPaths whose paths are prefixed with prefix.
+func NewExampleFieldPaths(prefix string) ExampleFieldPaths {
+	return ExampleFieldPaths{
+		Blob: esquery.NewFieldPath(esquery.JoinPath(prefix, "blob"), "binary"),
+		Bool: esquery.NewFieldPath(esquery.JoinPath(prefix, "bool"), "boolean"),
+		Date: esquery.NewFieldPath(esquery.JoinPath(prefix, "date"), "date"),
+	}
+}
+
+// ExampleFields is the field path tree of Example.
+var ExampleFields = NewExampleFieldPaths("")
@@ -0,0 +1,23 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+// ExampleQuery is a set of typed query helpers for fields of Example.
+type ExampleQuery struct {
+	Blob esquery.ExistsField
+	Bool esquery.TermField[estype.Boolean]
+	Date esquery.RangeField[ExampleDate]
+}
+
+// NewExampleQuery returns ExampleQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewExampleQuery(prefix string) ExampleQuery {
+	return ExampleQuery{
+		Blob: esquery.NewExistsField(esquery.JoinPath(prefix, "blob")),
+		Bool: esquery.NewTermField[estype.Boolean](esquery.JoinPath(prefix, "bool")),
+		Date: esquery.NewRangeField[ExampleDate](esquery.JoinPath(prefix, "date")),
+	}
+}
@@ -1,7 +1,21 @@
 package example
 
-//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./object.json -out-high ./object_high.go -out-raw ./object_raw.go -out-test ./object_test.go -map-option ./object_map_option.json
-//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./object_w_overlap.json -out-high ./object_w_overlap_high.go -out-raw ./object_w_overlap_raw.go -out-test ./object_w_overlap_test.go
-//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./all.json -out-high ./all_high.go -out-raw ./all_raw.go -out-test ./all_test.go -global-option ./all_global_option.json
-//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./object_dynamic_inheritance.json -out-high ./object_dynamic_inheritance_high.go -out-raw ./object_dynamic_inheritance_raw.go -out-test ./object_dynamic_inheritance_test.go
-//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./example.json -out-high ./example_high.go -out-raw ./example_raw.go -out-test ./example_test.go -global-option ./example_global_option.json -map-option ./example_map_option.json
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./object.json -out-high ./object_high.go -out-raw ./object_raw.go -out-query ./object_query.go -out-fields ./object_fields.go -out-test ./object_test.go -map-option ./object_map_option.json
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./object_w_overlap.json -out-high ./object_w_overlap_high.go -out-raw ./object_w_overlap_raw.go -out-query ./object_w_overlap_query.go -out-fields ./object_w_overlap_fields.go -out-test ./object_w_overlap_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./all.json -out-high ./all_high.go -out-raw ./all_raw.go -out-query ./all_query.go -out-fields ./all_fields.go -out-test ./all_test.go -global-option ./all_global_option.json
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./object_dynamic_inheritance.json -out-high ./object_dynamic_inheritance_high.go -out-raw ./object_dynamic_inheritance_raw.go -out-query ./object_dynamic_inheritance_query.go -out-fields ./object_dynamic_inheritance_fields.go -out-test ./object_dynamic_inheritance_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./example.json -out-high ./example_high.go -out-raw ./example_raw.go -out-query ./example_query.go -out-fields ./example_fields.go -out-test ./example_test.go -global-option ./example_global_option.json -map-option ./example_map_option.json
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./migration_v1.json -out-high ./migration_v1_high.go -out-raw ./migration_v1_raw.go -out-test ./migration_v1_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./migration_v2.json -out-high ./migration_v2_high.go -out-raw ./migration_v2_raw.go -out-test ./migration_v2_test.go
+//go:generate go run ../../cmd/diff-es-mapping/main.go -prefix-with-index-name -old ./migration_v1.json -new ./migration_v2.json -out-index-body ./migration_v2_index.json -out-reindex-body ./migration_reindex.json -out-convert ./migration_convert.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -index-template ./index_template.json -component-template ./component_template.json -index-name logs-app -out-high ./template_logs_high.go -out-raw ./template_logs_raw.go -out-query ./template_logs_query.go -out-fields ./template_logs_fields.go -out-test ./template_logs_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./dynamic_template.json -out-high ./dynamic_template_high.go -out-raw ./dynamic_template_raw.go -out-query ./dynamic_template_query.go -out-fields ./dynamic_template_fields.go -out-test ./dynamic_template_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./runtime.json -out-high ./runtime_high.go -out-raw ./runtime_raw.go -out-runtime ./runtime_runtime.go -out-test ./runtime_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./meta_fields.json -out-high ./meta_fields_high.go -out-raw ./meta_fields_raw.go -out-query ./meta_fields_query.go -out-fields ./meta_fields_fields.go -out-test ./meta_fields_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -target-version 8.18 -i ./newer_types.json -out-high ./newer_types_high.go -out-raw ./newer_types_raw.go -out-query ./newer_types_query.go -out-fields ./newer_types_fields.go -out-test ./newer_types_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./alias.json -out-high ./alias_high.go -out-raw ./alias_raw.go -out-query ./alias_query.go -out-fields ./alias_fields.go -out-test ./alias_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./subobjects.json -out-high ./subobjects_high.go -out-raw ./subobjects_raw.go -out-query ./subobjects_query.go -out-fields ./subobjects_fields.go -out-test ./subobjects_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./range.json -out-high ./range_high.go -out-raw ./range_raw.go -out-query ./range_query.go -out-fields ./range_fields.go -out-test ./range_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./completion.json -out-high ./completion_high.go -out-raw ./completion_raw.go -out-query ./completion_query.go -out-fields ./completion_fields.go -out-test ./completion_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -target-version 8.18 -i ./vector.json -out-high ./vector_high.go -out-raw ./vector_raw.go -out-query ./vector_query.go -out-fields ./vector_fields.go -out-test ./vector_test.go
+//go:generate go run ../../cmd/generate-es-type/main.go -prefix-with-index-name -i ./dynamic_detection.json -out-high ./dynamic_detection_high.go -out-raw ./dynamic_detection_raw.go -out-query ./dynamic_detection_query.go -out-fields ./dynamic_detection_fields.go -out-test ./dynamic_detection_test.go
@@ -0,0 +1,42 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// TenantDocFieldPaths is a tree of field paths of TenantDoc.
+type TenantDocFieldPaths struct {
+	Body   esquery.FieldPath
+	Meta   TenantDocMetaFieldPaths
+	Tenant esquery.FieldPath
+	Title  esquery.FieldPath
+}
+
+// NewTenantDocFieldPaths returns TenantDocFieldPaths whose paths are prefixed with prefix.
+func NewTenantDocFieldPaths(prefix string) TenantDocFieldPaths {
+	return TenantDocFieldPaths{
+		Body:   esquery.NewFieldPath(esquery.JoinPath(prefix, "body"), "text"),
+		Meta:   NewTenantDocMetaFieldPaths(esquery.JoinPath(prefix, "meta")),
+		Tenant: esquery.NewFieldPath(esquery.JoinPath(prefix, "tenant"), "keyword"),
+		Title:  esquery.NewFieldPath(esquery.JoinPath(prefix, "title"), "text"),
+	}
+}
+
+// TenantDocMetaFieldPaths is a tree of field paths of TenantDocMeta.
+type TenantDocMetaFieldPaths struct {
+	esquery.FieldPath
+	Secret esquery.FieldPath
+	Tags   esquery.FieldPath
+}
+
+// NewTenantDocMetaFieldPaths returns TenantDocMetaFieldPaths whose paths are prefixed with prefix.
+func NewTenantDocMetaFieldPaths(prefix string) TenantDocMetaFieldPaths {
+	return TenantDocMetaFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		Secret:    esquery.NewFieldPath(esquery.JoinPath(prefix, "secret"), "keyword"),
+		Tags:      esquery.NewFieldPath(esquery.JoinPath(prefix, "tags"), "keyword"),
+	}
+}
+
+// TenantDocFields is the field path tree of TenantDoc.
+var TenantDocFields = NewTenantDocFieldPaths("")
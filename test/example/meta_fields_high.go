@@ -0,0 +1,37 @@
+package example
+
+import (
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type TenantDoc struct {
+	Meta   *[]TenantDocMeta `json:"meta"`
+	Tenant *[]string        `json:"tenant"`
+	Title  *[]string        `json:"title"`
+}
+
+func (t TenantDoc) ToRaw() TenantDocRaw {
+	return TenantDocRaw{
+		Meta: estype.MapField(estype.NewField(t.Meta), func(v TenantDocMeta) TenantDocMetaRaw {
+			return v.ToRaw()
+		}),
+		Tenant: estype.NewField(t.Tenant),
+		Title:  estype.NewField(t.Title),
+	}
+}
+
+// RoutingRequired reports whether _routing.required is true in the mapping.
+// If true, index, get, update and delete requests for documents of this type must specify routing.
+func (t TenantDoc) RoutingRequired() bool {
+	return true
+}
+
+type TenantDocMeta struct {
+	Tags *[]string `json:"tags"`
+}
+
+func (t TenantDocMeta) ToRaw() TenantDocMetaRaw {
+	return TenantDocMetaRaw{
+		Tags: estype.NewField(t.Tags),
+	}
+}
@@ -0,0 +1,41 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// TenantDocQuery is a set of typed query helpers for fields of TenantDoc.
+type TenantDocQuery struct {
+	Body   esquery.TextField[string]
+	Meta   TenantDocMetaQuery
+	Tenant esquery.KeywordField[string]
+	Title  esquery.TextField[string]
+}
+
+// NewTenantDocQuery returns TenantDocQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewTenantDocQuery(prefix string) TenantDocQuery {
+	return TenantDocQuery{
+		Body:   esquery.NewTextField[string](esquery.JoinPath(prefix, "body")),
+		Meta:   NewTenantDocMetaQuery(esquery.JoinPath(prefix, "meta")),
+		Tenant: esquery.NewKeywordField[string](esquery.JoinPath(prefix, "tenant")),
+		Title:  esquery.NewTextField[string](esquery.JoinPath(prefix, "title")),
+	}
+}
+
+// TenantDocMetaQuery is a set of typed query helpers for fields of TenantDocMeta.
+type TenantDocMetaQuery struct {
+	esquery.ObjectField
+	Secret esquery.KeywordField[string]
+	Tags   esquery.KeywordField[string]
+}
+
+// NewTenantDocMetaQuery returns TenantDocMetaQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewTenantDocMetaQuery(prefix string) TenantDocMetaQuery {
+	return TenantDocMetaQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		Secret:      esquery.NewKeywordField[string](esquery.JoinPath(prefix, "secret")),
+		Tags:        esquery.NewKeywordField[string](esquery.JoinPath(prefix, "tags")),
+	}
+}
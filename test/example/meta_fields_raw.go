@@ -0,0 +1,64 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type TenantDocRaw struct {
+	Meta   estype.Field[TenantDocMetaRaw] `json:"meta"`
+	Tenant estype.Field[string]           `json:"tenant"`
+	Title  estype.Field[string]           `json:"title"`
+}
+
+func (r TenantDocRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysTenantDocRaw = estype.ObjectKeys{
+	"meta":   true,
+	"tenant": false,
+	"title":  false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *TenantDocRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysTenantDocRaw)
+	if err != nil {
+		return err
+	}
+	type plain TenantDocRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t TenantDocRaw) ToPlain() TenantDoc {
+	return TenantDoc{
+		Meta: estype.MapField(t.Meta, func(v TenantDocMetaRaw) TenantDocMeta {
+			return v.ToPlain()
+		}).Value(),
+		Tenant: t.Tenant.Value(),
+		Title:  t.Title.Value(),
+	}
+}
+
+// RoutingRequired reports whether _routing.required is true in the mapping.
+// If true, index, get, update and delete requests for documents of this type must specify routing.
+func (t TenantDocRaw) RoutingRequired() bool {
+	return true
+}
+
+type TenantDocMetaRaw struct {
+	Tags estype.Field[string] `json:"tags"`
+}
+
+func (r TenantDocMetaRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t TenantDocMetaRaw) ToPlain() TenantDocMeta {
+	return TenantDocMeta{
+		Tags: t.Tags.Value(),
+	}
+}
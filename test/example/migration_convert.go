@@ -0,0 +1,59 @@
+package example
+
+import (
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+// ConvertMigrationV1 converts MigrationV1 into MigrationV2 through their raw types.
+func ConvertMigrationV1(in MigrationV1) MigrationV2 {
+	return ConvertMigrationV1Raw(in.ToRaw()).ToPlain()
+}
+
+// ConvertMigrationV1Raw converts MigrationV1Raw into MigrationV2Raw.
+//
+// Fields below are not converted:
+//   - ratio: not convertible from double (float64) to float (float32)
+//   - tags: no field in the old type
+//   - legacy: no field in the new type, dropped
+func ConvertMigrationV1Raw(in MigrationV1Raw) MigrationV2Raw {
+	return MigrationV2Raw{
+		Attrs:  estype.MapField(in.Attrs, ConvertMigrationV1AttrsRaw),
+		Author: estype.MapField(in.Author, ConvertMigrationV1AuthorRaw),
+		Count:  estype.MapField(in.Count, func(v int32) int64 { return int64(v) }),
+		Created: estype.MapField(in.Created, func(v MigrationV1Created) estype.StrictDateOptionalTimeNanosEpochMillis {
+			return estype.StrictDateOptionalTimeNanosEpochMillis(v)
+		}),
+		Title: in.Title,
+	}
+}
+
+// ConvertMigrationV1AttrsRaw converts MigrationV1AttrsRaw into MigrationV2AttrsRaw.
+// Fields matched to none of dynamic templates are carried over as is.
+//
+// Fields below are not converted:
+//   - attrs (dynamic template flags): no dynamic template in the old type
+//   - attrs (dynamic template labels): no dynamic template in the new type, dropped
+func ConvertMigrationV1AttrsRaw(in MigrationV1AttrsRaw) MigrationV2AttrsRaw {
+	out := MigrationV2AttrsRaw{Other: in.Other}
+	if in.Timestamps != nil {
+		out.Timestamps = make(map[string]estype.Field[MigrationV2Timestamps], len(in.Timestamps))
+		for k, v := range in.Timestamps {
+			out.Timestamps[k] = estype.MapField(v, func(v MigrationV1Timestamps) MigrationV2Timestamps { return MigrationV2Timestamps(v) })
+		}
+	}
+	if in.Counts != nil {
+		out.Counts = make(map[string]estype.Field[int64], len(in.Counts))
+		for k, v := range in.Counts {
+			out.Counts[k] = estype.MapField(v, func(v int32) int64 { return int64(v) })
+		}
+	}
+	return out
+}
+
+// ConvertMigrationV1AuthorRaw converts MigrationV1AuthorRaw into MigrationV2AuthorRaw.
+func ConvertMigrationV1AuthorRaw(in MigrationV1AuthorRaw) MigrationV2AuthorRaw {
+	return MigrationV2AuthorRaw{
+		Age:  estype.MapField(in.Age, func(v int16) int32 { return int32(v) }),
+		Name: in.Name,
+	}
+}
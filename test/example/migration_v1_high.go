@@ -0,0 +1,124 @@
+package example
+
+import (
+	"encoding/json"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/flextime"
+	typeparamcommon "github.com/ngicks/type-param-common"
+)
+
+type MigrationV1 struct {
+	Attrs   *[]MigrationV1Attrs   `json:"attrs"`
+	Author  *[]MigrationV1Author  `json:"author"`
+	Count   *[]int32              `json:"count"`
+	Created *[]MigrationV1Created `json:"created"`
+	Legacy  *[]string             `json:"legacy"`
+	Ratio   *[]float64            `json:"ratio"`
+	Title   *[]string             `json:"title"`
+}
+
+func (t MigrationV1) ToRaw() MigrationV1Raw {
+	return MigrationV1Raw{
+		Attrs: estype.MapField(estype.NewField(t.Attrs), func(v MigrationV1Attrs) MigrationV1AttrsRaw {
+			return v.ToRaw()
+		}),
+		Author: estype.MapField(estype.NewField(t.Author), func(v MigrationV1Author) MigrationV1AuthorRaw {
+			return v.ToRaw()
+		}),
+		Count:   estype.NewField(t.Count),
+		Created: estype.NewField(t.Created),
+		Legacy:  estype.NewField(t.Legacy),
+		Ratio:   estype.NewField(t.Ratio),
+		Title:   estype.NewField(t.Title),
+	}
+}
+
+type MigrationV1Attrs struct {
+	// Timestamps holds fields matched to the dynamic template "timestamps".
+	Timestamps map[string][]MigrationV1Timestamps
+	// Counts holds fields matched to the dynamic template "counts".
+	Counts map[string][]int32
+	// Labels holds fields matched to the dynamic template "labels".
+	Labels map[string][]string
+	// Other holds fields matched to none of dynamic templates with a statically known type.
+	Other map[string][]any
+}
+
+func (t MigrationV1Attrs) ToRaw() MigrationV1AttrsRaw {
+	return MigrationV1AttrsRaw{
+		Timestamps: estype.DynamicFieldsToRaw(t.Timestamps),
+		Counts:     estype.DynamicFieldsToRaw(t.Counts),
+		Labels:     estype.DynamicFieldsToRaw(t.Labels),
+		Other:      estype.DynamicFieldsToRaw(t.Other),
+	}
+}
+
+// MigrationV1Timestamps represents elasticsearch date.
+type MigrationV1Timestamps time.Time
+
+func (t MigrationV1Timestamps) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserMigrationV1Timestamps = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006-01-02 15:04:05`)),
+)
+
+func (t *MigrationV1Timestamps) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserMigrationV1Timestamps.Parse,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	*t = MigrationV1Timestamps(tt)
+	return nil
+}
+
+func (t MigrationV1Timestamps) String() string {
+	return time.Time(t).Format(`2006-01-02 15:04:05`)
+}
+
+type MigrationV1Author struct {
+	Age  *[]int16  `json:"age"`
+	Name *[]string `json:"name"`
+}
+
+func (t MigrationV1Author) ToRaw() MigrationV1AuthorRaw {
+	return MigrationV1AuthorRaw{
+		Age:  estype.NewField(t.Age),
+		Name: estype.NewField(t.Name),
+	}
+}
+
+// MigrationV1Created represents elasticsearch date.
+type MigrationV1Created time.Time
+
+func (t MigrationV1Created) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserMigrationV1Created = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006-01-02 15:04:05`)),
+)
+
+func (t *MigrationV1Created) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserMigrationV1Created.Parse,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	*t = MigrationV1Created(tt)
+	return nil
+}
+
+func (t MigrationV1Created) String() string {
+	return time.Time(t).Format(`2006-01-02 15:04:05`)
+}
@@ -0,0 +1,141 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type MigrationV1Raw struct {
+	Attrs   estype.Field[MigrationV1AttrsRaw]  `json:"attrs"`
+	Author  estype.Field[MigrationV1AuthorRaw] `json:"author"`
+	Count   estype.Field[int32]                `json:"count"`
+	Created estype.Field[MigrationV1Created]   `json:"created"`
+	Legacy  estype.Field[string]               `json:"legacy"`
+	Ratio   estype.Field[float64]              `json:"ratio"`
+	Title   estype.Field[string]               `json:"title"`
+}
+
+func (r MigrationV1Raw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysMigrationV1Raw = estype.ObjectKeys{
+	"attrs":   true,
+	"author":  true,
+	"count":   false,
+	"created": false,
+	"legacy":  false,
+	"ratio":   false,
+	"title":   false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *MigrationV1Raw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysMigrationV1Raw)
+	if err != nil {
+		return err
+	}
+	type plain MigrationV1Raw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t MigrationV1Raw) ToPlain() MigrationV1 {
+	return MigrationV1{
+		Attrs: estype.MapField(t.Attrs, func(v MigrationV1AttrsRaw) MigrationV1Attrs {
+			return v.ToPlain()
+		}).Value(),
+		Author: estype.MapField(t.Author, func(v MigrationV1AuthorRaw) MigrationV1Author {
+			return v.ToPlain()
+		}).Value(),
+		Count:   t.Count.Value(),
+		Created: t.Created.Value(),
+		Legacy:  t.Legacy.Value(),
+		Ratio:   t.Ratio.Value(),
+		Title:   t.Title.Value(),
+	}
+}
+
+type MigrationV1AttrsRaw struct {
+	Timestamps map[string]estype.Field[MigrationV1Timestamps]
+	Counts     map[string]estype.Field[int32]
+	Labels     map[string]estype.Field[string]
+	Other      map[string]estype.Field[any]
+}
+
+var dynamicTemplatesMigrationV1Attrs = estype.DynamicTemplateTable{
+	Matchers: []estype.DynamicTemplateMatcher{
+		{
+			Name:  "timestamps",
+			Match: []string{"*_at"},
+		},
+		{
+			Name:  "counts",
+			Match: []string{"*_count"},
+		},
+		{
+			Name:  "labels",
+			Match: []string{"label_*"},
+		},
+	},
+}
+
+func (r MigrationV1AttrsRaw) MarshalJSON() ([]byte, error) {
+	out := map[string]any{}
+	estype.CollectDynamicFields(out, r.Timestamps)
+	estype.CollectDynamicFields(out, r.Counts)
+	estype.CollectDynamicFields(out, r.Labels)
+	estype.CollectDynamicFields(out, r.Other)
+	return json.Marshal(out)
+}
+
+func (r *MigrationV1AttrsRaw) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*r = MigrationV1AttrsRaw{}
+	for name, value := range fields {
+		var err error
+		switch dynamicTemplatesMigrationV1Attrs.Match("attrs"+"."+name, name, value) {
+		case 0:
+			err = estype.UnmarshalDynamicField(&r.Timestamps, name, value)
+		case 1:
+			err = estype.UnmarshalDynamicField(&r.Counts, name, value)
+		case 2:
+			err = estype.UnmarshalDynamicField(&r.Labels, name, value)
+		default:
+			err = estype.UnmarshalDynamicField(&r.Other, name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t MigrationV1AttrsRaw) ToPlain() MigrationV1Attrs {
+	return MigrationV1Attrs{
+		Timestamps: estype.DynamicFieldsToPlain(t.Timestamps),
+		Counts:     estype.DynamicFieldsToPlain(t.Counts),
+		Labels:     estype.DynamicFieldsToPlain(t.Labels),
+		Other:      estype.DynamicFieldsToPlain(t.Other),
+	}
+}
+
+type MigrationV1AuthorRaw struct {
+	Age  estype.Field[int16]  `json:"age"`
+	Name estype.Field[string] `json:"name"`
+}
+
+func (r MigrationV1AuthorRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t MigrationV1AuthorRaw) ToPlain() MigrationV1Author {
+	return MigrationV1Author{
+		Age:  t.Age.Value(),
+		Name: t.Name.Value(),
+	}
+}
@@ -0,0 +1,96 @@
+package example
+
+import (
+	"encoding/json"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/flextime"
+	typeparamcommon "github.com/ngicks/type-param-common"
+)
+
+type MigrationV2 struct {
+	Attrs   *[]MigrationV2Attrs                              `json:"attrs"`
+	Author  *[]MigrationV2Author                             `json:"author"`
+	Count   *[]int64                                         `json:"count"`
+	Created *[]estype.StrictDateOptionalTimeNanosEpochMillis `json:"created"`
+	Ratio   *[]float32                                       `json:"ratio"`
+	Tags    *[]string                                        `json:"tags"`
+	Title   *[]string                                        `json:"title"`
+}
+
+func (t MigrationV2) ToRaw() MigrationV2Raw {
+	return MigrationV2Raw{
+		Attrs: estype.MapField(estype.NewField(t.Attrs), func(v MigrationV2Attrs) MigrationV2AttrsRaw {
+			return v.ToRaw()
+		}),
+		Author: estype.MapField(estype.NewField(t.Author), func(v MigrationV2Author) MigrationV2AuthorRaw {
+			return v.ToRaw()
+		}),
+		Count:   estype.NewField(t.Count),
+		Created: estype.NewField(t.Created),
+		Ratio:   estype.NewField(t.Ratio),
+		Tags:    estype.NewField(t.Tags),
+		Title:   estype.NewField(t.Title),
+	}
+}
+
+type MigrationV2Attrs struct {
+	// Timestamps holds fields matched to the dynamic template "timestamps".
+	Timestamps map[string][]MigrationV2Timestamps
+	// Counts holds fields matched to the dynamic template "counts".
+	Counts map[string][]int64
+	// Flags holds fields matched to the dynamic template "flags".
+	Flags map[string][]estype.Boolean
+	// Other holds fields matched to none of dynamic templates with a statically known type.
+	Other map[string][]any
+}
+
+func (t MigrationV2Attrs) ToRaw() MigrationV2AttrsRaw {
+	return MigrationV2AttrsRaw{
+		Timestamps: estype.DynamicFieldsToRaw(t.Timestamps),
+		Counts:     estype.DynamicFieldsToRaw(t.Counts),
+		Flags:      estype.DynamicFieldsToRaw(t.Flags),
+		Other:      estype.DynamicFieldsToRaw(t.Other),
+	}
+}
+
+// MigrationV2Timestamps represents elasticsearch date.
+type MigrationV2Timestamps time.Time
+
+func (t MigrationV2Timestamps) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserMigrationV2Timestamps = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006/01/02 15:04:05`)),
+)
+
+func (t *MigrationV2Timestamps) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserMigrationV2Timestamps.Parse,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	*t = MigrationV2Timestamps(tt)
+	return nil
+}
+
+func (t MigrationV2Timestamps) String() string {
+	return time.Time(t).Format(`2006/01/02 15:04:05`)
+}
+
+type MigrationV2Author struct {
+	Age  *[]int32  `json:"age"`
+	Name *[]string `json:"name"`
+}
+
+func (t MigrationV2Author) ToRaw() MigrationV2AuthorRaw {
+	return MigrationV2AuthorRaw{
+		Age:  estype.NewField(t.Age),
+		Name: estype.NewField(t.Name),
+	}
+}
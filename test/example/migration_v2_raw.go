@@ -0,0 +1,141 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type MigrationV2Raw struct {
+	Attrs   estype.Field[MigrationV2AttrsRaw]                           `json:"attrs"`
+	Author  estype.Field[MigrationV2AuthorRaw]                          `json:"author"`
+	Count   estype.Field[int64]                                         `json:"count"`
+	Created estype.Field[estype.StrictDateOptionalTimeNanosEpochMillis] `json:"created"`
+	Ratio   estype.Field[float32]                                       `json:"ratio"`
+	Tags    estype.Field[string]                                        `json:"tags"`
+	Title   estype.Field[string]                                        `json:"title"`
+}
+
+func (r MigrationV2Raw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysMigrationV2Raw = estype.ObjectKeys{
+	"attrs":   true,
+	"author":  true,
+	"count":   false,
+	"created": false,
+	"ratio":   false,
+	"tags":    false,
+	"title":   false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *MigrationV2Raw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysMigrationV2Raw)
+	if err != nil {
+		return err
+	}
+	type plain MigrationV2Raw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t MigrationV2Raw) ToPlain() MigrationV2 {
+	return MigrationV2{
+		Attrs: estype.MapField(t.Attrs, func(v MigrationV2AttrsRaw) MigrationV2Attrs {
+			return v.ToPlain()
+		}).Value(),
+		Author: estype.MapField(t.Author, func(v MigrationV2AuthorRaw) MigrationV2Author {
+			return v.ToPlain()
+		}).Value(),
+		Count:   t.Count.Value(),
+		Created: t.Created.Value(),
+		Ratio:   t.Ratio.Value(),
+		Tags:    t.Tags.Value(),
+		Title:   t.Title.Value(),
+	}
+}
+
+type MigrationV2AttrsRaw struct {
+	Timestamps map[string]estype.Field[MigrationV2Timestamps]
+	Counts     map[string]estype.Field[int64]
+	Flags      map[string]estype.Field[estype.Boolean]
+	Other      map[string]estype.Field[any]
+}
+
+var dynamicTemplatesMigrationV2Attrs = estype.DynamicTemplateTable{
+	Matchers: []estype.DynamicTemplateMatcher{
+		{
+			Name:  "timestamps",
+			Match: []string{"*_at"},
+		},
+		{
+			Name:  "counts",
+			Match: []string{"*_count"},
+		},
+		{
+			Name:  "flags",
+			Match: []string{"is_*"},
+		},
+	},
+}
+
+func (r MigrationV2AttrsRaw) MarshalJSON() ([]byte, error) {
+	out := map[string]any{}
+	estype.CollectDynamicFields(out, r.Timestamps)
+	estype.CollectDynamicFields(out, r.Counts)
+	estype.CollectDynamicFields(out, r.Flags)
+	estype.CollectDynamicFields(out, r.Other)
+	return json.Marshal(out)
+}
+
+func (r *MigrationV2AttrsRaw) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*r = MigrationV2AttrsRaw{}
+	for name, value := range fields {
+		var err error
+		switch dynamicTemplatesMigrationV2Attrs.Match("attrs"+"."+name, name, value) {
+		case 0:
+			err = estype.UnmarshalDynamicField(&r.Timestamps, name, value)
+		case 1:
+			err = estype.UnmarshalDynamicField(&r.Counts, name, value)
+		case 2:
+			err = estype.UnmarshalDynamicField(&r.Flags, name, value)
+		default:
+			err = estype.UnmarshalDynamicField(&r.Other, name, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t MigrationV2AttrsRaw) ToPlain() MigrationV2Attrs {
+	return MigrationV2Attrs{
+		Timestamps: estype.DynamicFieldsToPlain(t.Timestamps),
+		Counts:     estype.DynamicFieldsToPlain(t.Counts),
+		Flags:      estype.DynamicFieldsToPlain(t.Flags),
+		Other:      estype.DynamicFieldsToPlain(t.Other),
+	}
+}
+
+type MigrationV2AuthorRaw struct {
+	Age  estype.Field[int32]  `json:"age"`
+	Name estype.Field[string] `json:"name"`
+}
+
+func (r MigrationV2AuthorRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t MigrationV2AuthorRaw) ToPlain() MigrationV2Author {
+	return MigrationV2Author{
+		Age:  t.Age.Value(),
+		Name: t.Name.Value(),
+	}
+}
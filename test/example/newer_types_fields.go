@@ -0,0 +1,62 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// NewerTypesFieldPaths is a tree of field paths of NewerTypes.
+type NewerTypesFieldPaths struct {
+	Annotated  esquery.FieldPath
+	Area       esquery.FieldPath
+	Attributes NewerTypesAttributesFieldPaths
+	Embedding  esquery.FieldPath
+	Message    NewerTypesMessageSubFieldPaths
+	Summary    esquery.FieldPath
+	Tags       esquery.FieldPath
+	Tokens     esquery.FieldPath
+}
+
+// NewNewerTypesFieldPaths returns NewerTypesFieldPaths whose paths are prefixed with prefix.
+func NewNewerTypesFieldPaths(prefix string) NewerTypesFieldPaths {
+	return NewerTypesFieldPaths{
+		Annotated:  esquery.NewFieldPath(esquery.JoinPath(prefix, "annotated"), "annotated_text"),
+		Area:       esquery.NewFieldPath(esquery.JoinPath(prefix, "area"), "geo_shape"),
+		Attributes: NewNewerTypesAttributesFieldPaths(esquery.JoinPath(prefix, "attributes")),
+		Embedding:  esquery.NewFieldPath(esquery.JoinPath(prefix, "embedding"), "dense_vector"),
+		Message:    NewNewerTypesMessageSubFieldPaths(esquery.JoinPath(prefix, "message")),
+		Summary:    esquery.NewFieldPath(esquery.JoinPath(prefix, "summary"), "semantic_text"),
+		Tags:       esquery.NewFieldPath(esquery.JoinPath(prefix, "tags"), "counted_keyword"),
+		Tokens:     esquery.NewFieldPath(esquery.JoinPath(prefix, "tokens"), "sparse_vector"),
+	}
+}
+
+// NewerTypesAttributesFieldPaths is a tree of field paths of NewerTypesAttributes.
+type NewerTypesAttributesFieldPaths struct {
+	esquery.FieldPath
+	Host esquery.FieldPath
+}
+
+// NewNewerTypesAttributesFieldPaths returns NewerTypesAttributesFieldPaths whose paths are prefixed with prefix.
+func NewNewerTypesAttributesFieldPaths(prefix string) NewerTypesAttributesFieldPaths {
+	return NewerTypesAttributesFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "passthrough"),
+		Host:      esquery.NewFieldPath(esquery.JoinPath(prefix, "host"), "keyword"),
+	}
+}
+
+// NewerTypesMessageSubFieldPaths is a tree of field paths of NewerTypesMessageSub.
+type NewerTypesMessageSubFieldPaths struct {
+	esquery.FieldPath
+	Raw esquery.FieldPath
+}
+
+// NewNewerTypesMessageSubFieldPaths returns NewerTypesMessageSubFieldPaths whose paths are prefixed with prefix.
+func NewNewerTypesMessageSubFieldPaths(prefix string) NewerTypesMessageSubFieldPaths {
+	return NewerTypesMessageSubFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "match_only_text"),
+		Raw:       esquery.NewFieldPath(esquery.JoinPath(prefix, "raw"), "keyword"),
+	}
+}
+
+// NewerTypesFields is the field path tree of NewerTypes.
+var NewerTypesFields = NewNewerTypesFieldPaths("")
@@ -0,0 +1,70 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type NewerTypes struct {
+	Annotated  *[]string               `json:"annotated"`
+	Area       *[]estype.Geoshape      `json:"area"`
+	Attributes *[]NewerTypesAttributes `json:"attributes"`
+	Embedding  *[]NewerTypesEmbedding  `json:"embedding"`
+	Message    *[]string               `json:"message"`
+	Summary    *[]string               `json:"summary"`
+	Tags       *[]string               `json:"tags"`
+	Tokens     *[]map[string]float64   `json:"tokens"`
+}
+
+func (t NewerTypes) ToRaw() NewerTypesRaw {
+	return NewerTypesRaw{
+		Annotated: estype.NewField(t.Annotated),
+		Area:      estype.NewField(t.Area),
+		Attributes: estype.MapField(estype.NewField(t.Attributes), func(v NewerTypesAttributes) NewerTypesAttributesRaw {
+			return v.ToRaw()
+		}),
+		Embedding: estype.NewField(t.Embedding),
+		Message:   estype.NewField(t.Message),
+		Summary:   estype.NewField(t.Summary),
+		Tags:      estype.NewField(t.Tags),
+		Tokens:    estype.NewField(t.Tokens),
+	}
+}
+
+type NewerTypesAttributes struct {
+	Host *[]string `json:"host"`
+}
+
+func (t NewerTypesAttributes) ToRaw() NewerTypesAttributesRaw {
+	return NewerTypesAttributesRaw{
+		Host: estype.NewField(t.Host),
+	}
+}
+
+// NewerTypesEmbedding is a value of the dense_vector field of 3 byte elements, compared by cosine.
+type NewerTypesEmbedding []int8
+
+func (v NewerTypesEmbedding) MarshalJSON() ([]byte, error) {
+	if err := estype.CheckByteVector(v, 3, "cosine"); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]int8(v))
+}
+
+func (v *NewerTypesEmbedding) UnmarshalJSON(data []byte) error {
+	vec, err := estype.UnmarshalByteVectorJSON(data)
+	if err != nil {
+		return err
+	}
+	if err := estype.CheckByteVector(vec, 3, "cosine"); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// Score returns _score of kNN search for v as a document vector, computed by cosine as Elasticsearch does.
+func (v NewerTypesEmbedding) Score(query NewerTypesEmbedding) (float32, error) {
+	return estype.ByteVectorScore("cosine", query, v)
+}
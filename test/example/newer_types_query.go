@@ -0,0 +1,47 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// NewerTypesQuery is a set of typed query helpers for fields of NewerTypes.
+type NewerTypesQuery struct {
+	Annotated  esquery.TextField[string]
+	Area       esquery.ExistsField
+	Attributes NewerTypesAttributesQuery
+	Embedding  esquery.ExistsField
+	Message    esquery.TextField[string]
+	Summary    esquery.ExistsField
+	Tags       esquery.KeywordField[string]
+	Tokens     esquery.ExistsField
+}
+
+// NewNewerTypesQuery returns NewerTypesQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewNewerTypesQuery(prefix string) NewerTypesQuery {
+	return NewerTypesQuery{
+		Annotated:  esquery.NewTextField[string](esquery.JoinPath(prefix, "annotated")),
+		Area:       esquery.NewExistsField(esquery.JoinPath(prefix, "area")),
+		Attributes: NewNewerTypesAttributesQuery(esquery.JoinPath(prefix, "attributes")),
+		Embedding:  esquery.NewExistsField(esquery.JoinPath(prefix, "embedding")),
+		Message:    esquery.NewTextField[string](esquery.JoinPath(prefix, "message")),
+		Summary:    esquery.NewExistsField(esquery.JoinPath(prefix, "summary")),
+		Tags:       esquery.NewKeywordField[string](esquery.JoinPath(prefix, "tags")),
+		Tokens:     esquery.NewExistsField(esquery.JoinPath(prefix, "tokens")),
+	}
+}
+
+// NewerTypesAttributesQuery is a set of typed query helpers for fields of NewerTypesAttributes.
+type NewerTypesAttributesQuery struct {
+	esquery.ObjectField
+	Host esquery.KeywordField[string]
+}
+
+// NewNewerTypesAttributesQuery returns NewerTypesAttributesQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewNewerTypesAttributesQuery(prefix string) NewerTypesAttributesQuery {
+	return NewerTypesAttributesQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		Host:        esquery.NewKeywordField[string](esquery.JoinPath(prefix, "host")),
+	}
+}
@@ -0,0 +1,73 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type NewerTypesRaw struct {
+	Annotated  estype.Field[string]                  `json:"annotated"`
+	Area       estype.Field[estype.Geoshape]         `json:"area"`
+	Attributes estype.Field[NewerTypesAttributesRaw] `json:"attributes"`
+	Embedding  estype.Field[NewerTypesEmbedding]     `json:"embedding"`
+	Message    estype.Field[string]                  `json:"message"`
+	Summary    estype.Field[string]                  `json:"summary"`
+	Tags       estype.Field[string]                  `json:"tags"`
+	Tokens     estype.Field[map[string]float64]      `json:"tokens"`
+}
+
+func (r NewerTypesRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysNewerTypesRaw = estype.ObjectKeys{
+	"annotated":  false,
+	"area":       false,
+	"attributes": true,
+	"embedding":  false,
+	"message":    false,
+	"summary":    false,
+	"tags":       false,
+	"tokens":     false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *NewerTypesRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysNewerTypesRaw)
+	if err != nil {
+		return err
+	}
+	type plain NewerTypesRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t NewerTypesRaw) ToPlain() NewerTypes {
+	return NewerTypes{
+		Annotated: t.Annotated.Value(),
+		Area:      t.Area.Value(),
+		Attributes: estype.MapField(t.Attributes, func(v NewerTypesAttributesRaw) NewerTypesAttributes {
+			return v.ToPlain()
+		}).Value(),
+		Embedding: t.Embedding.Value(),
+		Message:   t.Message.Value(),
+		Summary:   t.Summary.Value(),
+		Tags:      t.Tags.Value(),
+		Tokens:    t.Tokens.Value(),
+	}
+}
+
+type NewerTypesAttributesRaw struct {
+	Host estype.Field[string] `json:"host"`
+}
+
+func (r NewerTypesAttributesRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t NewerTypesAttributesRaw) ToPlain() NewerTypesAttributes {
+	return NewerTypesAttributes{
+		Host: t.Host.Value(),
+	}
+}
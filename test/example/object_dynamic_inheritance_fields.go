@@ -0,0 +1,54 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// ObjectDynamicInheritanceFieldPaths is a tree of field paths of ObjectDynamicInheritance.
+type ObjectDynamicInheritanceFieldPaths struct {
+	Manager ObjectDynamicInheritanceManagerFieldPaths
+	Player  esquery.FieldPath
+}
+
+// NewObjectDynamicInheritanceFieldPaths returns ObjectDynamicInheritanceFieldPaths whose paths are prefixed with prefix.
+func NewObjectDynamicInheritanceFieldPaths(prefix string) ObjectDynamicInheritanceFieldPaths {
+	return ObjectDynamicInheritanceFieldPaths{
+		Manager: NewObjectDynamicInheritanceManagerFieldPaths(esquery.JoinPath(prefix, "manager")),
+		Player:  esquery.NewFieldPath(esquery.JoinPath(prefix, "player"), "object"),
+	}
+}
+
+// ObjectDynamicInheritanceManagerFieldPaths is a tree of field paths of ObjectDynamicInheritanceManager.
+type ObjectDynamicInheritanceManagerFieldPaths struct {
+	esquery.FieldPath
+	Age  esquery.FieldPath
+	Name ObjectDynamicInheritanceNameFieldPaths
+}
+
+// NewObjectDynamicInheritanceManagerFieldPaths returns ObjectDynamicInheritanceManagerFieldPaths whose paths are prefixed with prefix.
+func NewObjectDynamicInheritanceManagerFieldPaths(prefix string) ObjectDynamicInheritanceManagerFieldPaths {
+	return ObjectDynamicInheritanceManagerFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		Age:       esquery.NewFieldPath(esquery.JoinPath(prefix, "age"), "integer"),
+		Name:      NewObjectDynamicInheritanceNameFieldPaths(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// ObjectDynamicInheritanceNameFieldPaths is a tree of field paths of ObjectDynamicInheritanceName.
+type ObjectDynamicInheritanceNameFieldPaths struct {
+	esquery.FieldPath
+	First esquery.FieldPath
+	Last  esquery.FieldPath
+}
+
+// NewObjectDynamicInheritanceNameFieldPaths returns ObjectDynamicInheritanceNameFieldPaths whose paths are prefixed with prefix.
+func NewObjectDynamicInheritanceNameFieldPaths(prefix string) ObjectDynamicInheritanceNameFieldPaths {
+	return ObjectDynamicInheritanceNameFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		First:     esquery.NewFieldPath(esquery.JoinPath(prefix, "first"), "text"),
+		Last:      esquery.NewFieldPath(esquery.JoinPath(prefix, "last"), "text"),
+	}
+}
+
+// ObjectDynamicInheritanceFields is the field path tree of ObjectDynamicInheritance.
+var ObjectDynamicInheritanceFields = NewObjectDynamicInheritanceFieldPaths("")
@@ -0,0 +1,54 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// ObjectDynamicInheritanceQuery is a set of typed query helpers for fields of ObjectDynamicInheritance.
+type ObjectDynamicInheritanceQuery struct {
+	Manager ObjectDynamicInheritanceManagerQuery
+	Player  esquery.ObjectField
+}
+
+// NewObjectDynamicInheritanceQuery returns ObjectDynamicInheritanceQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectDynamicInheritanceQuery(prefix string) ObjectDynamicInheritanceQuery {
+	return ObjectDynamicInheritanceQuery{
+		Manager: NewObjectDynamicInheritanceManagerQuery(esquery.JoinPath(prefix, "manager")),
+		Player:  esquery.NewObjectField(esquery.JoinPath(prefix, "player")),
+	}
+}
+
+// ObjectDynamicInheritanceManagerQuery is a set of typed query helpers for fields of ObjectDynamicInheritanceManager.
+type ObjectDynamicInheritanceManagerQuery struct {
+	esquery.ObjectField
+	Age  esquery.RangeField[int32]
+	Name ObjectDynamicInheritanceNameQuery
+}
+
+// NewObjectDynamicInheritanceManagerQuery returns ObjectDynamicInheritanceManagerQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectDynamicInheritanceManagerQuery(prefix string) ObjectDynamicInheritanceManagerQuery {
+	return ObjectDynamicInheritanceManagerQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		Age:         esquery.NewRangeField[int32](esquery.JoinPath(prefix, "age")),
+		Name:        NewObjectDynamicInheritanceNameQuery(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// ObjectDynamicInheritanceNameQuery is a set of typed query helpers for fields of ObjectDynamicInheritanceName.
+type ObjectDynamicInheritanceNameQuery struct {
+	esquery.ObjectField
+	First esquery.TextField[string]
+	Last  esquery.TextField[string]
+}
+
+// NewObjectDynamicInheritanceNameQuery returns ObjectDynamicInheritanceNameQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectDynamicInheritanceNameQuery(prefix string) ObjectDynamicInheritanceNameQuery {
+	return ObjectDynamicInheritanceNameQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		First:       esquery.NewTextField[string](esquery.JoinPath(prefix, "first")),
+		Last:        esquery.NewTextField[string](esquery.JoinPath(prefix, "last")),
+	}
+}
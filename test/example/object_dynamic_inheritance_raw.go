@@ -1,6 +1,8 @@
 package example
 
 import (
+	"encoding/json"
+
 	estype "github.com/ngicks/elastic-type/es_type"
 )
 
@@ -13,6 +15,22 @@ func (r ObjectDynamicInheritanceRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysObjectDynamicInheritanceRaw = estype.ObjectKeys{
+	"manager": true,
+	"player":  true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *ObjectDynamicInheritanceRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysObjectDynamicInheritanceRaw)
+	if err != nil {
+		return err
+	}
+	type plain ObjectDynamicInheritanceRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t ObjectDynamicInheritanceRaw) ToPlain() ObjectDynamicInheritance {
 	return ObjectDynamicInheritance{
 		Manager: estype.MapField(t.Manager, func(v ObjectDynamicInheritanceManagerRaw) ObjectDynamicInheritanceManager {
@@ -33,6 +51,22 @@ func (r ObjectDynamicInheritanceManagerRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysObjectDynamicInheritanceManagerRaw = estype.ObjectKeys{
+	"age":  false,
+	"name": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *ObjectDynamicInheritanceManagerRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysObjectDynamicInheritanceManagerRaw)
+	if err != nil {
+		return err
+	}
+	type plain ObjectDynamicInheritanceManagerRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t ObjectDynamicInheritanceManagerRaw) ToPlain() ObjectDynamicInheritanceManager {
 	return ObjectDynamicInheritanceManager{
 		Age: t.Age.Value(),
@@ -0,0 +1,52 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// ObjectExampleFieldPaths is a tree of field paths of ObjectExample.
+type ObjectExampleFieldPaths struct {
+	Manager ObjectExampleManagerFieldPaths
+}
+
+// NewObjectExampleFieldPaths returns ObjectExampleFieldPaths whose paths are prefixed with prefix.
+func NewObjectExampleFieldPaths(prefix string) ObjectExampleFieldPaths {
+	return ObjectExampleFieldPaths{
+		Manager: NewObjectExampleManagerFieldPaths(esquery.JoinPath(prefix, "manager")),
+	}
+}
+
+// ObjectExampleManagerFieldPaths is a tree of field paths of ObjectExampleManager.
+type ObjectExampleManagerFieldPaths struct {
+	esquery.FieldPath
+	Age  esquery.FieldPath
+	Name ObjectExampleNameFieldPaths
+}
+
+// NewObjectExampleManagerFieldPaths returns ObjectExampleManagerFieldPaths whose paths are prefixed with prefix.
+func NewObjectExampleManagerFieldPaths(prefix string) ObjectExampleManagerFieldPaths {
+	return ObjectExampleManagerFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		Age:       esquery.NewFieldPath(esquery.JoinPath(prefix, "age"), "integer"),
+		Name:      NewObjectExampleNameFieldPaths(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// ObjectExampleNameFieldPaths is a tree of field paths of ObjectExampleName.
+type ObjectExampleNameFieldPaths struct {
+	esquery.FieldPath
+	First esquery.FieldPath
+	Last  esquery.FieldPath
+}
+
+// NewObjectExampleNameFieldPaths returns ObjectExampleNameFieldPaths whose paths are prefixed with prefix.
+func NewObjectExampleNameFieldPaths(prefix string) ObjectExampleNameFieldPaths {
+	return ObjectExampleNameFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		First:     esquery.NewFieldPath(esquery.JoinPath(prefix, "first"), "text"),
+		Last:      esquery.NewFieldPath(esquery.JoinPath(prefix, "last"), "text"),
+	}
+}
+
+// ObjectExampleFields is the field path tree of ObjectExample.
+var ObjectExampleFields = NewObjectExampleFieldPaths("")
@@ -0,0 +1,52 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// ObjectExampleQuery is a set of typed query helpers for fields of ObjectExample.
+type ObjectExampleQuery struct {
+	Manager ObjectExampleManagerQuery
+}
+
+// NewObjectExampleQuery returns ObjectExampleQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectExampleQuery(prefix string) ObjectExampleQuery {
+	return ObjectExampleQuery{
+		Manager: NewObjectExampleManagerQuery(esquery.JoinPath(prefix, "manager")),
+	}
+}
+
+// ObjectExampleManagerQuery is a set of typed query helpers for fields of ObjectExampleManager.
+type ObjectExampleManagerQuery struct {
+	esquery.ObjectField
+	Age  esquery.RangeField[int32]
+	Name ObjectExampleNameQuery
+}
+
+// NewObjectExampleManagerQuery returns ObjectExampleManagerQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectExampleManagerQuery(prefix string) ObjectExampleManagerQuery {
+	return ObjectExampleManagerQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		Age:         esquery.NewRangeField[int32](esquery.JoinPath(prefix, "age")),
+		Name:        NewObjectExampleNameQuery(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// ObjectExampleNameQuery is a set of typed query helpers for fields of ObjectExampleName.
+type ObjectExampleNameQuery struct {
+	esquery.ObjectField
+	First esquery.TextField[string]
+	Last  esquery.TextField[string]
+}
+
+// NewObjectExampleNameQuery returns ObjectExampleNameQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectExampleNameQuery(prefix string) ObjectExampleNameQuery {
+	return ObjectExampleNameQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		First:       esquery.NewTextField[string](esquery.JoinPath(prefix, "first")),
+		Last:        esquery.NewTextField[string](esquery.JoinPath(prefix, "last")),
+	}
+}
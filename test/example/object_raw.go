@@ -1,6 +1,8 @@
 package example
 
 import (
+	"encoding/json"
+
 	estype "github.com/ngicks/elastic-type/es_type"
 )
 
@@ -12,6 +14,21 @@ func (r ObjectExampleRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysObjectExampleRaw = estype.ObjectKeys{
+	"manager": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *ObjectExampleRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysObjectExampleRaw)
+	if err != nil {
+		return err
+	}
+	type plain ObjectExampleRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t ObjectExampleRaw) ToPlain() ObjectExample {
 	return ObjectExample{
 		Manager: estype.MapField(t.Manager, func(v ObjectExampleManagerRaw) ObjectExampleManager {
@@ -29,6 +46,22 @@ func (r ObjectExampleManagerRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysObjectExampleManagerRaw = estype.ObjectKeys{
+	"age":  false,
+	"name": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *ObjectExampleManagerRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysObjectExampleManagerRaw)
+	if err != nil {
+		return err
+	}
+	type plain ObjectExampleManagerRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t ObjectExampleManagerRaw) ToPlain() ObjectExampleManager {
 	return ObjectExampleManager{
 		Age: t.Age.ValueSingleZero(),
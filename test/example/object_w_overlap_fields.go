@@ -0,0 +1,86 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// ObjectWOverlapFieldPaths is a tree of field paths of ObjectWOverlap.
+type ObjectWOverlapFieldPaths struct {
+	Manager     ObjectWOverlapManagerFieldPaths
+	Subordinate ObjectWOverlapSubordinateFieldPaths
+}
+
+// NewObjectWOverlapFieldPaths returns ObjectWOverlapFieldPaths whose paths are prefixed with prefix.
+func NewObjectWOverlapFieldPaths(prefix string) ObjectWOverlapFieldPaths {
+	return ObjectWOverlapFieldPaths{
+		Manager:     NewObjectWOverlapManagerFieldPaths(esquery.JoinPath(prefix, "manager")),
+		Subordinate: NewObjectWOverlapSubordinateFieldPaths(esquery.JoinPath(prefix, "subordinate")),
+	}
+}
+
+// ObjectWOverlapManagerFieldPaths is a tree of field paths of ObjectWOverlapManager.
+type ObjectWOverlapManagerFieldPaths struct {
+	esquery.FieldPath
+	Age  esquery.FieldPath
+	Name ObjectWOverlapNameFieldPaths
+}
+
+// NewObjectWOverlapManagerFieldPaths returns ObjectWOverlapManagerFieldPaths whose paths are prefixed with prefix.
+func NewObjectWOverlapManagerFieldPaths(prefix string) ObjectWOverlapManagerFieldPaths {
+	return ObjectWOverlapManagerFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		Age:       esquery.NewFieldPath(esquery.JoinPath(prefix, "age"), "integer"),
+		Name:      NewObjectWOverlapNameFieldPaths(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// ObjectWOverlapNameFieldPaths is a tree of field paths of ObjectWOverlapName.
+type ObjectWOverlapNameFieldPaths struct {
+	esquery.FieldPath
+	First esquery.FieldPath
+	Last  esquery.FieldPath
+}
+
+// NewObjectWOverlapNameFieldPaths returns ObjectWOverlapNameFieldPaths whose paths are prefixed with prefix.
+func NewObjectWOverlapNameFieldPaths(prefix string) ObjectWOverlapNameFieldPaths {
+	return ObjectWOverlapNameFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		First:     esquery.NewFieldPath(esquery.JoinPath(prefix, "first"), "text"),
+		Last:      esquery.NewFieldPath(esquery.JoinPath(prefix, "last"), "text"),
+	}
+}
+
+// ObjectWOverlapSubordinateFieldPaths is a tree of field paths of ObjectWOverlapSubordinate.
+type ObjectWOverlapSubordinateFieldPaths struct {
+	esquery.FieldPath
+	Age  esquery.FieldPath
+	Name ObjectWOverlapSubordinateNameFieldPaths
+}
+
+// NewObjectWOverlapSubordinateFieldPaths returns ObjectWOverlapSubordinateFieldPaths whose paths are prefixed with prefix.
+func NewObjectWOverlapSubordinateFieldPaths(prefix string) ObjectWOverlapSubordinateFieldPaths {
+	return ObjectWOverlapSubordinateFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "nested"),
+		Age:       esquery.NewFieldPath(esquery.JoinPath(prefix, "age"), "integer"),
+		Name:      NewObjectWOverlapSubordinateNameFieldPaths(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// ObjectWOverlapSubordinateNameFieldPaths is a tree of field paths of ObjectWOverlapSubordinateName.
+type ObjectWOverlapSubordinateNameFieldPaths struct {
+	esquery.FieldPath
+	First esquery.FieldPath
+	Last  esquery.FieldPath
+}
+
+// NewObjectWOverlapSubordinateNameFieldPaths returns ObjectWOverlapSubordinateNameFieldPaths whose paths are prefixed with prefix.
+func NewObjectWOverlapSubordinateNameFieldPaths(prefix string) ObjectWOverlapSubordinateNameFieldPaths {
+	return ObjectWOverlapSubordinateNameFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		First:     esquery.NewFieldPath(esquery.JoinPath(prefix, "first"), "text"),
+		Last:      esquery.NewFieldPath(esquery.JoinPath(prefix, "last"), "text"),
+	}
+}
+
+// ObjectWOverlapFields is the field path tree of ObjectWOverlap.
+var ObjectWOverlapFields = NewObjectWOverlapFieldPaths("")
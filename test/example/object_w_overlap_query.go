@@ -0,0 +1,88 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// ObjectWOverlapQuery is a set of typed query helpers for fields of ObjectWOverlap.
+type ObjectWOverlapQuery struct {
+	Manager     ObjectWOverlapManagerQuery
+	Subordinate ObjectWOverlapSubordinateQuery
+}
+
+// NewObjectWOverlapQuery returns ObjectWOverlapQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectWOverlapQuery(prefix string) ObjectWOverlapQuery {
+	return ObjectWOverlapQuery{
+		Manager:     NewObjectWOverlapManagerQuery(esquery.JoinPath(prefix, "manager")),
+		Subordinate: NewObjectWOverlapSubordinateQuery(esquery.JoinPath(prefix, "subordinate")),
+	}
+}
+
+// ObjectWOverlapManagerQuery is a set of typed query helpers for fields of ObjectWOverlapManager.
+type ObjectWOverlapManagerQuery struct {
+	esquery.ObjectField
+	Age  esquery.RangeField[int32]
+	Name ObjectWOverlapNameQuery
+}
+
+// NewObjectWOverlapManagerQuery returns ObjectWOverlapManagerQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectWOverlapManagerQuery(prefix string) ObjectWOverlapManagerQuery {
+	return ObjectWOverlapManagerQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		Age:         esquery.NewRangeField[int32](esquery.JoinPath(prefix, "age")),
+		Name:        NewObjectWOverlapNameQuery(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// ObjectWOverlapNameQuery is a set of typed query helpers for fields of ObjectWOverlapName.
+type ObjectWOverlapNameQuery struct {
+	esquery.ObjectField
+	First esquery.TextField[string]
+	Last  esquery.TextField[string]
+}
+
+// NewObjectWOverlapNameQuery returns ObjectWOverlapNameQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectWOverlapNameQuery(prefix string) ObjectWOverlapNameQuery {
+	return ObjectWOverlapNameQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		First:       esquery.NewTextField[string](esquery.JoinPath(prefix, "first")),
+		Last:        esquery.NewTextField[string](esquery.JoinPath(prefix, "last")),
+	}
+}
+
+// ObjectWOverlapSubordinateQuery is a set of typed query helpers for fields of ObjectWOverlapSubordinate.
+type ObjectWOverlapSubordinateQuery struct {
+	esquery.NestedField
+	Age  esquery.RangeField[int32]
+	Name ObjectWOverlapSubordinateNameQuery
+}
+
+// NewObjectWOverlapSubordinateQuery returns ObjectWOverlapSubordinateQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectWOverlapSubordinateQuery(prefix string) ObjectWOverlapSubordinateQuery {
+	return ObjectWOverlapSubordinateQuery{
+		NestedField: esquery.NewNestedField(prefix),
+		Age:         esquery.NewRangeField[int32](esquery.JoinPath(prefix, "age")),
+		Name:        NewObjectWOverlapSubordinateNameQuery(esquery.JoinPath(prefix, "name")),
+	}
+}
+
+// ObjectWOverlapSubordinateNameQuery is a set of typed query helpers for fields of ObjectWOverlapSubordinateName.
+type ObjectWOverlapSubordinateNameQuery struct {
+	esquery.ObjectField
+	First esquery.TextField[string]
+	Last  esquery.TextField[string]
+}
+
+// NewObjectWOverlapSubordinateNameQuery returns ObjectWOverlapSubordinateNameQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewObjectWOverlapSubordinateNameQuery(prefix string) ObjectWOverlapSubordinateNameQuery {
+	return ObjectWOverlapSubordinateNameQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		First:       esquery.NewTextField[string](esquery.JoinPath(prefix, "first")),
+		Last:        esquery.NewTextField[string](esquery.JoinPath(prefix, "last")),
+	}
+}
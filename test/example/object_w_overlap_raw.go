@@ -1,6 +1,8 @@
 package example
 
 import (
+	"encoding/json"
+
 	estype "github.com/ngicks/elastic-type/es_type"
 )
 
@@ -13,6 +15,22 @@ func (r ObjectWOverlapRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysObjectWOverlapRaw = estype.ObjectKeys{
+	"manager":     true,
+	"subordinate": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *ObjectWOverlapRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysObjectWOverlapRaw)
+	if err != nil {
+		return err
+	}
+	type plain ObjectWOverlapRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t ObjectWOverlapRaw) ToPlain() ObjectWOverlap {
 	return ObjectWOverlap{
 		Manager: estype.MapField(t.Manager, func(v ObjectWOverlapManagerRaw) ObjectWOverlapManager {
@@ -33,6 +51,22 @@ func (r ObjectWOverlapManagerRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysObjectWOverlapManagerRaw = estype.ObjectKeys{
+	"age":  false,
+	"name": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *ObjectWOverlapManagerRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysObjectWOverlapManagerRaw)
+	if err != nil {
+		return err
+	}
+	type plain ObjectWOverlapManagerRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t ObjectWOverlapManagerRaw) ToPlain() ObjectWOverlapManager {
 	return ObjectWOverlapManager{
 		Age: t.Age.Value(),
@@ -67,6 +101,22 @@ func (r ObjectWOverlapSubordinateRaw) MarshalJSON() ([]byte, error) {
 	return estype.MarshalFieldsJSON(r)
 }
 
+var keysObjectWOverlapSubordinateRaw = estype.ObjectKeys{
+	"age":  false,
+	"name": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *ObjectWOverlapSubordinateRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysObjectWOverlapSubordinateRaw)
+	if err != nil {
+		return err
+	}
+	type plain ObjectWOverlapSubordinateRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 func (t ObjectWOverlapSubordinateRaw) ToPlain() ObjectWOverlapSubordinate {
 	return ObjectWOverlapSubordinate{
 		Age: t.Age.Value(),
@@ -0,0 +1,28 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// RangeDocFieldPaths is a tree of field paths of RangeDoc.
+type RangeDocFieldPaths struct {
+	Age     esquery.FieldPath
+	Network esquery.FieldPath
+	Period  esquery.FieldPath
+	Price   esquery.FieldPath
+	Updated esquery.FieldPath
+}
+
+// NewRangeDocFieldPaths returns RangeDocFieldPaths whose paths are prefixed with prefix.
+func NewRangeDocFieldPaths(prefix string) RangeDocFieldPaths {
+	return RangeDocFieldPaths{
+		Age:     esquery.NewFieldPath(esquery.JoinPath(prefix, "age"), "integer_range"),
+		Network: esquery.NewFieldPath(esquery.JoinPath(prefix, "network"), "ip_range"),
+		Period:  esquery.NewFieldPath(esquery.JoinPath(prefix, "period"), "date_range"),
+		Price:   esquery.NewFieldPath(esquery.JoinPath(prefix, "price"), "double_range"),
+		Updated: esquery.NewFieldPath(esquery.JoinPath(prefix, "updated"), "date_range"),
+	}
+}
+
+// RangeDocFields is the field path tree of RangeDoc.
+var RangeDocFields = NewRangeDocFieldPaths("")
@@ -0,0 +1,56 @@
+package example
+
+import (
+	"encoding/json"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/flextime"
+	typeparamcommon "github.com/ngicks/type-param-common"
+)
+
+type RangeDoc struct {
+	Age     *[]estype.Range[int32]                                    `json:"age"`
+	Network *[]estype.IPRange                                         `json:"network"`
+	Period  *[]estype.Range[RangeDocPeriodBound]                      `json:"period"`
+	Price   *[]estype.Range[float64]                                  `json:"price"`
+	Updated *[]estype.Range[estype.StrictDateOptionalTimeEpochMillis] `json:"updated"`
+}
+
+func (t RangeDoc) ToRaw() RangeDocRaw {
+	return RangeDocRaw{
+		Age:     estype.NewField(t.Age),
+		Network: estype.NewField(t.Network),
+		Period:  estype.NewField(t.Period),
+		Price:   estype.NewField(t.Price),
+		Updated: estype.NewField(t.Updated),
+	}
+}
+
+// RangeDocPeriodBound represents elasticsearch date.
+type RangeDocPeriodBound time.Time
+
+func (t RangeDocPeriodBound) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserRangeDocPeriodBound = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006-01-02`)),
+)
+
+func (t *RangeDocPeriodBound) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserRangeDocPeriodBound.Parse,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	*t = RangeDocPeriodBound(tt)
+	return nil
+}
+
+func (t RangeDocPeriodBound) String() string {
+	return time.Time(t).Format(`2006-01-02`)
+}
@@ -0,0 +1,26 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// RangeDocQuery is a set of typed query helpers for fields of RangeDoc.
+type RangeDocQuery struct {
+	Age     esquery.ExistsField
+	Network esquery.ExistsField
+	Period  esquery.ExistsField
+	Price   esquery.ExistsField
+	Updated esquery.ExistsField
+}
+
+// NewRangeDocQuery returns RangeDocQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewRangeDocQuery(prefix string) RangeDocQuery {
+	return RangeDocQuery{
+		Age:     esquery.NewExistsField(esquery.JoinPath(prefix, "age")),
+		Network: esquery.NewExistsField(esquery.JoinPath(prefix, "network")),
+		Period:  esquery.NewExistsField(esquery.JoinPath(prefix, "period")),
+		Price:   esquery.NewExistsField(esquery.JoinPath(prefix, "price")),
+		Updated: esquery.NewExistsField(esquery.JoinPath(prefix, "updated")),
+	}
+}
@@ -0,0 +1,27 @@
+package example
+
+import (
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type RangeDocRaw struct {
+	Age     estype.Field[estype.Range[int32]]                                    `json:"age"`
+	Network estype.Field[estype.IPRange]                                         `json:"network"`
+	Period  estype.Field[estype.Range[RangeDocPeriodBound]]                      `json:"period"`
+	Price   estype.Field[estype.Range[float64]]                                  `json:"price"`
+	Updated estype.Field[estype.Range[estype.StrictDateOptionalTimeEpochMillis]] `json:"updated"`
+}
+
+func (r RangeDocRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t RangeDocRaw) ToPlain() RangeDoc {
+	return RangeDoc{
+		Age:     t.Age.Value(),
+		Network: t.Network.Value(),
+		Period:  t.Period.Value(),
+		Price:   t.Price.Value(),
+		Updated: t.Updated.Value(),
+	}
+}
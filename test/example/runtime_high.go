@@ -0,0 +1,23 @@
+package example
+
+import (
+	"net/netip"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type AccessLog struct {
+	Timestamp *[]estype.StrictDateOptionalTimeEpochMillis `json:"@timestamp"`
+	ClientIp  *[]netip.Addr                               `json:"client_ip"`
+	End       *[]estype.StrictDateOptionalTimeEpochMillis `json:"end"`
+	Port      *[]int32                                    `json:"port"`
+}
+
+func (t AccessLog) ToRaw() AccessLogRaw {
+	return AccessLogRaw{
+		Timestamp: estype.NewField(t.Timestamp),
+		ClientIp:  estype.NewField(t.ClientIp),
+		End:       estype.NewField(t.End),
+		Port:      estype.NewField(t.Port),
+	}
+}
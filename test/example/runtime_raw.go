@@ -0,0 +1,27 @@
+package example
+
+import (
+	"net/netip"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type AccessLogRaw struct {
+	Timestamp estype.Field[estype.StrictDateOptionalTimeEpochMillis] `json:"@timestamp"`
+	ClientIp  estype.Field[netip.Addr]                               `json:"client_ip"`
+	End       estype.Field[estype.StrictDateOptionalTimeEpochMillis] `json:"end"`
+	Port      estype.Field[int32]                                    `json:"port"`
+}
+
+func (r AccessLogRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t AccessLogRaw) ToPlain() AccessLog {
+	return AccessLog{
+		Timestamp: t.Timestamp.Value(),
+		ClientIp:  t.ClientIp.Value(),
+		End:       t.End.Value(),
+		Port:      t.Port.Value(),
+	}
+}
@@ -0,0 +1,49 @@
+package example
+
+import (
+	"encoding/json"
+	"net/netip"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/flextime"
+	typeparamcommon "github.com/ngicks/type-param-common"
+)
+
+// AccessLogRuntime is runtime fields, to be unmarshalled from "fields" of a search hit.
+// Request them by the fields option of the search API, e.g. "fields": ["*"].
+type AccessLogRuntime struct {
+	ClientIp     []netip.Addr          `json:"client.ip,omitempty"`
+	ClientSecure []estype.Boolean      `json:"client.secure,omitempty"`
+	Day          []AccessLogRuntimeDay `json:"day,omitempty"`
+	DayOfWeek    []string              `json:"day_of_week,omitempty"`
+	DurationMs   []int64               `json:"duration_ms,omitempty"`
+}
+
+// AccessLogRuntimeDay represents elasticsearch date.
+type AccessLogRuntimeDay time.Time
+
+func (t AccessLogRuntimeDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+var parserAccessLogRuntimeDay = flextime.NewFlextime(
+	typeparamcommon.Must(flextime.NewLayoutSet(`2006-01-02`)),
+)
+
+func (t *AccessLogRuntimeDay) UnmarshalJSON(data []byte) error {
+	tt, err := estype.UnmarshalEsTime(
+		data,
+		parserAccessLogRuntimeDay.Parse,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	*t = AccessLogRuntimeDay(tt)
+	return nil
+}
+
+func (t AccessLogRuntimeDay) String() string {
+	return time.Time(t).Format(`2006-01-02`)
+}
@@ -0,0 +1,42 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// MetricsDocFieldPaths is a tree of field paths of MetricsDoc.
+type MetricsDocFieldPaths struct {
+	Host    esquery.FieldPath
+	Metrics MetricsDocMetricsFieldPaths
+}
+
+// NewMetricsDocFieldPaths returns MetricsDocFieldPaths whose paths are prefixed with prefix.
+func NewMetricsDocFieldPaths(prefix string) MetricsDocFieldPaths {
+	return MetricsDocFieldPaths{
+		Host:    esquery.NewFieldPath(esquery.JoinPath(prefix, "host"), "keyword"),
+		Metrics: NewMetricsDocMetricsFieldPaths(esquery.JoinPath(prefix, "metrics")),
+	}
+}
+
+// MetricsDocMetricsFieldPaths is a tree of field paths of MetricsDocMetrics.
+type MetricsDocMetricsFieldPaths struct {
+	esquery.FieldPath
+	CpuSystem  esquery.FieldPath
+	CpuUser    esquery.FieldPath
+	DiskIoTime esquery.FieldPath
+	LabelsEnv  esquery.FieldPath
+}
+
+// NewMetricsDocMetricsFieldPaths returns MetricsDocMetricsFieldPaths whose paths are prefixed with prefix.
+func NewMetricsDocMetricsFieldPaths(prefix string) MetricsDocMetricsFieldPaths {
+	return MetricsDocMetricsFieldPaths{
+		FieldPath:  esquery.NewFieldPath(prefix, "object"),
+		CpuSystem:  esquery.NewFieldPath(esquery.JoinPath(prefix, "cpu.system"), "double"),
+		CpuUser:    esquery.NewFieldPath(esquery.JoinPath(prefix, "cpu.user"), "double"),
+		DiskIoTime: esquery.NewFieldPath(esquery.JoinPath(prefix, "disk.io.time"), "date"),
+		LabelsEnv:  esquery.NewFieldPath(esquery.JoinPath(prefix, "labels.env"), "keyword"),
+	}
+}
+
+// MetricsDocFields is the field path tree of MetricsDoc.
+var MetricsDocFields = NewMetricsDocFieldPaths("")
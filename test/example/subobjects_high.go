@@ -0,0 +1,35 @@
+package example
+
+import (
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type MetricsDoc struct {
+	Host    *[]string            `json:"host"`
+	Metrics *[]MetricsDocMetrics `json:"metrics"`
+}
+
+func (t MetricsDoc) ToRaw() MetricsDocRaw {
+	return MetricsDocRaw{
+		Host: estype.NewField(t.Host),
+		Metrics: estype.MapField(estype.NewField(t.Metrics), func(v MetricsDocMetrics) MetricsDocMetricsRaw {
+			return v.ToRaw()
+		}),
+	}
+}
+
+type MetricsDocMetrics struct {
+	CpuSystem  *[]float64            `json:"cpu.system"`
+	CpuUser    *[]float64            `json:"cpu.user"`
+	DiskIoTime *[]estype.EpochMillis `json:"disk.io.time"`
+	LabelsEnv  *[]string             `json:"labels.env"`
+}
+
+func (t MetricsDocMetrics) ToRaw() MetricsDocMetricsRaw {
+	return MetricsDocMetricsRaw{
+		CpuSystem:  estype.NewField(t.CpuSystem),
+		CpuUser:    estype.NewField(t.CpuUser),
+		DiskIoTime: estype.NewField(t.DiskIoTime),
+		LabelsEnv:  estype.NewField(t.LabelsEnv),
+	}
+}
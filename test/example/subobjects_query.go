@@ -0,0 +1,42 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+// MetricsDocQuery is a set of typed query helpers for fields of MetricsDoc.
+type MetricsDocQuery struct {
+	Host    esquery.KeywordField[string]
+	Metrics MetricsDocMetricsQuery
+}
+
+// NewMetricsDocQuery returns MetricsDocQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewMetricsDocQuery(prefix string) MetricsDocQuery {
+	return MetricsDocQuery{
+		Host:    esquery.NewKeywordField[string](esquery.JoinPath(prefix, "host")),
+		Metrics: NewMetricsDocMetricsQuery(esquery.JoinPath(prefix, "metrics")),
+	}
+}
+
+// MetricsDocMetricsQuery is a set of typed query helpers for fields of MetricsDocMetrics.
+type MetricsDocMetricsQuery struct {
+	esquery.ObjectField
+	CpuSystem  esquery.RangeField[float64]
+	CpuUser    esquery.RangeField[float64]
+	DiskIoTime esquery.RangeField[estype.EpochMillis]
+	LabelsEnv  esquery.KeywordField[string]
+}
+
+// NewMetricsDocMetricsQuery returns MetricsDocMetricsQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewMetricsDocMetricsQuery(prefix string) MetricsDocMetricsQuery {
+	return MetricsDocMetricsQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		CpuSystem:   esquery.NewRangeField[float64](esquery.JoinPath(prefix, "cpu.system")),
+		CpuUser:     esquery.NewRangeField[float64](esquery.JoinPath(prefix, "cpu.user")),
+		DiskIoTime:  esquery.NewRangeField[estype.EpochMillis](esquery.JoinPath(prefix, "disk.io.time")),
+		LabelsEnv:   esquery.NewKeywordField[string](esquery.JoinPath(prefix, "labels.env")),
+	}
+}
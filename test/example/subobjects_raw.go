@@ -0,0 +1,79 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type MetricsDocRaw struct {
+	Host    estype.Field[string]               `json:"host"`
+	Metrics estype.Field[MetricsDocMetricsRaw] `json:"metrics"`
+}
+
+func (r MetricsDocRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysMetricsDocRaw = estype.ObjectKeys{
+	"host":    false,
+	"metrics": true,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *MetricsDocRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysMetricsDocRaw)
+	if err != nil {
+		return err
+	}
+	type plain MetricsDocRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t MetricsDocRaw) ToPlain() MetricsDoc {
+	return MetricsDoc{
+		Host: t.Host.Value(),
+		Metrics: estype.MapField(t.Metrics, func(v MetricsDocMetricsRaw) MetricsDocMetrics {
+			return v.ToPlain()
+		}).Value(),
+	}
+}
+
+type MetricsDocMetricsRaw struct {
+	CpuSystem  estype.Field[float64]            `json:"cpu.system"`
+	CpuUser    estype.Field[float64]            `json:"cpu.user"`
+	DiskIoTime estype.Field[estype.EpochMillis] `json:"disk.io.time"`
+	LabelsEnv  estype.Field[string]             `json:"labels.env"`
+}
+
+func (r MetricsDocMetricsRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysMetricsDocMetricsRaw = estype.ObjectKeys{
+	"cpu.system":   false,
+	"cpu.user":     false,
+	"disk.io.time": false,
+	"labels.env":   false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *MetricsDocMetricsRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysMetricsDocMetricsRaw)
+	if err != nil {
+		return err
+	}
+	type plain MetricsDocMetricsRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t MetricsDocMetricsRaw) ToPlain() MetricsDocMetrics {
+	return MetricsDocMetrics{
+		CpuSystem:  t.CpuSystem.Value(),
+		CpuUser:    t.CpuUser.Value(),
+		DiskIoTime: t.DiskIoTime.Value(),
+		LabelsEnv:  t.LabelsEnv.Value(),
+	}
+}
@@ -0,0 +1,56 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// LogsAppFieldPaths is a tree of field paths of LogsApp.
+type LogsAppFieldPaths struct {
+	Timestamp esquery.FieldPath
+	Host      LogsAppHostFieldPaths
+	Level     esquery.FieldPath
+	Message   LogsAppMessageSubFieldPaths
+}
+
+// NewLogsAppFieldPaths returns LogsAppFieldPaths whose paths are prefixed with prefix.
+func NewLogsAppFieldPaths(prefix string) LogsAppFieldPaths {
+	return LogsAppFieldPaths{
+		Timestamp: esquery.NewFieldPath(esquery.JoinPath(prefix, "@timestamp"), "date"),
+		Host:      NewLogsAppHostFieldPaths(esquery.JoinPath(prefix, "host")),
+		Level:     esquery.NewFieldPath(esquery.JoinPath(prefix, "level"), "keyword"),
+		Message:   NewLogsAppMessageSubFieldPaths(esquery.JoinPath(prefix, "message")),
+	}
+}
+
+// LogsAppHostFieldPaths is a tree of field paths of LogsAppHost.
+type LogsAppHostFieldPaths struct {
+	esquery.FieldPath
+	Ip   esquery.FieldPath
+	Name esquery.FieldPath
+}
+
+// NewLogsAppHostFieldPaths returns LogsAppHostFieldPaths whose paths are prefixed with prefix.
+func NewLogsAppHostFieldPaths(prefix string) LogsAppHostFieldPaths {
+	return LogsAppHostFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "object"),
+		Ip:        esquery.NewFieldPath(esquery.JoinPath(prefix, "ip"), "ip"),
+		Name:      esquery.NewFieldPath(esquery.JoinPath(prefix, "name"), "keyword"),
+	}
+}
+
+// LogsAppMessageSubFieldPaths is a tree of field paths of LogsAppMessageSub.
+type LogsAppMessageSubFieldPaths struct {
+	esquery.FieldPath
+	Keyword esquery.FieldPath
+}
+
+// NewLogsAppMessageSubFieldPaths returns LogsAppMessageSubFieldPaths whose paths are prefixed with prefix.
+func NewLogsAppMessageSubFieldPaths(prefix string) LogsAppMessageSubFieldPaths {
+	return LogsAppMessageSubFieldPaths{
+		FieldPath: esquery.NewFieldPath(prefix, "text"),
+		Keyword:   esquery.NewFieldPath(esquery.JoinPath(prefix, "keyword"), "keyword"),
+	}
+}
+
+// LogsAppFields is the field path tree of LogsApp.
+var LogsAppFields = NewLogsAppFieldPaths("")
@@ -0,0 +1,37 @@
+package example
+
+import (
+	"net/netip"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type LogsApp struct {
+	Timestamp *[]estype.StrictDateOptionalTimeEpochMillis `json:"@timestamp"`
+	Host      *[]LogsAppHost                              `json:"host"`
+	Level     *[]string                                   `json:"level"`
+	Message   *[]string                                   `json:"message"`
+}
+
+func (t LogsApp) ToRaw() LogsAppRaw {
+	return LogsAppRaw{
+		Timestamp: estype.NewField(t.Timestamp),
+		Host: estype.MapField(estype.NewField(t.Host), func(v LogsAppHost) LogsAppHostRaw {
+			return v.ToRaw()
+		}),
+		Level:   estype.NewField(t.Level),
+		Message: estype.NewField(t.Message),
+	}
+}
+
+type LogsAppHost struct {
+	Ip   *[]netip.Addr `json:"ip"`
+	Name *[]string     `json:"name"`
+}
+
+func (t LogsAppHost) ToRaw() LogsAppHostRaw {
+	return LogsAppHostRaw{
+		Ip:   estype.NewField(t.Ip),
+		Name: estype.NewField(t.Name),
+	}
+}
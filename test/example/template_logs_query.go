@@ -0,0 +1,44 @@
+package example
+
+import (
+	"net/netip"
+
+	esquery "github.com/ngicks/elastic-type/es_query"
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+// LogsAppQuery is a set of typed query helpers for fields of LogsApp.
+type LogsAppQuery struct {
+	Timestamp esquery.RangeField[estype.StrictDateOptionalTimeEpochMillis]
+	Host      LogsAppHostQuery
+	Level     esquery.KeywordField[string]
+	Message   esquery.TextField[string]
+}
+
+// NewLogsAppQuery returns LogsAppQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewLogsAppQuery(prefix string) LogsAppQuery {
+	return LogsAppQuery{
+		Timestamp: esquery.NewRangeField[estype.StrictDateOptionalTimeEpochMillis](esquery.JoinPath(prefix, "@timestamp")),
+		Host:      NewLogsAppHostQuery(esquery.JoinPath(prefix, "host")),
+		Level:     esquery.NewKeywordField[string](esquery.JoinPath(prefix, "level")),
+		Message:   esquery.NewTextField[string](esquery.JoinPath(prefix, "message")),
+	}
+}
+
+// LogsAppHostQuery is a set of typed query helpers for fields of LogsAppHost.
+type LogsAppHostQuery struct {
+	esquery.ObjectField
+	Ip   esquery.RangeField[netip.Addr]
+	Name esquery.KeywordField[string]
+}
+
+// NewLogsAppHostQuery returns LogsAppHostQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewLogsAppHostQuery(prefix string) LogsAppHostQuery {
+	return LogsAppHostQuery{
+		ObjectField: esquery.NewObjectField(prefix),
+		Ip:          esquery.NewRangeField[netip.Addr](esquery.JoinPath(prefix, "ip")),
+		Name:        esquery.NewKeywordField[string](esquery.JoinPath(prefix, "name")),
+	}
+}
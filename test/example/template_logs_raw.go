@@ -0,0 +1,64 @@
+package example
+
+import (
+	"encoding/json"
+	"net/netip"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type LogsAppRaw struct {
+	Timestamp estype.Field[estype.StrictDateOptionalTimeEpochMillis] `json:"@timestamp"`
+	Host      estype.Field[LogsAppHostRaw]                           `json:"host"`
+	Level     estype.Field[string]                                   `json:"level"`
+	Message   estype.Field[string]                                   `json:"message"`
+}
+
+func (r LogsAppRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+var keysLogsAppRaw = estype.ObjectKeys{
+	"@timestamp": false,
+	"host":       true,
+	"level":      false,
+	"message":    false,
+}
+
+// UnmarshalJSON accepts both dotted and expanded forms of keys, e.g. {"a.b": 1} and {"a": {"b": 1}}.
+// Documents are re-encoded before decoding only if they have keys to rewrite. See estype.NormalizeKeys.
+func (r *LogsAppRaw) UnmarshalJSON(data []byte) error {
+	data, err := estype.NormalizeKeys(data, keysLogsAppRaw)
+	if err != nil {
+		return err
+	}
+	type plain LogsAppRaw
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func (t LogsAppRaw) ToPlain() LogsApp {
+	return LogsApp{
+		Timestamp: t.Timestamp.Value(),
+		Host: estype.MapField(t.Host, func(v LogsAppHostRaw) LogsAppHost {
+			return v.ToPlain()
+		}).Value(),
+		Level:   t.Level.Value(),
+		Message: t.Message.Value(),
+	}
+}
+
+type LogsAppHostRaw struct {
+	Ip   estype.Field[netip.Addr] `json:"ip"`
+	Name estype.Field[string]     `json:"name"`
+}
+
+func (r LogsAppHostRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t LogsAppHostRaw) ToPlain() LogsAppHost {
+	return LogsAppHost{
+		Ip:   t.Ip.Value(),
+		Name: t.Name.Value(),
+	}
+}
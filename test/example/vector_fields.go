@@ -0,0 +1,24 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// VectorDocFieldPaths is a tree of field paths of VectorDoc.
+type VectorDocFieldPaths struct {
+	Bits      esquery.FieldPath
+	Unindexed esquery.FieldPath
+	Unit      esquery.FieldPath
+}
+
+// NewVectorDocFieldPaths returns VectorDocFieldPaths whose paths are prefixed with prefix.
+func NewVectorDocFieldPaths(prefix string) VectorDocFieldPaths {
+	return VectorDocFieldPaths{
+		Bits:      esquery.NewFieldPath(esquery.JoinPath(prefix, "bits"), "dense_vector"),
+		Unindexed: esquery.NewFieldPath(esquery.JoinPath(prefix, "unindexed"), "dense_vector"),
+		Unit:      esquery.NewFieldPath(esquery.JoinPath(prefix, "unit"), "dense_vector"),
+	}
+}
+
+// VectorDocFields is the field path tree of VectorDoc.
+var VectorDocFields = NewVectorDocFieldPaths("")
@@ -0,0 +1,102 @@
+package example
+
+import (
+	"encoding/json"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type VectorDoc struct {
+	Bits      *[]VectorDocBits      `json:"bits"`
+	Unindexed *[]VectorDocUnindexed `json:"unindexed"`
+	Unit      *[]VectorDocUnit      `json:"unit"`
+}
+
+func (t VectorDoc) ToRaw() VectorDocRaw {
+	return VectorDocRaw{
+		Bits:      estype.NewField(t.Bits),
+		Unindexed: estype.NewField(t.Unindexed),
+		Unit:      estype.NewField(t.Unit),
+	}
+}
+
+// VectorDocBits is a value of the dense_vector field of 16 bit elements, packed into bytes.
+type VectorDocBits []int8
+
+func (v VectorDocBits) MarshalJSON() ([]byte, error) {
+	if err := estype.CheckBitVector(v, 16); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]int8(v))
+}
+
+func (v *VectorDocBits) UnmarshalJSON(data []byte) error {
+	vec, err := estype.UnmarshalByteVectorJSON(data)
+	if err != nil {
+		return err
+	}
+	if err := estype.CheckBitVector(vec, 16); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// Score returns _score of kNN search for v as a document vector, computed from the hamming distance as Elasticsearch does.
+func (v VectorDocBits) Score(query VectorDocBits) (float32, error) {
+	return estype.BitVectorScore(query, v)
+}
+
+// VectorDocUnindexed is a value of the dense_vector field of 2 float elements, compared by cosine.
+type VectorDocUnindexed []float32
+
+func (v VectorDocUnindexed) MarshalJSON() ([]byte, error) {
+	if err := estype.CheckFloatVector(v, 2, ""); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]float32(v))
+}
+
+func (v *VectorDocUnindexed) UnmarshalJSON(data []byte) error {
+	var vec []float32
+	if err := json.Unmarshal(data, &vec); err != nil {
+		return err
+	}
+	if err := estype.CheckFloatVector(vec, 2, ""); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// Score returns _score of kNN search for v as a document vector, computed by cosine as Elasticsearch does.
+func (v VectorDocUnindexed) Score(query VectorDocUnindexed) (float32, error) {
+	return estype.FloatVectorScore("cosine", query, v)
+}
+
+// VectorDocUnit is a value of the dense_vector field of 2 float elements, compared by dot_product.
+type VectorDocUnit []float32
+
+func (v VectorDocUnit) MarshalJSON() ([]byte, error) {
+	if err := estype.CheckFloatVector(v, 2, "dot_product"); err != nil {
+		return nil, err
+	}
+	return json.Marshal([]float32(v))
+}
+
+func (v *VectorDocUnit) UnmarshalJSON(data []byte) error {
+	var vec []float32
+	if err := json.Unmarshal(data, &vec); err != nil {
+		return err
+	}
+	if err := estype.CheckFloatVector(vec, 2, "dot_product"); err != nil {
+		return err
+	}
+	*v = vec
+	return nil
+}
+
+// Score returns _score of kNN search for v as a document vector, computed by dot_product as Elasticsearch does.
+func (v VectorDocUnit) Score(query VectorDocUnit) (float32, error) {
+	return estype.FloatVectorScore("dot_product", query, v)
+}
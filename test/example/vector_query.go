@@ -0,0 +1,22 @@
+package example
+
+import (
+	esquery "github.com/ngicks/elastic-type/es_query"
+)
+
+// VectorDocQuery is a set of typed query helpers for fields of VectorDoc.
+type VectorDocQuery struct {
+	Bits      esquery.ExistsField
+	Unindexed esquery.ExistsField
+	Unit      esquery.ExistsField
+}
+
+// NewVectorDocQuery returns VectorDocQuery whose field paths are prefixed with prefix.
+// prefix must be empty for the document root.
+func NewVectorDocQuery(prefix string) VectorDocQuery {
+	return VectorDocQuery{
+		Bits:      esquery.NewExistsField(esquery.JoinPath(prefix, "bits")),
+		Unindexed: esquery.NewExistsField(esquery.JoinPath(prefix, "unindexed")),
+		Unit:      esquery.NewExistsField(esquery.JoinPath(prefix, "unit")),
+	}
+}
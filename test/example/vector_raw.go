@@ -0,0 +1,23 @@
+package example
+
+import (
+	estype "github.com/ngicks/elastic-type/es_type"
+)
+
+type VectorDocRaw struct {
+	Bits      estype.Field[VectorDocBits]      `json:"bits"`
+	Unindexed estype.Field[VectorDocUnindexed] `json:"unindexed"`
+	Unit      estype.Field[VectorDocUnit]      `json:"unit"`
+}
+
+func (r VectorDocRaw) MarshalJSON() ([]byte, error) {
+	return estype.MarshalFieldsJSON(r)
+}
+
+func (t VectorDocRaw) ToPlain() VectorDoc {
+	return VectorDoc{
+		Bits:      t.Bits.Value(),
+		Unindexed: t.Unindexed.Value(),
+		Unit:      t.Unit.Value(),
+	}
+}
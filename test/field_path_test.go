@@ -0,0 +1,79 @@
+package test_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateFieldPaths(t *testing.T) {
+	require := require.New(t)
+
+	f := example.AllFields
+
+	for _, tc := range []struct {
+		path   string
+		esType mapping.EsType
+		actual interface {
+			Path() string
+			EsType() mapping.EsType
+		}
+	}{
+		{"kwd", mapping.Keyword, f.Kwd},
+		{"nested", mapping.Object, f.Nested},
+		{"nested.age", mapping.Integer, f.Nested.Age},
+		{"object.name.first", mapping.Text, f.Object.Name.First},
+		{"text_w_token_count", mapping.Text, f.TextWTokenCount},
+		{"text_w_token_count.length", mapping.TokenCount, f.TextWTokenCount.Length},
+		{"search_as_you_type._2gram", mapping.SearchAsYouType, f.SearchAsYouType.Gram2},
+		{"search_as_you_type._3gram", mapping.SearchAsYouType, f.SearchAsYouType.Gram3},
+		{"search_as_you_type._index_prefix", mapping.SearchAsYouType, f.SearchAsYouType.IndexPrefix},
+	} {
+		require.Equal(tc.path, tc.actual.Path())
+		require.Equal(tc.esType, tc.actual.EsType())
+	}
+}
+
+func TestGenerateFieldPathsNames(t *testing.T) {
+	require := require.New(t)
+
+	var m mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"properties": {
+			"sayt": { "type": "search_as_you_type", "max_shingle_size": 4 },
+			"name_keyword": { "type": "text", "fields": { "raw": { "type": "keyword" } } },
+			"nameKeyword": { "properties": { "raw": { "type": "keyword" } } }
+		}
+	}`), &m))
+
+	globalOpt := generate.GlobalOption{}
+	globalOpt.TypeNameGenerator.PostProcess = []generate.TypeNamePostProcessRule{
+		generate.Prefix("doc", 1),
+		generate.PascalCaseUnderscoreHyphen(),
+	}
+	highLevelTy, _, _, err := generate.Generate(m, "doc", globalOpt, nil)
+	require.NoError(err)
+	paths := generate.GenerateFieldPaths(highLevelTy)
+
+	tyNames := map[string]bool{}
+	var defs strings.Builder
+	for _, gen := range paths {
+		require.False(tyNames[gen.TyName], "duplicate type name %s", gen.TyName)
+		tyNames[gen.TyName] = true
+		defs.WriteString(gen.TyDef)
+	}
+	require.True(tyNames["DocNameKeywordFieldPaths"])
+	require.True(tyNames["DocNameKeywordSubFieldPaths"])
+	for _, s := range []string{
+		`Gram2: esquery.NewFieldPath(esquery.JoinPath(prefix, "_2gram"), "search_as_you_type")`,
+		`Gram3: esquery.NewFieldPath(esquery.JoinPath(prefix, "_3gram"), "search_as_you_type")`,
+		`Gram4: esquery.NewFieldPath(esquery.JoinPath(prefix, "_4gram"), "search_as_you_type")`,
+	} {
+		require.Contains(defs.String(), s)
+	}
+}
@@ -0,0 +1,137 @@
+package test_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	fromstruct "github.com/ngicks/elastic-type/from_struct"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+type fromStructBase struct {
+	ID string `json:"id"`
+}
+
+type fromStructComment struct {
+	Author string    `json:"author"`
+	At     time.Time `json:"at"`
+}
+
+type fromStructDoc struct {
+	fromStructBase
+	Name      string                       `json:"name" es:"type=keyword,ignore_above=256"`
+	Body      string                       `json:"body,omitempty" es:"type=text,analyzer=english"`
+	CreatedAt time.Time                    `json:"created_at"`
+	UpdatedAt *estype.EpochMillis          `json:"updated_at"`
+	Count     estype.Field[int32]          `json:"count"`
+	Price     float64                      `json:"price" es:"type=scaled_float,scaling_factor=100"`
+	Tags      []string                     `json:"tags"`
+	Client    netip.Addr                   `json:"client"`
+	Location  estype.Geopoint              `json:"location"`
+	Spot      estype.Point                 `json:"spot"`
+	Comments  []fromStructComment          `json:"comments" es:"type=nested"`
+	Labels    map[string]string            `json:"labels"`
+	Blob      []byte                       `json:"blob"`
+	Visible   estype.Field[estype.Boolean] `json:"visible"`
+	Secret    string                       `json:"-"`
+	Skipped   string                       `es:"-"`
+	private   string
+}
+
+func TestFromStruct(t *testing.T) {
+	require := require.New(t)
+
+	m, err := fromstruct.Mappings(&fromStructDoc{})
+	require.NoError(err)
+
+	bin := must(json.Marshal(m))
+	require.JSONEq(`{
+		"properties": {
+			"id": { "type": "keyword" },
+			"name": { "type": "keyword", "ignore_above": 256 },
+			"body": { "type": "text", "analyzer": "english" },
+			"created_at": { "type": "date" },
+			"updated_at": { "type": "date", "format": "epoch_millis" },
+			"count": { "type": "integer" },
+			"price": { "type": "scaled_float", "scaling_factor": 100 },
+			"tags": { "type": "keyword" },
+			"client": { "type": "ip" },
+			"location": { "type": "geo_point" },
+			"spot": { "type": "point" },
+			"comments": {
+				"type": "nested",
+				"properties": {
+					"author": { "type": "keyword" },
+					"at": { "type": "date" }
+				}
+			},
+			"labels": { "dynamic": true },
+			"blob": { "type": "binary" },
+			"visible": { "type": "boolean" }
+		}
+	}`, string(bin))
+
+	// structs -> mapping -> raw types.
+	highLevelTy, rawTy, _, err := generate.Generate(m, "doc", generate.GlobalOption{}, nil)
+	require.NoError(err)
+	require.NotEmpty(highLevelTy)
+	require.Equal("DocRaw", rawTy[0].TyName)
+	for name, fieldTy := range map[string]string{
+		"blob":       "[]byte",
+		"body":       "string",
+		"client":     "netip.Addr",
+		"comments":   "CommentsRaw",
+		"count":      "int32",
+		"created_at": "estype.StrictDateOptionalTimeEpochMillis",
+		"id":         "string",
+		"location":   "estype.Geopoint",
+		"name":       "string",
+		"price":      "float64",
+		"spot":       "estype.Point",
+		"tags":       "string",
+		"updated_at": "estype.EpochMillis",
+		"visible":    "estype.Boolean",
+	} {
+		pattern := `\sestype\.Field\[` + regexp.QuoteMeta(fieldTy) + `\]\s+` + "`" + `json:"` + name + `"`
+		require.Regexp(pattern, rawTy[0].TyDef, name)
+	}
+
+	// Generated raw types can be the input too,
+	// and must derive the same types as the mapping they are generated from.
+	var source map[string]struct {
+		Mappings mapping.Mappings `json:"mappings"`
+	}
+	require.NoError(json.Unmarshal(must(os.ReadFile("./example/example.json")), &source))
+	fromRaw, err := fromstruct.Mappings(example.ExampleRaw{})
+	require.NoError(err)
+	sourceProps, props := *source["example"].Mappings.Properties, *fromRaw.Properties
+	require.Len(props, len(sourceProps))
+	for name, prop := range sourceProps {
+		require.Equal(prop.Type, props[name].Type, name)
+	}
+
+	_, err = fromstruct.Mappings(struct {
+		Ch chan int `json:"ch"`
+	}{})
+	require.ErrorContains(err, "ch: can not infer type")
+
+	_, err = fromstruct.Mappings(struct {
+		Name string `json:"name" es:"type=keyword,no_such_param=1"`
+	}{})
+	require.ErrorContains(err, "no_such_param is not a param of keyword")
+
+	_, err = fromstruct.Mappings(struct {
+		Vec []float32 `json:"vec" es:"type=dense_vector,dims=0"`
+	}{})
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(err, &validationErrs)
+	require.Equal(mapping.RuleDenseVectorDims, validationErrs[0].Rule)
+}
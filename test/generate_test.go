@@ -9,6 +9,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
 	"github.com/ngicks/elastic-type/test"
 	"github.com/ngicks/elastic-type/test/example"
 	"github.com/ngicks/gommon/pkg/randstr"
@@ -47,19 +49,19 @@ func TestGenerate_all(t *testing.T) {
 			Blob:        tpc.Escape([]byte(`foobarbaz`)),
 			Bool:        tpc.Escape(estype.Boolean(true)),
 			Byte:        tpc.Escape(int8(12)),
-			Comp:        tpc.Escape(randomStr()),
+			Comp:        tpc.Escape(estype.Completion{Input: []string{randomStr()}, Weight: tpc.Escape(int32(10))}),
 			ConstantKwd: tpc.Escape("debug"),
 			Date:        tpc.Escape(example.AllDate(nowSec)),
 			DateNano:    tpc.Escape(example.AllDateNano(nowNano)),
-			DateRange: tpc.Escape(map[string]interface{}{
-				"gte": float64(12345),
-				"lte": float64(12350),
+			DateRange: tpc.Escape(estype.Range[estype.StrictDateOptionalTimeEpochMillis]{
+				Gte: tpc.Escape(estype.StrictDateOptionalTimeEpochMillis(time.UnixMilli(12345))),
+				Lte: tpc.Escape(estype.StrictDateOptionalTimeEpochMillis(time.UnixMilli(12350))),
 			}),
-			DenseVector: tpc.Escape([]float64{16, 15, 14}),
+			DenseVector: tpc.Escape(example.AllDenseVector{16, 15, 14}),
 			Double:      tpc.Escape(float64(68)),
-			DoubleRange: tpc.Escape(map[string]interface{}{
-				"gte": 10.1,
-				"lt":  20.1,
+			DoubleRange: tpc.Escape(estype.Range[float64]{
+				Gte: tpc.Escape(10.1),
+				Lt:  tpc.Escape(20.1),
 			}),
 			Flattened: tpc.Escape(map[string]interface{}{
 				"priority": "urgent",
@@ -70,9 +72,9 @@ func TestGenerate_all(t *testing.T) {
 				},
 			}),
 			Float: tpc.Escape(float32(357.3209)),
-			FloatRange: tpc.Escape(map[string]interface{}{
-				"gte": 10.1,
-				"lt":  20.1,
+			FloatRange: tpc.Escape(estype.Range[float32]{
+				Gte: tpc.Escape(float32(10.1)),
+				Lt:  tpc.Escape(float32(20.1)),
 			}),
 			Geopoint: tpc.Escape(estype.Geopoint{
 				Lat: 41.12,
@@ -82,28 +84,28 @@ func TestGenerate_all(t *testing.T) {
 				Geometry: geom.Point{-77.03653, 38.897676},
 			}),
 			HalfFloat: tpc.Escape(float32(2131.57)),
-			Histogram: tpc.Escape(map[string]interface{}{
-				"values": []any{0.1, 0.2, 0.3, 0.4, 0.5},
-				"counts": []any{float64(3), float64(7), float64(23), float64(12), float64(6)},
+			Histogram: tpc.Escape(estype.Histogram{
+				Values: []float64{0.1, 0.2, 0.3, 0.4, 0.5},
+				Counts: []int64{3, 7, 23, 12, 6},
 			}),
 			Integer: tpc.Escape(int32(60)),
-			IntegerRange: tpc.Escape(map[string]interface{}{
-				"gte": float64(10),
-				"lt":  float64(20),
+			IntegerRange: tpc.Escape(estype.Range[int32]{
+				Gte: tpc.Escape(int32(10)),
+				Lt:  tpc.Escape(int32(20)),
 			}),
 			IpAddr: tpc.Escape(netip.MustParseAddr("192.168.0.1")),
-			IpRange: tpc.Escape(map[string]interface{}{
-				"gte": "192.168.0.2",
-				"lt":  "192.168.0.240",
-			}),
-			Join: tpc.Escape(map[string]interface{}{
-				"name": "question",
+			IpRange: tpc.Escape(estype.IPRange{
+				Range: estype.Range[netip.Addr]{
+					Gte: tpc.Escape(netip.MustParseAddr("192.168.0.2")),
+					Lt:  tpc.Escape(netip.MustParseAddr("192.168.0.240")),
+				},
 			}),
+			Join: tpc.Escape(example.NewAllJoinQuestion()),
 			Kwd:  tpc.Escape("naaaaaaaaaaaaaah"),
 			Long: tpc.Escape(int64(210389467827)),
-			LongRange: tpc.Escape(map[string]interface{}{
-				"gte": float64(10),
-				"lt":  float64(20),
+			LongRange: tpc.Escape(estype.Range[int64]{
+				Gte: tpc.Escape(int64(10)),
+				Lt:  tpc.Escape(int64(20)),
 			}),
 			Nested: tpc.Escape(example.AllNested{
 				Age: tpc.Escape(int32(123)),
@@ -119,10 +121,7 @@ func TestGenerate_all(t *testing.T) {
 					Last:  tpc.Escape("doe"),
 				},
 			}),
-			Point: tpc.Escape(map[string]interface{}{
-				"type":        "Point",
-				"coordinates": []any{-71.34, 41.12},
-			}),
+			Point: tpc.Escape(estype.Point{X: -71.34, Y: 41.12}),
 			Query: tpc.Escape(map[string]interface{}{
 				"match": map[string]any{
 					"kwd": "value",
@@ -135,7 +134,7 @@ func TestGenerate_all(t *testing.T) {
 			}),
 			ScaledFloat:     tpc.Escape(float64(12315.4798)),
 			SearchAsYouType: tpc.Escape("quick brown fox jump lazy dog"),
-			Shape: tpc.Escape(estype.Geoshape{
+			Shape: tpc.Escape(estype.Shape{
 				Geometry: geom.Point{-77.03653, 38.897676},
 			}),
 			Short:           tpc.Escape(int16(2109)),
@@ -156,7 +155,7 @@ func TestGenerate_all(t *testing.T) {
 		diff := cmp.Diff(
 			fetchedPlain,
 			allPlain,
-			cmpopts.IgnoreFields(allPlain, "Date", "DateNano", "IpAddr"),
+			cmpopts.IgnoreFields(allPlain, "Date", "DateNano", "DateRange", "IpAddr", "IpRange"),
 		)
 		if diff != "" {
 			require.Failf("diff = %s", diff)
@@ -165,6 +164,10 @@ func TestGenerate_all(t *testing.T) {
 		require.True(time.Time(*allPlain.Date).Equal(time.Time(*fetchedPlain.Date)))
 		require.True(time.Time(*allPlain.DateNano).Equal(time.Time(*fetchedPlain.DateNano)))
 		require.Equal(allPlain.IpAddr.String(), fetchedPlain.IpAddr.String())
+		require.True(time.Time(*allPlain.DateRange.Gte).Equal(time.Time(*fetchedPlain.DateRange.Gte)))
+		require.True(time.Time(*allPlain.DateRange.Lte).Equal(time.Time(*fetchedPlain.DateRange.Lte)))
+		require.Equal(allPlain.IpRange.Gte.String(), fetchedPlain.IpRange.Gte.String())
+		require.Equal(allPlain.IpRange.Lt.String(), fetchedPlain.IpRange.Lt.String())
 	})
 }
 
@@ -197,3 +200,27 @@ func TestGenerate_example_with_custom_date(t *testing.T) {
 		require.True(time.Time(plain.Date).Equal(time.Time(fetchedPlain.Date)))
 	})
 }
+
+func TestDateFromParam_epoch(t *testing.T) {
+	require := require.New(t)
+
+	param := func(format string) mapping.DateParams {
+		return mapping.DateParams{Type: mapping.Date, Format: &format}
+	}
+
+	for format, tyName := range map[string]string{
+		"epoch_millis": "estype.EpochMillis",
+		"epoch_second": "estype.EpochSecond",
+	} {
+		gen, err := generate.DateFromParam(param(format), "doc", "", false)
+		require.NoError(err)
+		require.Equal(tyName, gen.TyName)
+		require.Empty(gen.TyDef)
+	}
+
+	_, err := generate.DateFromParam(param("epoch_millis"), "doc", "yyyy-MM-dd", false)
+	require.ErrorContains(err, "preferred format yyyy-MM-dd is not applicable to format epoch_millis")
+
+	_, err = generate.DateFromParam(param("epoch_millis||epoch_second"), "doc", "", false)
+	require.ErrorContains(err, "no format other than epoch_millis or epoch_second")
+}
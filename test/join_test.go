@@ -0,0 +1,79 @@
+package test_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	require := require.New(t)
+
+	for _, testCase := range []struct {
+		input    string
+		expected example.AllJoin
+	}{
+		{`{"join":"question"}`, example.NewAllJoinQuestion()},
+		{`{"join":{"name":"question"}}`, example.NewAllJoinQuestion()},
+		{`{"join":{"name":"answer","parent":"1"}}`, example.NewAllJoinAnswer("1")},
+	} {
+		var raw example.AllRaw
+		require.NoError(json.Unmarshal([]byte(testCase.input), &raw), testCase.input)
+		require.Equal(testCase.expected, *raw.ToPlain().Join)
+	}
+
+	var raw example.AllRaw
+	require.ErrorIs(json.Unmarshal([]byte(`{"join":"comment"}`), &raw), estype.ErrUnknownRelation)
+	require.ErrorIs(json.Unmarshal([]byte(`{"join":{"name":"answer"}}`), &raw), estype.ErrMissingParent)
+
+	bin, err := json.Marshal(example.NewAllJoinAnswer("1"))
+	require.NoError(err)
+	require.JSONEq(`{"name":"answer","parent":"1"}`, string(bin))
+
+	_, err = json.Marshal(example.AllJoin{Name: "comment"})
+	require.ErrorIs(err, estype.ErrUnknownRelation)
+}
+
+func TestJoinFromParam(t *testing.T) {
+	require := require.New(t)
+
+	m := mapping.New().
+		Field("rel", mapping.Join, mapping.Relation("question", "answer", "comment"), mapping.Relation("answer", "vote")).
+		MustBuild()
+	gen, err := generate.JoinFromParam(*(*m.Properties)["rel"].Param.(*mapping.JoinParams), "docRel")
+	require.NoError(err)
+	require.Equal("DocRel", gen.TyName)
+	for _, s := range []string{
+		`"vote": "answer"`,
+		"func NewDocRelQuestion() DocRel",
+		"func NewDocRelAnswer(parentID string) DocRel",
+		"func NewDocRelComment(parentID string) DocRel",
+		"func NewDocRelVote(parentID string) DocRel",
+	} {
+		require.Contains(gen.TyDef, s)
+	}
+
+	_, err = generate.JoinFromParam(mapping.JoinParams{Relations: map[string]any{
+		"question": "answer",
+		"post":     "answer",
+	}}, "docRel")
+	require.Error(err)
+
+	_, err = generate.JoinFromParam(mapping.JoinParams{Relations: map[string]any{
+		"question": []any{"answer", 1},
+	}}, "docRel")
+	require.ErrorContains(err, `relation of "question" must be string or []string, but has int`)
+
+	// names are quoted as Go string literals.
+	gen, err = generate.JoinFromParam(mapping.JoinParams{Relations: map[string]any{
+		`say "hi"`: `re\ply`,
+	}}, "docRel")
+	require.NoError(err)
+	require.Contains(gen.TyDef, `"re\\ply": "say \"hi\"",`)
+	require.Contains(gen.TyDef, `return DocRel{Name: "re\\ply", Parent: parentID}`)
+}
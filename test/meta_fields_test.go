@@ -0,0 +1,78 @@
+package test_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaFields(t *testing.T) {
+	require := require.New(t)
+
+	bin := must(os.ReadFile("./example/meta_fields.json"))
+	var settings mapping.MappingSettings
+	require.NoError(json.Unmarshal(bin, &settings))
+
+	var stored map[string]any
+	require.NoError(json.Unmarshal(bin, &stored))
+	require.True(cmp.Equal(stored, toAnyMap(settings)), cmp.Diff(stored, toAnyMap(settings)))
+
+	mappings := settings["tenant_doc"].Mappings
+	require.NoError(mappings.Validate())
+	require.True(mappings.RoutingRequired())
+	require.Equal([]string{"meta.secret"}, mappings.Source.Excludes)
+	require.Equal(map[string]any{"owner": "search-team", "version": float64(3)}, mappings.Meta)
+	require.False(*mappings.FieldNames.Enabled)
+
+	// body and meta.secret are not in the stored _source.
+	var doc example.TenantDocRaw
+	require.NoError(json.Unmarshal(
+		[]byte(`{"title":"foo","tenant":"t1","meta":{"tags":["a","b"]}}`),
+		&doc,
+	))
+	require.Equal(
+		example.TenantDoc{
+			Meta:   &[]example.TenantDocMeta{{Tags: &[]string{"a", "b"}}},
+			Tenant: &[]string{"t1"},
+			Title:  &[]string{"foo"},
+		},
+		doc.ToPlain(),
+	)
+	require.True(doc.RoutingRequired())
+	require.True(doc.ToPlain().RoutingRequired())
+
+	// Excluded fields can still be searched.
+	require.Equal("body", example.TenantDocFields.Body.Path())
+	require.Equal("meta.secret", example.TenantDocFields.Meta.Secret.Path())
+
+	// Nothing is stored in _source if it is disabled, by enabled: false or mode: disabled.
+	for _, source := range []string{`{"enabled": false}`, `{"mode": "disabled"}`} {
+		var m mapping.Mappings
+		require.NoError(json.Unmarshal([]byte(`{
+			"_source": `+source+`,
+			"properties": {
+				"title": { "type": "text" },
+				"meta": { "properties": { "tags": { "type": "keyword" } } }
+			}
+		}`), &m))
+		highLevelTy, rawTy, _, err := generate.Generate(m, "doc", generate.GlobalOption{}, nil)
+		require.NoError(err)
+		require.NotContains(rawTy[0].TyDef, `json:"title"`, source)
+		require.NotContains(rawTy[0].TyDef, `json:"meta"`, source)
+		for _, field := range highLevelTy[0].Fields {
+			require.True(field.SourceExcluded, "%s: %s", source, field.Name)
+		}
+	}
+
+	var invalid mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{"_source": {"mode": "compressed"}}`), &invalid))
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(invalid.Validate(), &validationErrs)
+	require.Equal(mapping.RuleSource, validationErrs[0].Rule)
+}
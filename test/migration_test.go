@@ -0,0 +1,113 @@
+package test_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigration(t *testing.T) {
+	require := require.New(t)
+
+	created := time.Date(2022, 10, 20, 16, 22, 46, 0, time.UTC)
+	old := example.MigrationV1{
+		Attrs: &[]example.MigrationV1Attrs{{
+			Timestamps: map[string][]example.MigrationV1Timestamps{"seen_at": {example.MigrationV1Timestamps(created)}},
+			Counts:     map[string][]int32{"view_count": {3}},
+			Labels:     map[string][]string{"label_a": {"dropped"}},
+			Other:      map[string][]any{"note": {"kept"}},
+		}},
+		Author: &[]example.MigrationV1Author{
+			{Age: &[]int16{32}, Name: &[]string{"foo"}},
+		},
+		Count:   &[]int32{5},
+		Created: &[]example.MigrationV1Created{example.MigrationV1Created(created)},
+		Legacy:  &[]string{"dropped"},
+		Ratio:   &[]float64{0.5},
+		Title:   &[]string{"title"},
+	}
+
+	converted := example.ConvertMigrationV1(old)
+
+	require.Equal(
+		example.MigrationV2{
+			Attrs: &[]example.MigrationV2Attrs{{
+				Timestamps: (*converted.Attrs)[0].Timestamps,
+				Counts:     map[string][]int64{"view_count": {3}},
+				Other:      map[string][]any{"note": {"kept"}},
+			}},
+			Author: &[]example.MigrationV2Author{
+				{Age: &[]int32{32}, Name: &[]string{"foo"}},
+			},
+			Count:   &[]int64{5},
+			Created: converted.Created,
+			Title:   &[]string{"title"},
+		},
+		converted,
+	)
+	require.Len(*converted.Created, 1)
+	require.True(time.Time((*converted.Created)[0]).Equal(created))
+	require.True(time.Time((*converted.Attrs)[0].Timestamps["seen_at"][0]).Equal(created))
+
+	decode := func(bin []byte) (string, mapping.Mappings) {
+		var settings mapping.MappingSettings
+		require.NoError(json.Unmarshal(bin, &settings))
+		for k, v := range settings {
+			return k, *v.Mappings
+		}
+		panic("empty")
+	}
+	gen := func(m mapping.Mappings, indexName string) []generate.GeneratedType {
+		globalOpt := generate.GlobalOption{}
+		globalOpt.TypeNameGenerator.PostProcess = []generate.TypeNamePostProcessRule{
+			generate.Prefix(indexName, 1),
+			generate.PascalCaseUnderscoreHyphen(),
+		}
+		highLevelTy, _, _, err := generate.Generate(m, indexName, globalOpt, nil)
+		require.NoError(err)
+		return highLevelTy
+	}
+	oldIndex, oldMappings := decode(must(os.ReadFile("./example/migration_v1.json")))
+	newIndex, newMappings := decode(must(os.ReadFile("./example/migration_v2.json")))
+
+	plan := generate.GenerateMigration(
+		oldMappings,
+		newMappings,
+		oldIndex,
+		newIndex,
+		gen(oldMappings, oldIndex),
+		gen(newMappings, newIndex),
+		generate.MigrationOption{
+			OldImportPath: "example.com/v1",
+			NewImportPath: "example.com/v2",
+		},
+	)
+	require.True(plan.Changes.Breaking())
+	require.Equal(
+		map[string]any{
+			"source": map[string]any{"index": "migration_v1"},
+			"dest":   map[string]any{"index": "migration_v2"},
+		},
+		plan.ReindexBody,
+	)
+	require.Equal(&newMappings, plan.IndexBody.Mappings)
+	require.Equal(
+		[]generate.UnconvertibleField{
+			{Path: "attrs (dynamic template flags)", Reason: "no dynamic template in the old type"},
+			{Path: "attrs (dynamic template labels)", Reason: "no dynamic template in the new type, dropped"},
+			{Path: "ratio", Reason: "not convertible from double (float64) to float (float32)"},
+			{Path: "tags", Reason: "no field in the old type"},
+			{Path: "legacy", Reason: "no field in the new type, dropped"},
+		},
+		plan.Unconvertible,
+	)
+	require.Contains(plan.Converter[0].Imports, `"example.com/v1"`)
+	require.Contains(plan.Converter[0].TyDef, "func ConvertMigrationV1(in v1.MigrationV1) v2.MigrationV2 {")
+	require.Contains(plan.Converter[1].TyDef, "func(v v1.MigrationV1Created) estype.StrictDateOptionalTimeNanosEpochMillis")
+}
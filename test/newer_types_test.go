@@ -0,0 +1,63 @@
+package test_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewerTypes(t *testing.T) {
+	require := require.New(t)
+
+	bin := must(os.ReadFile("./example/newer_types.json"))
+	var settings mapping.MappingSettings
+	require.NoError(json.Unmarshal(bin, &settings))
+
+	var stored map[string]any
+	require.NoError(json.Unmarshal(bin, &stored))
+	require.True(cmp.Equal(stored, toAnyMap(settings)), cmp.Diff(stored, toAnyMap(settings)))
+
+	mappings := *settings["newer_types"].Mappings
+	require.NoError(mappings.Validate())
+	require.NoError(mappings.ValidateFor(mapping.StackVersion{Major: 8, Minor: 15}))
+
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(mappings.ValidateFor(mapping.DefaultStackVersion), &validationErrs)
+	var paths []string
+	for _, e := range validationErrs {
+		require.Equal(mapping.RuleVersion, e.Rule)
+		paths = append(paths, e.Path)
+	}
+	require.Equal([]string{"area", "area", "attributes", "embedding", "summary", "tags", "tokens"}, paths)
+
+	// 8.4 users are not affected: newer types are unknown by default.
+	_, _, _, err := generate.Generate(mappings, "newer_types", generate.GlobalOption{}, nil)
+	require.ErrorIs(err, generate.ErrUnknownType)
+	_, _, _, err = generate.Generate(mappings, "newer_types", generate.GlobalOption{TargetVersion: "8.x"}, nil)
+	require.Error(err)
+
+	var doc example.NewerTypesRaw
+	require.NoError(json.Unmarshal([]byte(`{
+		"message": "GET /index.html",
+		"annotated": "[Beck](Beck) announced",
+		"tokens": {"foo": 0.5, "bar": 1.25},
+		"summary": ["a", "b"],
+		"tags": ["x", "x", "y"],
+		"attributes": {"host": "web-1"},
+		"embedding": [-1, 0, 127]
+	}`), &doc))
+
+	plain := doc.ToPlain()
+	require.Equal(&[]string{"GET /index.html"}, plain.Message)
+	require.Equal(&[]map[string]float64{{"foo": 0.5, "bar": 1.25}}, plain.Tokens)
+	require.Equal(&[]string{"a", "b"}, plain.Summary)
+	require.Equal(&[]string{"x", "x", "y"}, plain.Tags)
+	require.Equal(&[]example.NewerTypesAttributes{{Host: &[]string{"web-1"}}}, plain.Attributes)
+	require.Equal(&[]example.NewerTypesEmbedding{{-1, 0, 127}}, plain.Embedding)
+}
@@ -0,0 +1,48 @@
+package test_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	esquery "github.com/ngicks/elastic-type/es_query"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateQuery(t *testing.T) {
+	require := require.New(t)
+
+	q := example.NewAllQuery("")
+
+	require.Equal("nested.name.first", q.Nested.Name.First.Path())
+	require.Equal("object", q.Object.Path())
+
+	date := example.AllDate(time.Date(2022, 10, 20, 16, 22, 46, 0, time.UTC))
+	for _, tc := range []struct {
+		query    esquery.Query
+		expected string
+	}{
+		{
+			// generated date type marshals into the format of the mapping.
+			q.Date.Range(esquery.Gte(date)),
+			`{"range":{"date":{"gte":"2022-10-20 16:22:46"}}}`,
+		},
+		{
+			q.Object.Name.Last.Match("doe", esquery.Analyzer("standard")),
+			`{"match":{"object.name.last":{"analyzer":"standard","query":"doe"}}}`,
+		},
+		{
+			q.Integer.Terms(1, 2),
+			`{"terms":{"integer":[1,2]}}`,
+		},
+		{
+			q.Kwd.Prefix("foo"),
+			`{"prefix":{"kwd":{"value":"foo"}}}`,
+		},
+	} {
+		bin, err := json.Marshal(tc.query)
+		require.NoError(err)
+		require.JSONEq(tc.expected, string(bin))
+	}
+}
@@ -0,0 +1,58 @@
+package test_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange(t *testing.T) {
+	require := require.New(t)
+
+	input := `{
+		"age": {"gte": 10, "lt": 20},
+		"price": {"gt": 1.5},
+		"period": {"gte": "2022-10-01", "lte": "2022-10-31"},
+		"updated": {"lt": 1666282966123},
+		"network": "192.168.0.0/24"
+	}`
+
+	var raw example.RangeDocRaw
+	require.NoError(json.Unmarshal([]byte(input), &raw))
+	doc := raw.ToPlain()
+
+	age := (*doc.Age)[0]
+	require.Equal(int32(10), *age.Gte)
+	require.Equal(int32(20), *age.Lt)
+	require.Equal(1.5, *(*doc.Price)[0].Gt)
+
+	// bounds of date_range are parsed in the format of the field.
+	period := (*doc.Period)[0]
+	require.True(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC).Equal(time.Time(*period.Gte)))
+	require.True(time.Date(2022, 10, 31, 0, 0, 0, 0, time.UTC).Equal(time.Time(*period.Lte)))
+	require.True(time.UnixMilli(1666282966123).Equal(time.Time(*(*doc.Updated)[0].Lt)))
+
+	network := (*doc.Network)[0]
+	require.Equal(netip.MustParsePrefix("192.168.0.0/24"), *network.CIDR)
+	require.Equal(netip.MustParseAddr("192.168.0.255"), *network.Lte)
+
+	// formatted in the local time zone.
+	updated := must(json.Marshal((*doc.Updated)[0].Lt))
+
+	bin, err := json.Marshal(raw)
+	require.NoError(err)
+	require.JSONEq(`{
+		"age": [{"gte": 10, "lt": 20}],
+		"price": [{"gt": 1.5}],
+		"period": [{"gte": "2022-10-01", "lte": "2022-10-31"}],
+		"updated": [{"lt": `+string(updated)+`}],
+		"network": ["192.168.0.0/24"]
+	}`, string(bin))
+
+	var _ estype.Range[example.RangeDocPeriodBound] = period
+}
@@ -0,0 +1,81 @@
+package test_test
+
+import (
+	"encoding/json"
+	"net/netip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntime(t *testing.T) {
+	require := require.New(t)
+
+	bin := must(os.ReadFile("./example/runtime.json"))
+	var settings mapping.MappingSettings
+	require.NoError(json.Unmarshal(bin, &settings))
+
+	var stored map[string]any
+	require.NoError(json.Unmarshal(bin, &stored))
+	require.True(cmp.Equal(stored, toAnyMap(settings)), cmp.Diff(stored, toAnyMap(settings)))
+	require.NoError(settings["access_log"].Mappings.Validate())
+
+	// A hit of the search API, requested with "fields": ["*"].
+	hit := []byte(`{
+		"_index": "access_log",
+		"_id": "1",
+		"_source": {
+			"@timestamp": "2022-10-20T16:22:46.123Z",
+			"end": "2022-10-20T16:22:47.000Z",
+			"client_ip": "192.168.0.1",
+			"port": 443
+		},
+		"fields": {
+			"@timestamp": ["2022-10-20T16:22:46.123Z"],
+			"day": ["2022-10-20"],
+			"day_of_week": ["Thursday"],
+			"duration_ms": [877],
+			"client.ip": ["192.168.0.1"],
+			"client.secure": [true]
+		}
+	}`)
+
+	var decoded struct {
+		Source example.AccessLogRaw     `json:"_source"`
+		Fields example.AccessLogRuntime `json:"fields"`
+	}
+	require.NoError(json.Unmarshal(hit, &decoded))
+
+	require.Equal(
+		example.AccessLogRuntime{
+			ClientIp:     []netip.Addr{netip.MustParseAddr("192.168.0.1")},
+			ClientSecure: []estype.Boolean{true},
+			Day:          []example.AccessLogRuntimeDay{example.AccessLogRuntimeDay(time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC))},
+			DayOfWeek:    []string{"Thursday"},
+			DurationMs:   []int64{877},
+		},
+		decoded.Fields,
+	)
+
+	var invalid mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"runtime": {
+			"a": { "type": "keyword", "format": "yyyy" },
+			"b": { "type": "composite", "fields": { "c": { "type": "lookup" } } },
+			"d": { "type": "text" }
+		}
+	}`), &invalid))
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(invalid.Validate(), &validationErrs)
+	var paths []string
+	for _, e := range validationErrs {
+		paths = append(paths, e.Path)
+	}
+	require.Equal([]string{"a", "b", "b.c", "d"}, paths)
+}
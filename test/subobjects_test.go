@@ -0,0 +1,67 @@
+package test_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	estype "github.com/ngicks/elastic-type/es_type"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test/example"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubobjects(t *testing.T) {
+	require := require.New(t)
+
+	bin := must(os.ReadFile("./example/subobjects.json"))
+	var settings mapping.MappingSettings
+	require.NoError(json.Unmarshal(bin, &settings))
+	require.NoError(settings["metrics_doc"].Mappings.Validate())
+
+	expected := example.MetricsDoc{
+		Host: &[]string{"h1"},
+		Metrics: &[]example.MetricsDocMetrics{{
+			CpuSystem: &[]float64{0.5},
+			CpuUser:   &[]float64{1.5},
+			LabelsEnv: &[]string{"prod"},
+		}},
+	}
+	for _, doc := range []string{
+		`{"host":"h1","metrics":{"cpu.user":1.5,"cpu.system":0.5,"labels.env":"prod"}}`,
+		`{"host":"h1","metrics":{"cpu":{"user":1.5,"system":0.5},"labels":{"env":"prod"}}}`,
+		`{"host":"h1","metrics":{"cpu.user":1.5,"cpu":{"system":0.5},"labels":{"env":"prod"}}}`,
+	} {
+		var raw example.MetricsDocRaw
+		require.NoError(json.Unmarshal([]byte(doc), &raw), doc)
+		require.Equal(expected, raw.ToPlain(), doc)
+	}
+
+	// marshalled in the dotted form, as the mapping defines.
+	var raw example.MetricsDocMetricsRaw
+	require.NoError(json.Unmarshal([]byte(`{"cpu":{"user":1.5}}`), &raw))
+	out, err := json.Marshal(raw)
+	require.NoError(err)
+	require.JSONEq(`{"cpu.user":[1.5]}`, string(out))
+
+	err = json.Unmarshal([]byte(`{"cpu.user":1.5,"cpu":{"user":2}}`), &raw)
+	var conflict *estype.KeyConflictError
+	require.ErrorAs(err, &conflict)
+	require.Equal("cpu.user", conflict.Key)
+
+	require.Equal("metrics.cpu.user", example.MetricsDocFields.Metrics.CpuUser.Path())
+
+	var invalid mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"properties": {
+			"metrics": {
+				"subobjects": false,
+				"properties": {"cpu": {"properties": {"user": {"type": "double"}}}}
+			}
+		}
+	}`), &invalid))
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(invalid.Validate(), &validationErrs)
+	require.Equal(mapping.RuleSubobjects, validationErrs[0].Rule)
+	require.Equal("metrics.cpu", validationErrs[0].Path)
+}
@@ -0,0 +1,152 @@
+package test_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate(t *testing.T) {
+	require := require.New(t)
+
+	var templates mapping.IndexTemplatesResponse
+	require.NoError(json.Unmarshal(must(os.ReadFile("./example/index_template.json")), &templates))
+	var components mapping.ComponentTemplatesResponse
+	require.NoError(json.Unmarshal(must(os.ReadFile("./example/component_template.json")), &components))
+
+	name, _, ok := templates.Match("other-index")
+	require.True(ok)
+	require.Equal("template_fallback", name)
+
+	name, template, ok := templates.Match("logs-app")
+	require.True(ok)
+	require.Equal("template_logs", name)
+
+	composed, err := template.Compose(components.Map())
+	require.NoError(err)
+
+	var expected mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"dynamic": "strict",
+		"properties": {
+			"@timestamp": { "type": "date" },
+			"host": {
+				"properties": {
+					"ip": { "type": "ip" },
+					"name": { "type": "keyword" }
+				}
+			},
+			"level": { "type": "keyword" },
+			"message": {
+				"type": "text",
+				"fields": {
+					"keyword": { "type": "keyword", "ignore_above": 256 }
+				}
+			}
+		}
+	}`), &expected))
+	require.Equal(toAnyMap(expected), toAnyMap(composed))
+
+	// inputs are not modified.
+	base := components.Map()["template_base"]
+	require.NotContains(*(*base.Template.Mappings.Properties)["host"].Param.(*mapping.ObjectParams).Properties, "ip")
+
+	_, err = template.Compose(map[string]mapping.ComponentTemplate{})
+	require.ErrorContains(err, "missing component templates: template_base, template_host")
+
+	// sub-properties of passthrough objects are merged as object and nested ones are.
+	var passthroughComponents map[string]mapping.ComponentTemplate
+	require.NoError(json.Unmarshal([]byte(`{
+		"attrs_a": {
+			"template": {
+				"mappings": {
+					"properties": {
+						"attributes": {
+							"type": "passthrough",
+							"priority": 10,
+							"properties": { "a": { "type": "keyword" } }
+						}
+					}
+				}
+			}
+		},
+		"attrs_b": {
+			"template": {
+				"mappings": {
+					"properties": {
+						"attributes": {
+							"type": "passthrough",
+							"time_series_dimension": true,
+							"properties": { "b": { "type": "long" } }
+						}
+					}
+				}
+			}
+		}
+	}`), &passthroughComponents))
+	composed, err = mapping.IndexTemplate{ComposedOf: []string{"attrs_a", "attrs_b"}}.Compose(passthroughComponents)
+	require.NoError(err)
+	var expectedPassthrough mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"properties": {
+			"attributes": {
+				"type": "passthrough",
+				"priority": 10,
+				"time_series_dimension": true,
+				"properties": {
+					"a": { "type": "keyword" },
+					"b": { "type": "long" }
+				}
+			}
+		}
+	}`), &expectedPassthrough))
+	require.Equal(toAnyMap(expectedPassthrough), toAnyMap(composed))
+
+	// _meta is merged recursively, and params of _source are merged.
+	var metaComponents map[string]mapping.ComponentTemplate
+	require.NoError(json.Unmarshal([]byte(`{
+		"meta_a": {
+			"template": {
+				"mappings": {
+					"_meta": {
+						"owner": "team-a",
+						"version": 1,
+						"labels": { "env": "prod", "tier": "backend" }
+					},
+					"_source": { "excludes": ["secret"] }
+				}
+			}
+		},
+		"meta_b": {
+			"template": {
+				"mappings": {
+					"_meta": {
+						"version": 2,
+						"labels": { "tier": "frontend", "region": "eu" }
+					},
+					"_source": { "includes": ["*"] }
+				}
+			}
+		}
+	}`), &metaComponents))
+	composed, err = mapping.IndexTemplate{ComposedOf: []string{"meta_a", "meta_b"}}.Compose(metaComponents)
+	require.NoError(err)
+	var expectedMeta mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"_meta": {
+			"owner": "team-a",
+			"version": 2,
+			"labels": { "env": "prod", "tier": "frontend", "region": "eu" }
+		},
+		"_source": { "includes": ["*"], "excludes": ["secret"] }
+	}`), &expectedMeta))
+	require.Equal(toAnyMap(expectedMeta), toAnyMap(composed))
+	// inputs are not modified.
+	require.Equal(
+		map[string]any{"env": "prod", "tier": "backend"},
+		metaComponents["meta_a"].Template.Mappings.Meta["labels"],
+	)
+}
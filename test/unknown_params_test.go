@@ -0,0 +1,119 @@
+package test_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/stretchr/testify/require"
+)
+
+const unknownParamsMapping = `{
+	"metrics": {
+		"mappings": {
+			"date_detection": false,
+			"numeric_detection": true,
+			"dynamic": "strict",
+			"properties": {
+				"cpu": {"type": "double", "time_series_metric": "gauge"},
+				"host": {
+					"type": "keyword",
+					"time_series_dimension": true,
+					"synthetic_source_keep": "arrays",
+					"fields": {
+						"text": {"type": "text", "plugin_param": {"a": [1, 2]}}
+					}
+				},
+				"labels": {
+					"type": "object",
+					"dimensions": ["a", "b"],
+					"properties": {
+						"name": {"type": "keyword"}
+					}
+				},
+				"plugin": {"type": "no_such_type", "some_param": 1}
+			}
+		}
+	}
+}`
+
+func TestUnknownParams(t *testing.T) {
+	require := require.New(t)
+
+	var settings mapping.MappingSettings
+	require.NoError(json.Unmarshal([]byte(unknownParamsMapping), &settings))
+	m := settings["metrics"].Mappings
+
+	require.Equal(map[string]json.RawMessage{
+		"date_detection":    json.RawMessage(`false`),
+		"numeric_detection": json.RawMessage(`true`),
+	}, m.Unknown)
+
+	props := *m.Properties
+	require.Equal(map[string]json.RawMessage{"time_series_metric": json.RawMessage(`"gauge"`)}, props["cpu"].Unknown)
+	// time_series_dimension is a modeled param.
+	require.Equal(map[string]json.RawMessage{"synthetic_source_keep": json.RawMessage(`"arrays"`)}, props["host"].Unknown)
+	require.Equal(map[string]json.RawMessage{"plugin_param": json.RawMessage(`{"a": [1, 2]}`)}, props["host"].MultiFields()["text"].Unknown)
+	require.Equal(map[string]json.RawMessage{"dimensions": json.RawMessage(`["a", "b"]`)}, props["labels"].Unknown)
+	require.Nil((*props["labels"].Param.(*mapping.ObjectParams).Properties)["name"].Unknown)
+	// UnknownParams keeps everything by itself.
+	require.Nil(props["plugin"].Unknown)
+
+	bin, err := json.Marshal(settings)
+	require.NoError(err)
+	require.JSONEq(unknownParamsMapping, string(bin))
+
+	// read-modify-write keeps unknown params.
+	coerce := false
+	props["cpu"].Param.(*mapping.NumericParams).Coerce = &coerce
+	bin, err = json.Marshal(props["cpu"])
+	require.NoError(err)
+	require.JSONEq(`{"type": "double", "coerce": false, "time_series_metric": "gauge"}`, string(bin))
+}
+
+func TestUnknownParamsDiffAndMerge(t *testing.T) {
+	require := require.New(t)
+
+	var old, new mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"date_detection": false,
+		"properties": {
+			"cpu": {"type": "double", "time_series_metric": "gauge"},
+			"labels": {"properties": {"name": {"type": "keyword"}}, "dimensions": ["a"]}
+		}
+	}`), &old))
+	require.NoError(json.Unmarshal([]byte(`{
+		"date_detection": false,
+		"properties": {
+			"cpu": {"type": "double", "time_series_metric": "counter"},
+			"labels": {"properties": {"name": {"type": "keyword"}}, "dimensions": ["a"], "extra": 1}
+		}
+	}`), &new))
+
+	require.Equal(mapping.Changes{
+		{Path: "cpu", Kind: mapping.Breaking, Param: "time_series_metric", Old: "gauge", New: "counter"},
+		{Path: "labels", Kind: mapping.Breaking, Param: "extra", New: float64(1)},
+	}, mapping.Diff(old, new))
+	require.Empty(mapping.Diff(old, old))
+
+	var overlay mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"numeric_detection": true,
+		"properties": {
+			"labels": {"properties": {"id": {"type": "long"}}, "dimensions": ["b"]}
+		}
+	}`), &overlay))
+	bin, err := json.Marshal(mapping.MergeMappings(old, overlay))
+	require.NoError(err)
+	require.JSONEq(`{
+		"date_detection": false,
+		"numeric_detection": true,
+		"properties": {
+			"cpu": {"type": "double", "time_series_metric": "gauge"},
+			"labels": {
+				"properties": {"name": {"type": "keyword"}, "id": {"type": "long"}},
+				"dimensions": ["b"]
+			}
+		}
+	}`, string(bin))
+}
@@ -0,0 +1,70 @@
+package test_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ngicks/elastic-type/generate"
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/stretchr/testify/require"
+)
+
+type testPluginParams struct {
+	Type     mapping.EsType `json:"type"`
+	Analyzer string         `json:"analyzer,omitempty"`
+	// ScalingFactor is int unlike scaled_float, to test that builder options do not convert values.
+	ScalingFactor *int `json:"scaling_factor,omitempty"`
+}
+
+func init() {
+	mapping.RegisterType("test_plugin", func() any { return &testPluginParams{} })
+	generate.RegisterType("test_plugin", generate.GeneratedType{TyName: "string"})
+}
+
+func TestUnknownType(t *testing.T) {
+	require := require.New(t)
+
+	bin := []byte(`{
+		"properties": {
+			"plugin": { "type": "test_plugin", "analyzer": "foo" },
+			"unknown": { "type": "some_new_type", "dims": 3, "nested": { "a": [1, 2] } }
+		}
+	}`)
+
+	var mappings mapping.Mappings
+	require.NoError(json.Unmarshal(bin, &mappings))
+
+	var stored map[string]any
+	require.NoError(json.Unmarshal(bin, &stored))
+	require.True(cmp.Equal(stored, toAnyMap(mappings)), cmp.Diff(stored, toAnyMap(mappings)))
+
+	props := *mappings.Properties
+	require.Equal(mapping.EsType("test_plugin"), props["plugin"].Type)
+	require.Equal(&testPluginParams{Type: "test_plugin", Analyzer: "foo"}, props["plugin"].Param)
+	require.Equal(mapping.EsType("some_new_type"), props["unknown"].Type)
+	require.IsType(&mapping.UnknownParams{}, props["unknown"].Param)
+
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(mappings.Validate(), &validationErrs)
+	require.Len(validationErrs, 1)
+	require.Equal("unknown", validationErrs[0].Path)
+	require.Equal(mapping.RuleUnknownType, validationErrs[0].Rule)
+
+	_, _, _, err := generate.Generate(mappings, "plugin_index", generate.GlobalOption{}, nil)
+	require.ErrorIs(err, generate.ErrUnknownType)
+	require.Contains(err.Error(), `"unknown"`)
+
+	_, rawTy, _, err := generate.Generate(
+		mappings,
+		"plugin_index",
+		generate.GlobalOption{UnknownTypeAsRaw: generate.True},
+		nil,
+	)
+	require.NoError(err)
+	require.True(strings.Contains(rawTy[0].TyDef, "estype.Field[string]"), rawTy[0].TyDef)
+	require.True(strings.Contains(rawTy[0].TyDef, "estype.Field[json.RawMessage]"), rawTy[0].TyDef)
+
+	require.Panics(func() { mapping.RegisterType(mapping.Keyword, func() any { return &testPluginParams{} }) })
+}
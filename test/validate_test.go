@@ -0,0 +1,147 @@
+package test_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/ngicks/elastic-type/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	for _, bin := range [][]byte{
+		test.AllMappings,
+		test.ExampleMappings,
+		test.ObjectInheritanceMappings,
+		test.ObjectWOverlapMappings,
+		test.ObjectMappings,
+	} {
+		var settings mapping.IndexSettings
+		require.NoError(json.Unmarshal(bin, &settings))
+		require.NoError(settings.Mappings.Validate())
+	}
+
+	var mappings mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"dynamic": "yes",
+		"properties": {
+			"alias": { "type": "alias" },
+			"vec": { "type": "dense_vector", "dims": 0 },
+			"scaled": { "type": "scaled_float" },
+			"obj": {
+				"properties": {
+					"unknown": { "type": "no_such_type" },
+					"txt": {
+						"type": "text",
+						"fields": {
+							"sayt": { "type": "search_as_you_type", "max_shingle_size": 5 }
+						}
+					}
+				}
+			},
+			"join_1": {
+				"type": "join",
+				"relations": { "a": "b", "b": ["c", "a"] }
+			},
+			"join_2": {
+				"type": "join",
+				"relations": { "x": "y", "z": "y" }
+			},
+			"join_3": {
+				"type": "join",
+				"relations": { "p": ["q", 1] }
+			},
+			"metric": {
+				"type": "aggregate_metric_double",
+				"metrics": ["min", "max"],
+				"default_metric": "sum"
+			}
+		}
+	}`), &mappings))
+
+	err := mappings.Validate()
+	require.Error(err)
+
+	var validationErrs mapping.ValidationErrors
+	require.ErrorAs(err, &validationErrs)
+
+	type pathRule struct {
+		Path string
+		Rule mapping.ValidationRule
+	}
+	var found []pathRule
+	for _, e := range validationErrs {
+		found = append(found, pathRule{e.Path, e.Rule})
+	}
+	require.Equal(
+		[]pathRule{
+			{"", mapping.RuleDynamic},
+			{"alias", mapping.RuleAliasPath},
+			{"join_1", mapping.RuleJoinRelations},
+			{"join_2", mapping.RuleJoinRelations},
+			{"join_3", mapping.RuleJoinRelations},
+			{"metric", mapping.RuleAggregateMetricDouble},
+			{"obj.txt.sayt", mapping.RuleMaxShingleSize},
+			{"obj.unknown", mapping.RuleUnknownType},
+			{"scaled", mapping.RuleScalingFactor},
+			{"vec", mapping.RuleDenseVectorDims},
+			{"", mapping.RuleJoinUnique},
+		},
+		found,
+	)
+}
+
+func TestValidateDenseVectorDims(t *testing.T) {
+	require := require.New(t)
+
+	decode := func(s string) mapping.Mappings {
+		var m mapping.Mappings
+		require.NoError(json.Unmarshal([]byte(s), &m))
+		return m
+	}
+	rulesOf := func(err error) []mapping.ValidationRule {
+		var errs mapping.ValidationErrors
+		require.ErrorAs(err, &errs)
+		var rules []mapping.ValidationRule
+		for _, e := range errs {
+			rules = append(rules, e.Rule)
+		}
+		return rules
+	}
+	v8_11 := mapping.StackVersion{Major: 8, Minor: 11}
+	v8_15 := mapping.StackVersion{Major: 8, Minor: 15}
+
+	// dims is required and at most 2048 before 8.11.
+	omitted := decode(`{"properties": {"vec": {"type": "dense_vector"}}}`)
+	require.Equal([]mapping.ValidationRule{mapping.RuleDenseVectorDims}, rulesOf(omitted.Validate()))
+	require.NoError(omitted.ValidateFor(v8_11))
+
+	large := decode(`{"properties": {"vec": {"type": "dense_vector", "dims": 4096}}}`)
+	require.Equal([]mapping.ValidationRule{mapping.RuleDenseVectorDims}, rulesOf(large.Validate()))
+	require.NoError(large.ValidateFor(v8_11))
+
+	tooLarge := decode(`{"properties": {"vec": {"type": "dense_vector", "dims": 4097}}}`)
+	require.Equal([]mapping.ValidationRule{mapping.RuleDenseVectorDims}, rulesOf(tooLarge.ValidateFor(v8_11)))
+
+	// bit elements must be a multiple of 8.
+	bits := decode(`{"properties": {"vec": {"type": "dense_vector", "element_type": "bit", "dims": 12}}}`)
+	require.Equal([]mapping.ValidationRule{mapping.RuleDenseVectorDims}, rulesOf(bits.ValidateFor(v8_15)))
+	bits = decode(`{"properties": {"vec": {"type": "dense_vector", "element_type": "bit", "dims": 16}}}`)
+	require.NoError(bits.ValidateFor(v8_15))
+
+	// index and doc_values of geo_shape are added in 8.6.
+	shape := decode(`{"properties": {"shape": {"type": "geo_shape", "index": false, "doc_values": true}}}`)
+	require.Equal(
+		[]mapping.ValidationRule{mapping.RuleVersion, mapping.RuleVersion},
+		rulesOf(shape.ValidateFor(mapping.StackVersion{Major: 8, Minor: 5})),
+	)
+	require.NoError(shape.ValidateFor(mapping.StackVersion{Major: 8, Minor: 6}))
+
+	// omitted dims is kept omitted.
+	bin, err := json.Marshal(omitted)
+	require.NoError(err)
+	require.JSONEq(`{"properties": {"vec": {"type": "dense_vector"}}}`, string(bin))
+}
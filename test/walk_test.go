@@ -0,0 +1,137 @@
+package test_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ngicks/elastic-type/mapping"
+	"github.com/stretchr/testify/require"
+)
+
+const walkMapping = `{
+	"properties": {
+		"user": {
+			"properties": {
+				"name": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+				"age": {"type": "integer"}
+			}
+		},
+		"comments": {
+			"type": "nested",
+			"properties": {
+				"body": {"type": "text"},
+				"replies": {
+					"type": "nested",
+					"properties": {"body": {"type": "text"}}
+				},
+				"meta": {"properties": {"score": {"type": "float"}}}
+			}
+		},
+		"tag": {"type": "keyword"}
+	}
+}`
+
+func walkProps(t *testing.T) mapping.Properties {
+	t.Helper()
+	var m mapping.Mappings
+	require.NoError(t, json.Unmarshal([]byte(walkMapping), &m))
+	return *m.Properties
+}
+
+func TestWalk(t *testing.T) {
+	require := require.New(t)
+	props := walkProps(t)
+
+	var visited []string
+	require.NoError(mapping.Walk(props, func(path []string, prop mapping.Property, kind mapping.WalkKind) error {
+		visited = append(visited, strings.Join(path, ".")+":"+kind.String())
+		return nil
+	}))
+	require.Equal([]string{
+		"comments:nested",
+		"comments.body:field",
+		"comments.meta:object",
+		"comments.meta.score:field",
+		"comments.replies:nested",
+		"comments.replies.body:field",
+		"tag:field",
+		"user:object",
+		"user.age:field",
+		"user.name:field",
+		"user.name.keyword:multi_field",
+	}, visited)
+
+	visited = nil
+	require.NoError(mapping.Walk(props, func(path []string, prop mapping.Property, kind mapping.WalkKind) error {
+		visited = append(visited, strings.Join(path, "."))
+		if kind == mapping.WalkNested {
+			return mapping.SkipSubtree
+		}
+		if path[0] == "user" && len(path) == 2 {
+			return mapping.SkipAll
+		}
+		return nil
+	}))
+	require.Equal([]string{"comments", "tag", "user", "user.age"}, visited)
+
+	errStop := errors.New("stop")
+	err := mapping.Walk(props, func(path []string, prop mapping.Property, kind mapping.WalkKind) error {
+		return errStop
+	})
+	require.ErrorIs(err, errStop)
+}
+
+func TestFlatten(t *testing.T) {
+	require := require.New(t)
+	flat := walkProps(t).Flatten()
+
+	require.Len(flat, 11)
+	require.Equal(mapping.WalkMultiField, flat["user.name.keyword"].Kind)
+	require.Equal(mapping.Keyword, flat["user.name.keyword"].Type)
+	require.Equal("", flat["user.name.keyword"].NestedPath)
+	require.Equal("", flat["comments"].NestedPath)
+	require.Equal("comments", flat["comments.body"].NestedPath)
+	require.Equal("comments", flat["comments.meta.score"].NestedPath)
+	require.Equal("comments", flat["comments.replies"].NestedPath)
+	require.Equal("comments.replies", flat["comments.replies.body"].NestedPath)
+	require.Equal("", flat["tag"].NestedPath)
+}
+
+func TestLookup(t *testing.T) {
+	require := require.New(t)
+	props := walkProps(t)
+
+	prop, ok := props.Lookup("user.name.keyword")
+	require.True(ok)
+	require.Equal(mapping.Keyword, prop.Type)
+
+	prop, ok = props.Lookup("comments.replies.body")
+	require.True(ok)
+	require.Equal(mapping.Text, prop.Type)
+
+	prop, ok = props.Lookup("comments")
+	require.True(ok)
+	require.Equal(mapping.Nested, prop.Type)
+
+	for _, path := range []string{"", "user.nope", "user.name.keyword.more", "tag.keyword", "user."} {
+		_, ok = props.Lookup(path)
+		require.False(ok, path)
+	}
+
+	// names containing dots.
+	var dotted mapping.Mappings
+	require.NoError(json.Unmarshal([]byte(`{
+		"properties": {
+			"host.name": {"type": "keyword"},
+			"host": {"properties": {"ip": {"type": "ip"}}}
+		}
+	}`), &dotted))
+	prop, ok = dotted.Properties.Lookup("host.name")
+	require.True(ok)
+	require.Equal(mapping.Keyword, prop.Type)
+	prop, ok = dotted.Properties.Lookup("host.ip")
+	require.True(ok)
+	require.Equal(mapping.IP, prop.Type)
+}